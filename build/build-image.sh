@@ -22,6 +22,7 @@ ROOT="$(cd "$(dirname "${BASH_SOURCE[0]}")"/.. >/dev/null && pwd)"
 CORTEX_VERSION=master
 
 slim="false"
+runtimes=()
 while [[ $# -gt 0 ]]; do
   key="$1"
   case $key in
@@ -29,6 +30,11 @@ while [[ $# -gt 0 ]]; do
     slim="true"
     shift
     ;;
+    --runtime)
+    runtimes+=("$2")
+    shift
+    shift
+    ;;
     *)
     positional_args+=("$1")
     shift
@@ -45,6 +51,11 @@ docker build "$ROOT" \
     -t cortexlabs/${image} \
     -t cortexlabs/${image}:${CORTEX_VERSION}
 
+# runtime tags (see RuntimeCatalog in pkg/types/userconfig/runtime.go)
+for runtime in "${runtimes[@]+"${runtimes[@]}"}"; do
+  docker tag cortexlabs/${image}:${CORTEX_VERSION} cortexlabs/${image}:${CORTEX_VERSION}-${runtime}
+done
+
 if [ "$slim" == "true" ]; then
     docker build "$ROOT" \
         -f $dir/Dockerfile \
@@ -20,6 +20,7 @@ set -euo pipefail
 CORTEX_VERSION=master
 
 slim="false"
+runtimes=()
 while [[ $# -gt 0 ]]; do
   key="$1"
   case $key in
@@ -27,6 +28,11 @@ while [[ $# -gt 0 ]]; do
     slim="true"
     shift
     ;;
+    --runtime)
+    runtimes+=("$2")
+    shift
+    shift
+    ;;
     *)
     positional_args+=("$1")
     shift
@@ -41,6 +47,16 @@ echo "$DOCKER_PASSWORD" | docker login -u "$DOCKER_USERNAME" --password-stdin
 
 docker push cortexlabs/${image}:${CORTEX_VERSION}
 
+# runtime tags are immutable once they have been pushed for a release (see RuntimeCatalog in pkg/types/userconfig/runtime.go)
+for runtime in "${runtimes[@]+"${runtimes[@]}"}"; do
+  runtime_image=cortexlabs/${image}:${CORTEX_VERSION}-${runtime}
+  if [ "$CORTEX_VERSION" != "master" ] && DOCKER_CLI_EXPERIMENTAL=enabled docker manifest inspect $runtime_image >/dev/null 2>&1; then
+    echo "error: $runtime_image has already been pushed and runtime tags can't be overwritten"
+    exit 1
+  fi
+  docker push $runtime_image
+done
+
 if [ "$slim" == "true" ]; then
   docker push cortexlabs/${image}-slim:${CORTEX_VERSION}
 fi
@@ -18,6 +18,7 @@ package cluster
 
 import (
 	"path/filepath"
+	"time"
 
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/json"
@@ -25,10 +26,18 @@ import (
 	"github.com/cortexlabs/cortex/pkg/operator/schema"
 )
 
-func Deploy(operatorConfig OperatorConfig, configPath string, deploymentBytesMap map[string][]byte, force bool) (schema.DeployResponse, error) {
+// ttl should be nil if the APIs should not expire, and previewBranch should be empty unless deploying a preview
+func Deploy(operatorConfig OperatorConfig, configPath string, deploymentBytesMap map[string][]byte, force bool, ttl *time.Duration, previewBranch string, checkDeps bool) (schema.DeployResponse, error) {
 	params := map[string]string{
-		"force":          s.Bool(force),
-		"configFileName": filepath.Base(configPath),
+		"force":             s.Bool(force),
+		"configFileName":    filepath.Base(configPath),
+		"checkDependencies": s.Bool(checkDeps),
+	}
+	if ttl != nil {
+		params["ttl"] = ttl.String()
+	}
+	if previewBranch != "" {
+		params["preview"] = previewBranch
 	}
 	uploadInput := &HTTPUploadInput{
 		Bytes: deploymentBytesMap,
@@ -0,0 +1,49 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/json"
+	s "github.com/cortexlabs/cortex/pkg/lib/strings"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+)
+
+// duration should be nil to remove the api's expiration
+func Extend(operatorConfig OperatorConfig, apiName string, duration *time.Duration) (schema.ExtendResponse, error) {
+	params := map[string]string{
+		"remove": s.Bool(duration == nil),
+	}
+	if duration != nil {
+		params["duration"] = duration.String()
+	}
+
+	httpRes, err := HTTPPostNoBody(operatorConfig, "/extend/"+apiName, params)
+	if err != nil {
+		return schema.ExtendResponse{}, err
+	}
+
+	var extendRes schema.ExtendResponse
+	err = json.Unmarshal(httpRes, &extendRes)
+	if err != nil {
+		return schema.ExtendResponse{}, errors.Wrap(err, "/extend", string(httpRes))
+	}
+
+	return extendRes, nil
+}
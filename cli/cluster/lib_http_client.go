@@ -18,18 +18,19 @@ package cluster
 
 import (
 	"bytes"
-	"crypto/tls"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/cortexlabs/cortex/pkg/consts"
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/files"
 	"github.com/cortexlabs/cortex/pkg/lib/json"
+	"github.com/cortexlabs/cortex/pkg/lib/transport"
 	"github.com/cortexlabs/cortex/pkg/lib/zip"
 	"github.com/cortexlabs/cortex/pkg/operator/schema"
 )
@@ -51,12 +52,22 @@ func (oc OperatorConfig) AuthHeader() string {
 	return fmt.Sprintf("CortexAWS %s|%s", oc.AWSAccessKeyID, oc.AWSSecretAccessKey)
 }
 
+type cachedResponse struct {
+	etag string
+	body []byte
+}
+
+var (
+	// the operator identifies the responses to GET requests with ETags, so that responses which haven't changed since the
+	// last request (e.g. in `cortex get --watch`) aren't re-sent
+	_responseCache    = map[string]cachedResponse{} // environment and URL -> response
+	_responseCacheMux sync.Mutex
+)
+
 var _operatorClient = &OperatorClient{
 	Client: &http.Client{
-		Timeout: 600 * time.Second,
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		},
+		Timeout:   600 * time.Second,
+		Transport: transport.NewInsecure(),
 	},
 }
 
@@ -186,6 +197,15 @@ func operatorRequest(operatorConfig OperatorConfig, method string, endpoint stri
 }
 
 func (client *OperatorClient) MakeRequest(operatorConfig OperatorConfig, request *http.Request) ([]byte, error) {
+	cacheKey := operatorConfig.EnvName + " " + request.URL.String()
+	var cached *cachedResponse
+	if request.Method == http.MethodGet {
+		cached = getCachedResponse(cacheKey)
+		if cached != nil {
+			request.Header.Set("If-None-Match", cached.etag)
+		}
+	}
+
 	if operatorConfig.Telemetry {
 		values := request.URL.Query()
 		values.Set("clientID", operatorConfig.ClientID)
@@ -201,6 +221,10 @@ func (client *OperatorClient) MakeRequest(operatorConfig OperatorConfig, request
 	}
 	defer response.Body.Close()
 
+	if response.StatusCode == http.StatusNotModified && cached != nil {
+		return cached.body, nil
+	}
+
 	if response.StatusCode != 200 {
 		bodyBytes, err := ioutil.ReadAll(response.Body)
 		if err != nil {
@@ -224,5 +248,26 @@ func (client *OperatorClient) MakeRequest(operatorConfig OperatorConfig, request
 	if err != nil {
 		return nil, errors.Wrap(err, _errStrRead)
 	}
+
+	if etag := response.Header.Get("ETag"); etag != "" && request.Method == http.MethodGet {
+		setCachedResponse(cacheKey, cachedResponse{etag: etag, body: bodyBytes})
+	}
+
 	return bodyBytes, nil
 }
+
+func getCachedResponse(key string) *cachedResponse {
+	_responseCacheMux.Lock()
+	defer _responseCacheMux.Unlock()
+
+	if response, ok := _responseCache[key]; ok {
+		return &response
+	}
+	return nil
+}
+
+func setCachedResponse(key string, response cachedResponse) {
+	_responseCacheMux.Lock()
+	defer _responseCacheMux.Unlock()
+	_responseCache[key] = response
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/json"
+	s "github.com/cortexlabs/cortex/pkg/lib/strings"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+)
+
+func LoadTest(operatorConfig OperatorConfig, apiName string, payload []byte, rps int, durationSecs int) (*schema.LoadTestResponse, error) {
+	params := map[string]string{
+		"rps":      s.Int(rps),
+		"duration": s.Int(durationSecs),
+	}
+
+	httpRes, err := HTTPPostJSON(operatorConfig, "/loadtest/"+apiName, payload, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var loadTestRes schema.LoadTestResponse
+	err = json.Unmarshal(httpRes, &loadTestRes)
+	if err != nil {
+		return nil, errors.Wrap(err, "/loadtest", string(httpRes))
+	}
+
+	return &loadTestRes, nil
+}
+
+func GetLoadTest(operatorConfig OperatorConfig, apiName string, jobID string) (*schema.LoadTestResponse, error) {
+	httpRes, err := HTTPGet(operatorConfig, "/loadtest/"+apiName+"/"+jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	var loadTestRes schema.LoadTestResponse
+	err = json.Unmarshal(httpRes, &loadTestRes)
+	if err != nil {
+		return nil, errors.Wrap(err, "/loadtest", string(httpRes))
+	}
+
+	return &loadTestRes, nil
+}
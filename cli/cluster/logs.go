@@ -17,7 +17,6 @@ limitations under the License.
 package cluster
 
 import (
-	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -29,6 +28,7 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/exit"
 	"github.com/cortexlabs/cortex/pkg/lib/json"
+	"github.com/cortexlabs/cortex/pkg/lib/transport"
 	"github.com/cortexlabs/cortex/pkg/operator/schema"
 	"github.com/gorilla/websocket"
 )
@@ -55,8 +55,11 @@ func StreamLogs(operatorConfig OperatorConfig, apiName string) error {
 	header.Set("Authorization", operatorConfig.AuthHeader())
 	header.Set("CortexAPIVersion", consts.CortexVersion)
 
+	tlsConfig := transport.TLSConfig()
+	tlsConfig.InsecureSkipVerify = true
 	var dialer = websocket.Dialer{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: tlsConfig,
 	}
 
 	connection, response, err := dialer.Dial(wsURL, header)
@@ -0,0 +1,48 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/json"
+	s "github.com/cortexlabs/cortex/pkg/lib/strings"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+)
+
+// maintenance should be nil to disable maintenance mode
+func SetMaintenance(operatorConfig OperatorConfig, apiName string, maintenance *schema.Maintenance) (schema.MaintenanceResponse, error) {
+	params := map[string]string{
+		"enabled": s.Bool(maintenance != nil),
+	}
+	if maintenance != nil {
+		params["status_code"] = s.Int(maintenance.StatusCode)
+		params["message"] = maintenance.Message
+	}
+
+	httpRes, err := HTTPPostNoBody(operatorConfig, "/maintenance/"+apiName, params)
+	if err != nil {
+		return schema.MaintenanceResponse{}, err
+	}
+
+	var maintenanceRes schema.MaintenanceResponse
+	err = json.Unmarshal(httpRes, &maintenanceRes)
+	if err != nil {
+		return schema.MaintenanceResponse{}, errors.Wrap(err, "/maintenance", string(httpRes))
+	}
+
+	return maintenanceRes, nil
+}
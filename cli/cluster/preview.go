@@ -0,0 +1,53 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/json"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+)
+
+func GetPreviews(operatorConfig OperatorConfig) (schema.GetPreviewsResponse, error) {
+	httpRes, err := HTTPGet(operatorConfig, "/previews")
+	if err != nil {
+		return schema.GetPreviewsResponse{}, err
+	}
+
+	var previewsRes schema.GetPreviewsResponse
+	if err = json.Unmarshal(httpRes, &previewsRes); err != nil {
+		return schema.GetPreviewsResponse{}, errors.Wrap(err, "/previews", string(httpRes))
+	}
+	return previewsRes, nil
+}
+
+func DeletePreview(operatorConfig OperatorConfig, branch string) (schema.DeleteResponse, error) {
+	params := map[string]string{
+		"branch": branch,
+	}
+
+	httpRes, err := HTTPDelete(operatorConfig, "/previews", params)
+	if err != nil {
+		return schema.DeleteResponse{}, err
+	}
+
+	var deleteRes schema.DeleteResponse
+	if err = json.Unmarshal(httpRes, &deleteRes); err != nil {
+		return schema.DeleteResponse{}, errors.Wrap(err, "/previews", string(httpRes))
+	}
+	return deleteRes, nil
+}
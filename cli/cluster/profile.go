@@ -0,0 +1,30 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	s "github.com/cortexlabs/cortex/pkg/lib/strings"
+)
+
+func ProfileOperator(operatorConfig OperatorConfig, profileName string, seconds int) ([]byte, error) {
+	params := map[string]string{}
+	if profileName == "cpu" {
+		params["seconds"] = s.Int(seconds)
+	}
+
+	return HTTPGet(operatorConfig, "/profile/"+profileName, params)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/json"
+	s "github.com/cortexlabs/cortex/pkg/lib/strings"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+)
+
+// start and end are optional (the operator defaults to the last hour)
+func Replay(operatorConfig OperatorConfig, apiName string, targetAPIName string, start *time.Time, end *time.Time, limit int) (*schema.ReplayResponse, error) {
+	params := map[string]string{
+		"target": targetAPIName,
+		"limit":  s.Int(limit),
+	}
+	if start != nil {
+		params["start"] = start.Format(time.RFC3339)
+	}
+	if end != nil {
+		params["end"] = end.Format(time.RFC3339)
+	}
+
+	httpRes, err := HTTPPostNoBody(operatorConfig, "/replay/"+apiName, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var replayRes schema.ReplayResponse
+	err = json.Unmarshal(httpRes, &replayRes)
+	if err != nil {
+		return nil, errors.Wrap(err, "/replay", string(httpRes))
+	}
+
+	return &replayRes, nil
+}
+
+func GetReplay(operatorConfig OperatorConfig, apiName string, replayID string) (*schema.ReplayResponse, error) {
+	httpRes, err := HTTPGet(operatorConfig, "/replay/"+apiName+"/"+replayID)
+	if err != nil {
+		return nil, err
+	}
+
+	var replayRes schema.ReplayResponse
+	err = json.Unmarshal(httpRes, &replayRes)
+	if err != nil {
+		return nil, errors.Wrap(err, "/replay", string(httpRes))
+	}
+
+	return &replayRes, nil
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/json"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+)
+
+// start and end are optional (the operator defaults to the last 24 hours)
+func GetUsage(operatorConfig OperatorConfig, start *time.Time, end *time.Time) (schema.UsageResponse, error) {
+	params := map[string]string{}
+	if start != nil {
+		params["start"] = start.Format(time.RFC3339)
+	}
+	if end != nil {
+		params["end"] = end.Format(time.RFC3339)
+	}
+
+	httpRes, err := HTTPGet(operatorConfig, "/usage", params)
+	if err != nil {
+		return schema.UsageResponse{}, err
+	}
+
+	var usageRes schema.UsageResponse
+	if err = json.Unmarshal(httpRes, &usageRes); err != nil {
+		return schema.UsageResponse{}, errors.Wrap(err, "/usage", string(httpRes))
+	}
+
+	return usageRes, nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cortexlabs/cortex/cli/cluster"
+	"github.com/cortexlabs/cortex/pkg/lib/exit"
+	"github.com/cortexlabs/cortex/pkg/lib/files"
+	"github.com/cortexlabs/cortex/pkg/lib/slices"
+	"github.com/cortexlabs/cortex/pkg/lib/telemetry"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+	"github.com/cortexlabs/cortex/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	_flagAccessReportEnv    string
+	_flagAccessReportStart  string
+	_flagAccessReportEnd    string
+	_flagAccessReportFormat string
+	_flagAccessReportOutput string
+)
+
+func accessReportInit() {
+	_accessReportCmd.Flags().SortFlags = false
+	_accessReportCmd.Flags().StringVarP(&_flagAccessReportEnv, "env", "e", getDefaultEnv(_generalCommandType), "environment to use")
+	_accessReportCmd.Flags().StringVar(&_flagAccessReportStart, "start", "", "start of the report, as a date (e.g. 2020-07-01) or an RFC 3339 timestamp (default: 24 hours before the end)")
+	_accessReportCmd.Flags().StringVar(&_flagAccessReportEnd, "end", "", "end of the report, as a date (e.g. 2020-07-31, which includes the whole day) or an RFC 3339 timestamp (default: now)")
+	_accessReportCmd.Flags().StringVarP(&_flagAccessReportFormat, "format", "f", schema.AccessReportFormatCSV, "format of the report (csv or json)")
+	_accessReportCmd.Flags().StringVarP(&_flagAccessReportOutput, "output", "o", "", "path to write the report to (default: stdout)")
+}
+
+var _accessReportCmd = &cobra.Command{
+	Use:   "access-report",
+	Short: "export who deployed, updated, and deleted apis over a time range",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		env, err := ReadOrConfigureEnv(_flagAccessReportEnv)
+		if err != nil {
+			telemetry.Event("cli.access-report")
+			exit.Error(err)
+		}
+		telemetry.Event("cli.access-report", map[string]interface{}{"provider": env.Provider.String(), "env_name": env.Name})
+
+		if env.Provider == types.LocalProviderType {
+			exit.Error(ErrorNotSupportedInLocalEnvironment())
+		}
+
+		if !slices.HasString(schema.AccessReportFormats, _flagAccessReportFormat) {
+			exit.Error(ErrorInvalidAccessReportFormat(_flagAccessReportFormat))
+		}
+
+		start, err := parseReportTime(_flagAccessReportStart, false)
+		if err != nil {
+			exit.Error(err)
+		}
+		end, err := parseReportTime(_flagAccessReportEnd, true)
+		if err != nil {
+			exit.Error(err)
+		}
+
+		report, err := cluster.AccessReport(MustGetOperatorConfig(env.Name), start, end, _flagAccessReportFormat)
+		if err != nil {
+			exit.Error(err)
+		}
+
+		if _flagAccessReportOutput == "" {
+			fmt.Print(string(report))
+			return
+		}
+		if err := files.WriteFile(report, _flagAccessReportOutput); err != nil {
+			exit.Error(err)
+		}
+	},
+}
+
+// parseReportTime accepts a date or an RFC 3339 timestamp; a date used as the end of a report includes the whole day
+func parseReportTime(str string, isEnd bool) (*time.Time, error) {
+	if str == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, str); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", str)
+	if err != nil {
+		return nil, ErrorInvalidReportTime(str)
+	}
+	if isEnd {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &t, nil
+}
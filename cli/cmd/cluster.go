@@ -35,6 +35,7 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/files"
 	"github.com/cortexlabs/cortex/pkg/lib/pointer"
 	"github.com/cortexlabs/cortex/pkg/lib/prompt"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
 	s "github.com/cortexlabs/cortex/pkg/lib/strings"
 	"github.com/cortexlabs/cortex/pkg/lib/table"
 	"github.com/cortexlabs/cortex/pkg/lib/telemetry"
@@ -42,6 +43,7 @@ import (
 	"github.com/cortexlabs/cortex/pkg/types"
 	"github.com/cortexlabs/cortex/pkg/types/clusterconfig"
 	"github.com/cortexlabs/cortex/pkg/types/clusterstate"
+	"github.com/cortexlabs/cortex/pkg/types/userconfig"
 	"github.com/spf13/cobra"
 )
 
@@ -50,6 +52,8 @@ var (
 	_flagClusterConfig         string
 	_flagClusterInfoDebug      bool
 	_flagClusterDisallowPrompt bool
+	_flagProfileSeconds        int
+	_flagProfileOutput         string
 )
 
 func clusterInit() {
@@ -78,6 +82,16 @@ func clusterInit() {
 	addClusterConfigFlag(_downCmd)
 	_downCmd.Flags().BoolVarP(&_flagClusterDisallowPrompt, "yes", "y", false, "skip prompts")
 	_clusterCmd.AddCommand(_downCmd)
+
+	_clusterImagesCmd.Flags().SortFlags = false
+	addClusterConfigFlag(_clusterImagesCmd)
+	_clusterCmd.AddCommand(_clusterImagesCmd)
+
+	_profileOperatorCmd.Flags().SortFlags = false
+	_profileOperatorCmd.Flags().StringVarP(&_flagClusterEnv, "env", "e", defaultEnv, "environment to use")
+	_profileOperatorCmd.Flags().IntVarP(&_flagProfileSeconds, "seconds", "s", 30, "duration of the cpu profile in seconds")
+	_profileOperatorCmd.Flags().StringVarP(&_flagProfileOutput, "output", "o", "", "path to write the profile to (default: operator-<profile>.pprof)")
+	_clusterCmd.AddCommand(_profileOperatorCmd)
 }
 
 func addClusterConfigFlag(cmd *cobra.Command) {
@@ -379,6 +393,92 @@ var _emailPrompValidation = &cr.PromptValidation{
 	},
 }
 
+var _profileOperatorCmd = &cobra.Command{
+	Use:   "profile-operator [PROFILE]",
+	Short: "capture a pprof profile from the operator (cpu, heap, goroutine, block, mutex, etc; defaults to cpu)",
+	Args:  cobra.RangeArgs(0, 1),
+	Run: func(cmd *cobra.Command, args []string) {
+		telemetry.Event("cli.cluster.profile-operator")
+
+		if _flagClusterEnv == "local" {
+			exit.Error(ErrorNotSupportedInLocalEnvironment())
+		}
+
+		profileName := "cpu"
+		if len(args) == 1 {
+			profileName = args[0]
+		}
+
+		outputPath := _flagProfileOutput
+		if outputPath == "" {
+			outputPath = fmt.Sprintf("operator-%s.pprof", profileName)
+		}
+		outputPath = files.UserRelToAbsPath(outputPath)
+
+		if profileName == "cpu" {
+			fmt.Printf("capturing a %ds cpu profile from the operator ...\n", _flagProfileSeconds)
+		}
+
+		profile, err := cluster.ProfileOperator(MustGetOperatorConfig(_flagClusterEnv), profileName, _flagProfileSeconds)
+		if err != nil {
+			exit.Error(err)
+		}
+
+		if err := files.WriteFile(profile, outputPath); err != nil {
+			exit.Error(err)
+		}
+
+		fmt.Printf("saved %s profile to %s; run `go tool pprof %s` to inspect it\n", profileName, outputPath, outputPath)
+	},
+}
+
+// the istio charts pull these from docker.io/istio (this must match ISTIO_VERSION in images/manager/Dockerfile)
+var _istioChartImages = []string{"istio/kubectl:1.4.2", "istio/install-cni:1.4.2"}
+
+var _clusterImagesCmd = &cobra.Command{
+	Use:   "images",
+	Short: "list the images to mirror to the image registry of an air-gapped cluster (as \"<source> <destination>\" lines)",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		telemetry.Event("cli.cluster.images")
+
+		if _flagClusterConfig == "" {
+			exit.Error(ErrorImageRegistryRequired())
+		}
+
+		clusterConfig := &clusterconfig.Config{}
+		if err := clusterconfig.SetDefaults(clusterConfig); err != nil {
+			exit.Error(err)
+		}
+		if err := readUserClusterConfigFile(clusterConfig); err != nil {
+			exit.Error(err)
+		}
+		if clusterConfig.ImageRegistry == nil {
+			exit.Error(ErrorImageRegistryRequired())
+		}
+
+		sourceImages := strset.New(consts.DefaultImagePathsSet.Slice()...)
+		for _, image := range clusterConfig.Images() {
+			sourceImages.Add(clusterconfig.UnmirroredImage(*image, clusterConfig.ImageRegistry))
+		}
+		for _, runtime := range userconfig.RuntimeCatalog {
+			sourceImages.Add(runtime.Image)
+			if runtime.TensorFlowServingImage != "" {
+				sourceImages.Add(runtime.TensorFlowServingImage)
+			}
+		}
+
+		for _, image := range sourceImages.SliceSorted() {
+			if mirroredImage := clusterconfig.MirroredImage(image, clusterConfig.ImageRegistry); mirroredImage != image {
+				fmt.Println(image, mirroredImage)
+			}
+		}
+		for _, image := range _istioChartImages {
+			fmt.Println("docker.io/"+image, *clusterConfig.ImageRegistry+"/"+image)
+		}
+	},
+}
+
 func promptForEmail() {
 	if email, err := files.ReadFile(_emailPath); err == nil && email != "" {
 		return
@@ -438,6 +538,13 @@ func cmdInfo(awsCreds AWSCredentials, accessConfig *clusterconfig.AccessConfig,
 		}
 	}
 
+	// keep connecting through a PrivateLink interface endpoint if the environment was configured to use one
+	if prevEnv, err := readEnv(_flagClusterEnv); err == nil && prevEnv != nil && prevEnv.OperatorEndpoint != nil {
+		if clusterConfig.OperatorPrivateLink != nil && strings.Contains(*prevEnv.OperatorEndpoint, ".vpce.amazonaws.com") {
+			operatorEndpoint = *prevEnv.OperatorEndpoint
+		}
+	}
+
 	if err := printInfoOperatorResponse(clusterConfig, operatorEndpoint, awsCreds); err != nil {
 		exit.Error(err)
 	}
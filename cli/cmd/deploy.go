@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/cortexlabs/cortex/cli/cluster"
 	"github.com/cortexlabs/cortex/cli/local"
@@ -50,6 +51,9 @@ var (
 	_flagDeployEnv            string
 	_flagDeployForce          bool
 	_flagDeployDisallowPrompt bool
+	_flagDeployTTL            time.Duration
+	_flagDeployPreview        string
+	_flagDeployCheckDeps      bool
 )
 
 func deployInit() {
@@ -57,6 +61,9 @@ func deployInit() {
 	_deployCmd.Flags().StringVarP(&_flagDeployEnv, "env", "e", getDefaultEnv(_generalCommandType), "environment to use")
 	_deployCmd.Flags().BoolVarP(&_flagDeployForce, "force", "f", false, "override the in-progress api update")
 	_deployCmd.Flags().BoolVarP(&_flagDeployDisallowPrompt, "yes", "y", false, "skip prompts")
+	_deployCmd.Flags().DurationVar(&_flagDeployTTL, "ttl", 0, "delete the apis automatically after this duration, e.g. 24h (the deadline can be moved with `cortex extend`)")
+	_deployCmd.Flags().BoolVar(&_flagDeployCheckDeps, "check-dependencies", false, "install requirements.txt and conda-packages.txt in a sandbox before updating the apis, and fail the deployment if they can't be installed")
+	_deployCmd.Flags().StringVar(&_flagDeployPreview, "preview", "", "deploy a preview of the apis for a git branch, under branch-suffixed names and endpoints (expires after 7 days unless --ttl is specified)")
 }
 
 var _deployCmd = &cobra.Command{
@@ -93,11 +100,26 @@ var _deployCmd = &cobra.Command{
 				exit.Error(err)
 			}
 
-			deployResponse, err = cluster.Deploy(MustGetOperatorConfig(env.Name), configPath, deploymentBytes, _flagDeployForce)
+			var ttl *time.Duration
+			if cmd.Flags().Changed("ttl") {
+				if _flagDeployTTL <= 0 {
+					exit.Error(ErrorInvalidDuration(_flagDeployTTL.String()))
+				}
+				ttl = &_flagDeployTTL
+			}
+
+			deployResponse, err = cluster.Deploy(MustGetOperatorConfig(env.Name), configPath, deploymentBytes, _flagDeployForce, ttl, _flagDeployPreview, _flagDeployCheckDeps)
 			if err != nil {
 				exit.Error(err)
 			}
 		} else {
+			if _flagDeployPreview != "" {
+				exit.Error(ErrorPreviewNotSupportedLocally())
+			}
+			if cmd.Flags().Changed("ttl") {
+				exit.Error(ErrorTTLNotSupportedLocally())
+			}
+
 			projectFiles, err := findProjectFiles(env.Provider, configPath)
 			if err != nil {
 				exit.Error(err)
@@ -234,6 +256,10 @@ func deployMessage(results []schema.DeployResult, envName string) string {
 		return statusMessage
 	}
 
+	if warningsMessage := getWarningsMessage(results); warningsMessage != "" {
+		statusMessage += "\n\n" + warningsMessage
+	}
+
 	apiCommandsMessage := getAPICommandsMessage(results, envName)
 
 	return statusMessage + "\n\n" + apiCommandsMessage
@@ -256,6 +282,16 @@ func mergeResultMessages(results []schema.DeployResult) string {
 	return strings.Join(messages, "\n")
 }
 
+func getWarningsMessage(results []schema.DeployResult) string {
+	var warnings []string
+	for _, result := range results {
+		for _, warning := range result.Warnings {
+			warnings = append(warnings, "warning: "+warning)
+		}
+	}
+	return strings.Join(warnings, "\n")
+}
+
 func didAllResultsError(results []schema.DeployResult) bool {
 	for _, result := range results {
 		if result.Error == "" {
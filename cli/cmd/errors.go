@@ -26,6 +26,7 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	s "github.com/cortexlabs/cortex/pkg/lib/strings"
 	"github.com/cortexlabs/cortex/pkg/lib/urls"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
 	"github.com/cortexlabs/cortex/pkg/types"
 	"github.com/cortexlabs/cortex/pkg/types/clusterconfig"
 	"github.com/cortexlabs/cortex/pkg/types/clusterstate"
@@ -81,6 +82,26 @@ const (
 	ErrShellCompletionNotSupported          = "cli.shell_completion_not_supported"
 	ErrNoTerminalWidth                      = "cli.no_terminal_width"
 	ErrDeployFromTopLevelDir                = "cli.deploy_from_top_level_dir"
+	ErrLoadTestLatencyThresholdExceeded     = "cli.load_test_latency_threshold_exceeded"
+	ErrLoadTestErrorRateThresholdExceeded   = "cli.load_test_error_rate_threshold_exceeded"
+	ErrLoadTestPayloadFileRequired          = "cli.load_test_payload_file_required"
+	ErrLoadTestFailed                       = "cli.load_test_failed"
+	ErrReplayTargetRequired                 = "cli.replay_target_required"
+	ErrReplayFailed                         = "cli.replay_failed"
+	ErrReplayMismatchRateThresholdExceeded  = "cli.replay_mismatch_rate_threshold_exceeded"
+	ErrMaintenanceFlagRequired              = "cli.maintenance_flag_required"
+	ErrExtendDurationOrRemove               = "cli.extend_duration_or_remove"
+	ErrInvalidDuration                      = "cli.invalid_duration"
+	ErrPreviewNotSupportedLocally           = "cli.preview_not_supported_locally"
+	ErrTTLNotSupportedLocally               = "cli.ttl_not_supported_locally"
+	ErrGitListRemoteBranches                = "cli.git_list_remote_branches"
+	ErrNoGitRemoteBranches                  = "cli.no_git_remote_branches"
+	ErrRuntimeNotFound                      = "cli.runtime_not_found"
+	ErrInvalidPredictorType                 = "cli.invalid_predictor_type"
+	ErrInvalidAccessReportFormat            = "cli.invalid_access_report_format"
+	ErrInvalidReportTime                    = "cli.invalid_report_time"
+	ErrAirGappedTelemetryEnabled            = "cli.air_gapped_telemetry_enabled"
+	ErrImageRegistryRequired                = "cli.image_registry_required"
 )
 
 func ErrorInvalidProvider(providerStr string) error {
@@ -350,3 +371,143 @@ func ErrorDeployFromTopLevelDir(genericDirName string, providerType types.Provid
 		Message: fmt.Sprintf("cannot deploy from your %s directory - when deploying your API, cortex sends all files in your project directory (i.e. the directory which contains cortex.yaml) to your %s (see https://docs.cortex.dev/v/%s/deployments/predictors#project-files); therefore it is recommended to create a subdirectory for your project files", genericDirName, targetStr, consts.CortexVersionMinor),
 	})
 }
+
+func ErrorLoadTestLatencyThresholdExceeded(p99 float64, maxP99 float64) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrLoadTestLatencyThresholdExceeded,
+		Message: fmt.Sprintf("p99 latency (%.1f ms) exceeded the threshold of %.1f ms", p99, maxP99),
+	})
+}
+
+func ErrorLoadTestErrorRateThresholdExceeded(errorRate float64, maxErrorRate float64) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrLoadTestErrorRateThresholdExceeded,
+		Message: fmt.Sprintf("error rate (%.4f) exceeded the threshold of %.4f", errorRate, maxErrorRate),
+	})
+}
+
+func ErrorLoadTestPayloadFileRequired() error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrLoadTestPayloadFileRequired,
+		Message: "please specify `--payload-file` (or `--job-id` to retrieve the results of an existing load test)",
+	})
+}
+
+func ErrorLoadTestFailed(jobID string, message string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrLoadTestFailed,
+		Message: fmt.Sprintf("load test %s failed:\n\n%s", jobID, strings.TrimSpace(message)),
+	})
+}
+
+func ErrorReplayTargetRequired() error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrReplayTargetRequired,
+		Message: "please specify `--target` (or `--replay-id` to retrieve the results of an existing replay)",
+	})
+}
+
+func ErrorReplayFailed(replayID string, message string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrReplayFailed,
+		Message: fmt.Sprintf("replay %s failed:\n\n%s", replayID, strings.TrimSpace(message)),
+	})
+}
+
+func ErrorReplayMismatchRateThresholdExceeded(mismatchRate float64, maxMismatchRate float64) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrReplayMismatchRateThresholdExceeded,
+		Message: fmt.Sprintf("mismatch rate (%.4f) exceeded the threshold of %.4f", mismatchRate, maxMismatchRate),
+	})
+}
+
+func ErrorMaintenanceFlagRequired() error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrMaintenanceFlagRequired,
+		Message: "please specify exactly one of `--on` or `--off`",
+	})
+}
+
+func ErrorExtendDurationOrRemove() error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrExtendDurationOrRemove,
+		Message: "please specify either a duration (e.g. `cortex extend my-api 24h`) or the `--remove` flag",
+	})
+}
+
+func ErrorInvalidDuration(duration string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidDuration,
+		Message: fmt.Sprintf("invalid duration %s; please specify a positive duration such as 30m or 24h", s.UserStr(duration)),
+	})
+}
+
+func ErrorPreviewNotSupportedLocally() error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrPreviewNotSupportedLocally,
+		Message: "preview deployments are not supported in the local environment; please specify an environment which uses the aws provider via the `--env` flag",
+	})
+}
+
+func ErrorTTLNotSupportedLocally() error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrTTLNotSupportedLocally,
+		Message: "the `--ttl` flag is not supported in the local environment; please specify an environment which uses the aws provider via the `--env` flag",
+	})
+}
+
+func ErrorGitListRemoteBranches(remote string, output string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrGitListRemoteBranches,
+		Message: fmt.Sprintf("unable to list the branches of the %s git remote (the command must be run from within a git repository): %s", remote, strings.TrimSpace(output)),
+	})
+}
+
+func ErrorNoGitRemoteBranches(remote string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrNoGitRemoteBranches,
+		Message: fmt.Sprintf("no branches were found in the %s git remote, so no previews were deleted", remote),
+	})
+}
+
+func ErrorRuntimeNotFound(runtime string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrRuntimeNotFound,
+		Message: fmt.Sprintf("%s is not a supported runtime; run `cortex images` to see all supported runtimes", s.UserStr(runtime)),
+	})
+}
+
+func ErrorInvalidPredictorType(predictorTypeStr string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidPredictorType,
+		Message: fmt.Sprintf("%s is not a valid predictor type (%s are supported)", predictorTypeStr, s.UserStrsAnd(userconfig.PredictorTypeStrings())),
+	})
+}
+
+func ErrorInvalidAccessReportFormat(format string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidAccessReportFormat,
+		Message: fmt.Sprintf("invalid format %s; valid formats are %s", s.UserStr(format), s.UserStrsOr(schema.AccessReportFormats)),
+	})
+}
+
+func ErrorInvalidReportTime(str string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidReportTime,
+		Message: fmt.Sprintf("invalid time %s; please specify a date (e.g. 2020-07-01) or an RFC 3339 timestamp (e.g. 2020-07-01T12:00:00Z)", s.UserStr(str)),
+	})
+}
+
+func ErrorAirGappedTelemetryEnabled() error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrAirGappedTelemetryEnabled,
+		Message: fmt.Sprintf("air-gapped clusters require the cli's telemetry to be disabled; please set `telemetry: false` in %s", _cliConfigPath),
+	})
+}
+
+func ErrorImageRegistryRequired() error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrImageRegistryRequired,
+		Message: fmt.Sprintf("this command requires a cluster configuration file which specifies `%s` (e.g. `--config cluster.yaml`)", clusterconfig.ImageRegistryKey),
+	})
+}
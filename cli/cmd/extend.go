@@ -0,0 +1,84 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/cortexlabs/cortex/cli/cluster"
+	"github.com/cortexlabs/cortex/pkg/lib/exit"
+	"github.com/cortexlabs/cortex/pkg/lib/print"
+	"github.com/cortexlabs/cortex/pkg/lib/telemetry"
+	"github.com/cortexlabs/cortex/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	_flagExtendEnv    string
+	_flagExtendRemove bool
+)
+
+func extendInit() {
+	_extendCmd.Flags().SortFlags = false
+	_extendCmd.Flags().StringVarP(&_flagExtendEnv, "env", "e", getDefaultEnv(_generalCommandType), "environment to use")
+	_extendCmd.Flags().BoolVar(&_flagExtendRemove, "remove", false, "remove the api's expiration so that it is not deleted automatically")
+}
+
+var _extendCmd = &cobra.Command{
+	Use:   "extend API_NAME [DURATION]",
+	Short: "extend the time before an api which was deployed with a ttl is deleted",
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		env, err := ReadOrConfigureEnv(_flagExtendEnv)
+		if err != nil {
+			telemetry.Event("cli.extend")
+			exit.Error(err)
+		}
+		telemetry.Event("cli.extend", map[string]interface{}{"provider": env.Provider.String(), "env_name": env.Name})
+
+		err = printEnvIfNotSpecified(_flagExtendEnv, cmd)
+		if err != nil {
+			exit.Error(err)
+		}
+
+		if env.Provider == types.LocalProviderType {
+			exit.Error(ErrorNotSupportedInLocalEnvironment())
+		}
+
+		var duration *time.Duration
+		if _flagExtendRemove {
+			if len(args) == 2 {
+				exit.Error(ErrorExtendDurationOrRemove())
+			}
+		} else {
+			if len(args) == 1 {
+				exit.Error(ErrorExtendDurationOrRemove())
+			}
+			parsedDuration, err := time.ParseDuration(args[1])
+			if err != nil || parsedDuration <= 0 {
+				exit.Error(ErrorInvalidDuration(args[1]))
+			}
+			duration = &parsedDuration
+		}
+
+		extendResponse, err := cluster.Extend(MustGetOperatorConfig(env.Name), args[0], duration)
+		if err != nil {
+			exit.Error(err)
+		}
+		print.BoldFirstLine(extendResponse.Message)
+	},
+}
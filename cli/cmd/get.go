@@ -17,7 +17,6 @@ limitations under the License.
 package cmd
 
 import (
-	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -38,6 +37,7 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/table"
 	"github.com/cortexlabs/cortex/pkg/lib/telemetry"
 	libtime "github.com/cortexlabs/cortex/pkg/lib/time"
+	"github.com/cortexlabs/cortex/pkg/lib/transport"
 	"github.com/cortexlabs/cortex/pkg/lib/urls"
 	"github.com/cortexlabs/cortex/pkg/operator/schema"
 	"github.com/cortexlabs/cortex/pkg/types"
@@ -63,8 +63,15 @@ const (
 	_title2XX           = "2XX"
 	_title4XX           = "4XX"
 	_title5XX           = "5XX"
+	_titleVersion       = "version"
+	_titleDeployed      = "deployed"
+	_titleModels        = "models"
+	_titleImageDigest   = "image digest"
 )
 
+// apis which will expire within this period are shown with a warning
+const _expirationWarningPeriod = 1 * time.Hour
+
 var (
 	_flagGetEnv string
 	_flagWatch  bool
@@ -195,6 +202,11 @@ func getAPIsInAllEnvironments() (string, error) {
 		out = syncAPITable.MustFormat() + "\n" + apiSplitTable.MustFormat()
 	}
 
+	if sunsetWarnings := sunsetWarningsStr(allSyncAPIs, allAPISplitters); sunsetWarnings != "" {
+		out = s.EnsureBlankLineIfNotEmpty(out)
+		out += sunsetWarnings
+	}
+
 	if len(errorsMap) == 1 {
 		out = s.EnsureBlankLineIfNotEmpty(out)
 		out += fmt.Sprintf("unable to detect apis from the %s environment; run `cortex get --env %s` if this is unexpected\n", errors.FirstKeyInErrorMap(errorsMap), errors.FirstKeyInErrorMap(errorsMap))
@@ -265,6 +277,11 @@ func getAPIs(env cliconfig.Environment, printEnv bool) (string, error) {
 		out = syncAPITable.MustFormat() + "\n" + apiSplitTable.MustFormat()
 	}
 
+	if sunsetWarnings := sunsetWarningsStr(apisRes.SyncAPIs, apisRes.APISplitter); sunsetWarnings != "" {
+		out = s.EnsureBlankLineIfNotEmpty(out)
+		out += sunsetWarnings
+	}
+
 	if env.Provider == types.LocalProviderType {
 		// apisplitter not supported in local
 		hideReplicaCountColumns(&syncAPITable)
@@ -277,6 +294,30 @@ func getAPIs(env cliconfig.Environment, printEnv bool) (string, error) {
 	return out, nil
 }
 
+// warnings for deprecated apis whose sunset dates are approaching, and for the api splitters which route traffic to them
+func sunsetWarningsStr(syncAPIs []schema.SyncAPI, apiSplitters []schema.APISplitter) string {
+	var out string
+
+	deprecations := map[string]*userconfig.Deprecation{}
+	for _, syncAPI := range syncAPIs {
+		if syncAPI.Spec.Deprecation == nil || !syncAPI.Spec.Deprecation.IsSunsetApproaching() {
+			continue
+		}
+		deprecations[syncAPI.Spec.Name] = syncAPI.Spec.Deprecation
+		out += "warning: " + syncAPI.Spec.DeprecationWarning() + "\n"
+	}
+
+	for _, apiSplitter := range apiSplitters {
+		for _, api := range apiSplitter.Spec.APIs {
+			if deprecation, ok := deprecations[api.Name]; ok {
+				out += "warning: " + userconfig.APISplitterDeprecationWarning(apiSplitter.Spec.Name, api.Name, deprecation) + "\n"
+			}
+		}
+	}
+
+	return out
+}
+
 func getLocalVersionMismatchedAPIsMessage() (string, error) {
 	mismatchedAPINames, err := local.ListVersionMismatchedAPIs()
 	if err != nil {
@@ -330,10 +371,15 @@ func apiSplitterTable(apiSplitter *schema.APISplitter, env cliconfig.Environment
 	out += console.Bold("kind: ") + apiSplitter.Spec.Kind.String() + "\n\n"
 	out += console.Bold("last updated: ") + libtime.SinceStr(&lastUpdated) + "\n\n"
 
-	t, err := trafficSplitTable(*apiSplitter, env)
+	out += expirationStr(apiSplitter.Spec.Name, apiSplitter.Expiration)
+
+	t, deprecationWarnings, err := trafficSplitTable(*apiSplitter, env)
 	if err != nil {
 		return "", err
 	}
+	for _, deprecationWarning := range deprecationWarnings {
+		out += "warning: " + deprecationWarning + "\n\n"
+	}
 	t.FindHeaderByTitle(_titleEnvironment).Hidden = true
 
 	out += t.MustFormat()
@@ -355,13 +401,18 @@ func apiSplitterTable(apiSplitter *schema.APISplitter, env cliconfig.Environment
 	return out, nil
 }
 
-func trafficSplitTable(apiSplitter schema.APISplitter, env cliconfig.Environment) (table.Table, error) {
+// also returns deprecation warnings for the apis that the api splitter routes traffic to
+func trafficSplitTable(apiSplitter schema.APISplitter, env cliconfig.Environment) (table.Table, []string, error) {
 	rows := make([][]interface{}, 0, len(apiSplitter.Spec.APIs))
+	var deprecationWarnings []string
 
 	for _, api := range apiSplitter.Spec.APIs {
 		apiRes, err := cluster.GetAPI(MustGetOperatorConfig(env.Name), api.Name)
 		if err != nil {
-			return table.Table{}, err
+			return table.Table{}, nil, err
+		}
+		if apiRes.SyncAPI.Spec.Deprecation != nil {
+			deprecationWarnings = append(deprecationWarnings, userconfig.APISplitterDeprecationWarning(apiSplitter.Spec.Name, api.Name, apiRes.SyncAPI.Spec.Deprecation))
 		}
 		lastUpdated := time.Unix(apiRes.SyncAPI.Spec.LastUpdated, 0)
 		rows = append(rows, []interface{}{
@@ -390,7 +441,7 @@ func trafficSplitTable(apiSplitter schema.APISplitter, env cliconfig.Environment
 			{Title: _title5XX},
 		},
 		Rows: rows,
-	}, nil
+	}, deprecationWarnings, nil
 }
 
 func apiSplitterListTable(apiSplitter []schema.APISplitter, envNames []string) table.Table {
@@ -430,8 +481,39 @@ func syncAPITable(syncAPI *schema.SyncAPI, env cliconfig.Environment) (string, e
 
 	out += console.Bold("kind: ") + syncAPI.Spec.Kind.String() + "\n\n"
 
+	if deprecationWarning := syncAPI.Spec.DeprecationWarning(); deprecationWarning != "" {
+		out += "warning: " + deprecationWarning + "\n\n"
+	}
+
+	out += expirationStr(syncAPI.Spec.Name, syncAPI.Expiration)
+
+	if syncAPI.Maintenance != nil {
+		out += fmt.Sprintf("warning: %s is in maintenance mode (requests will receive status code %d)", syncAPI.Spec.Name, syncAPI.Maintenance.StatusCode)
+		if syncAPI.Maintenance.Message != "" {
+			out += ": " + syncAPI.Maintenance.Message
+		}
+		out += "\n\n"
+	}
+
 	out += t.MustFormat()
 
+	if syncAPI.Status.LastCrash != nil {
+		out += "\n" + crashStr(syncAPI.Status.LastCrash)
+	}
+
+	if len(syncAPI.Status.ImageVerifications) > 0 {
+		out += "\n" + imageVerificationsStr(syncAPI.Status.ImageVerifications)
+	}
+
+	if len(syncAPI.Versions) > 1 {
+		versions := versionsTable(syncAPI.Versions)
+		out += "\n" + versions.MustFormat()
+	}
+
+	if syncAPI.GoldenDatasetGate != nil {
+		out += "\n" + goldenDatasetGateStr(syncAPI.GoldenDatasetGate)
+	}
+
 	if env.Provider != types.LocalProviderType && syncAPI.Spec.Monitoring != nil {
 		switch syncAPI.Spec.Monitoring.ModelType {
 		case userconfig.ClassificationModelType:
@@ -466,6 +548,159 @@ func syncAPITable(syncAPI *schema.SyncAPI, env cliconfig.Environment) (string, e
 	return out, nil
 }
 
+var _signalNames = map[int32]string{
+	4:  "SIGILL",
+	6:  "SIGABRT",
+	7:  "SIGBUS",
+	8:  "SIGFPE",
+	9:  "SIGKILL",
+	11: "SIGSEGV",
+	15: "SIGTERM",
+}
+
+func imageVerificationsStr(imageVerifications []status.ImageVerification) string {
+	var out string
+	for _, imageVerification := range imageVerifications {
+		out += console.Bold("verified image: ") + fmt.Sprintf("%s (pinned to %s, signed by key %s)\n", imageVerification.Image, imageVerification.Digest, imageVerification.KeyID)
+	}
+	return out
+}
+
+// the metrics of the API's recent versions (the api container's image digest is shown, since it runs the predictor)
+func versionsTable(versions []schema.APIVersionMetrics) table.Table {
+	rows := make([][]interface{}, 0, len(versions))
+	var total4XX int
+	var total5XX int
+
+	for _, version := range versions {
+		imageDigest := "-"
+		for _, image := range version.Images {
+			if image.Container == "api" {
+				imageDigest = image.ImageDigest
+				if len(imageDigest) > 19 {
+					imageDigest = imageDigest[:19] // e.g. sha256:0123456789ab
+				}
+			}
+		}
+
+		models := "-"
+		if len(version.Models) > 0 {
+			models = strings.Join(version.Models, ", ")
+		}
+
+		rows = append(rows, []interface{}{
+			fmt.Sprintf("v%d", version.Version),
+			libtime.SinceStr(&version.DeployedAt),
+			models,
+			imageDigest,
+			latencyStr(&version.Metrics),
+			code2XXStr(&version.Metrics),
+			code4XXStr(&version.Metrics),
+			code5XXStr(&version.Metrics),
+		})
+
+		if version.Metrics.NetworkStats != nil {
+			total4XX += version.Metrics.NetworkStats.Code4XX
+			total5XX += version.Metrics.NetworkStats.Code5XX
+		}
+	}
+
+	return table.Table{
+		Headers: []table.Header{
+			{Title: _titleVersion},
+			{Title: _titleDeployed},
+			{Title: _titleModels, MaxWidth: 50},
+			{Title: _titleImageDigest},
+			{Title: _titleAvgRequest},
+			{Title: _title2XX},
+			{Title: _title4XX, Hidden: total4XX == 0},
+			{Title: _title5XX, Hidden: total5XX == 0},
+		},
+		Rows: rows,
+	}
+}
+
+func goldenDatasetGateStr(gate *schema.GoldenDatasetGate) string {
+	var out string
+	switch gate.Status {
+	case schema.GoldenDatasetGateRunning:
+		out = fmt.Sprintf("an update is being validated against the golden dataset (started %s ago)", libtime.SinceStr(&gate.StartedAt))
+	case schema.GoldenDatasetGatePassed:
+		out = "the last update passed validation against the golden dataset and was promoted"
+	case schema.GoldenDatasetGateBlocked:
+		out = "the last update regressed on the golden dataset and was not promoted"
+	case schema.GoldenDatasetGateFailed:
+		out = "the last update could not be validated against the golden dataset and was not promoted"
+	}
+	if gate.Message != "" {
+		out += ": " + gate.Message
+	}
+	out = console.Bold("golden dataset: ") + out + "\n"
+
+	if result := gate.Result; result != nil {
+		accuracy := fmt.Sprintf("%.1f%%", result.Accuracy*100)
+		if result.BaselineAccuracy != nil {
+			accuracy += fmt.Sprintf(" (current version: %.1f%%)", *result.BaselineAccuracy*100)
+		}
+		latency := fmt.Sprintf("%.0fms", result.LatencyP99Ms)
+		if result.BaselineLatencyP99Ms != nil {
+			latency += fmt.Sprintf(" (current version: %.0fms)", *result.BaselineLatencyP99Ms)
+		}
+		out += fmt.Sprintf("%s examples, accuracy %s, p99 latency %s\n", s.Int(result.NumExamples), accuracy, latency)
+	}
+
+	return out
+}
+
+func crashStr(crash *status.Crash) string {
+	signal := crash.Signal
+	if signal == 0 && crash.ExitCode > 128 {
+		signal = crash.ExitCode - 128
+	}
+
+	reason := fmt.Sprintf("exit code %d", crash.ExitCode)
+	if signalName, ok := _signalNames[signal]; ok {
+		reason += ", " + signalName
+	}
+	if crash.Reason != "" && crash.Reason != "Error" {
+		reason += ", " + crash.Reason
+	}
+
+	out := console.Bold("last crash: ") + fmt.Sprintf("the %s container of %s exited %s ago (%s)", crash.Container, crash.PodName, libtime.SinceStr(&crash.FinishedAt), reason)
+	if crash.RestartCount > 0 {
+		out += fmt.Sprintf("; it has restarted %s", s.PluralS(s.Int32(crash.RestartCount)+" time", crash.RestartCount))
+	}
+	out += "\n"
+
+	if crash.ReportPath != nil {
+		out += console.Bold("crash report: ") + *crash.ReportPath + "\n"
+	}
+
+	if crash.Logs != nil && strings.TrimSpace(*crash.Logs) != "" {
+		out += titleStr("logs before crash") + strings.TrimRight(*crash.Logs, "\n") + "\n"
+	}
+
+	return out
+}
+
+// Returns "" if the api does not expire
+func expirationStr(apiName string, expiration *time.Time) string {
+	if expiration == nil {
+		return ""
+	}
+
+	now := time.Now()
+	if expiration.Before(now) {
+		return fmt.Sprintf("warning: %s has expired and is being deleted\n\n", apiName)
+	}
+
+	msg := fmt.Sprintf("%s expires in %s (at %s)", apiName, libtime.DifferenceStr(&now, expiration), libtime.LocalTimestamp(expiration))
+	if expiration.Sub(now) < _expirationWarningPeriod {
+		msg = fmt.Sprintf("warning: %s; run `cortex extend %s DURATION` to keep it running", msg, apiName)
+	}
+	return msg + "\n\n"
+}
+
 func apiTable(syncAPIs []schema.SyncAPI, envNames []string) table.Table {
 	rows := make([][]interface{}, 0, len(syncAPIs))
 	var totalFailed int32
@@ -674,10 +909,8 @@ func describeModelInput(status *status.Status, apiEndpoint string) string {
 
 func makeRequest(request *http.Request) (http.Header, []byte, error) {
 	client := http.Client{
-		Timeout: 600 * time.Second,
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		},
+		Timeout:   600 * time.Second,
+		Transport: transport.NewInsecure(),
 	}
 
 	response, err := client.Do(request)
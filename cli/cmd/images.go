@@ -0,0 +1,126 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cortexlabs/cortex/pkg/lib/exit"
+	"github.com/cortexlabs/cortex/pkg/lib/table"
+	"github.com/cortexlabs/cortex/pkg/lib/telemetry"
+	"github.com/cortexlabs/cortex/pkg/types/userconfig"
+	"github.com/spf13/cobra"
+)
+
+const (
+	_titleRuntime     = "runtime"
+	_titlePredictor   = "predictor type"
+	_titleAccelerator = "compute"
+	_titlePython      = "python"
+	_titleCUDA        = "cuda"
+	_titleFrameworks  = "frameworks"
+)
+
+var _flagImagesPredictorType string
+
+func imagesInit() {
+	_imagesCmd.Flags().SortFlags = false
+	_imagesCmd.Flags().StringVarP(&_flagImagesPredictorType, "predictor-type", "p", "", "only show runtimes for this predictor type (python, tensorflow, or onnx)")
+}
+
+var _imagesCmd = &cobra.Command{
+	Use:   "images [RUNTIME]",
+	Short: "list the runtimes (serving images) which can be referenced in an api's predictor configuration",
+	Args:  cobra.RangeArgs(0, 1),
+	Run: func(cmd *cobra.Command, args []string) {
+		telemetry.Event("cli.images")
+
+		if len(args) == 1 {
+			runtime := userconfig.GetRuntime(args[0])
+			if runtime == nil {
+				exit.Error(ErrorRuntimeNotFound(args[0]))
+			}
+			fmt.Print(runtimeStr(runtime))
+			return
+		}
+
+		runtimes := userconfig.RuntimeCatalog
+		if _flagImagesPredictorType != "" {
+			predictorType := userconfig.PredictorTypeFromString(_flagImagesPredictorType)
+			if predictorType == userconfig.UnknownPredictorType {
+				exit.Error(ErrorInvalidPredictorType(_flagImagesPredictorType))
+			}
+			runtimes = nil
+			for _, runtime := range userconfig.RuntimeCatalog {
+				if runtime.PredictorType == predictorType {
+					runtimes = append(runtimes, runtime)
+				}
+			}
+		}
+
+		t := runtimesTable(runtimes)
+		t.MustPrint()
+	},
+}
+
+func runtimesTable(runtimes []userconfig.Runtime) table.Table {
+	rows := make([][]interface{}, 0, len(runtimes))
+	for _, runtime := range runtimes {
+		cuda := "-"
+		if runtime.CUDA != "" {
+			cuda = runtime.CUDA
+		}
+		rows = append(rows, []interface{}{
+			runtime.Name,
+			runtime.PredictorType.String(),
+			string(runtime.Accelerator),
+			runtime.Python,
+			cuda,
+			strings.Join(runtime.Frameworks, ", "),
+		})
+	}
+
+	return table.Table{
+		Headers: []table.Header{
+			{Title: _titleRuntime},
+			{Title: _titlePredictor},
+			{Title: _titleAccelerator},
+			{Title: _titlePython},
+			{Title: _titleCUDA},
+			{Title: _titleFrameworks},
+		},
+		Rows: rows,
+	}
+}
+
+func runtimeStr(runtime *userconfig.Runtime) string {
+	var items table.KeyValuePairs
+	items.Add(_titleRuntime, runtime.Name)
+	items.Add(_titlePredictor, runtime.PredictorType.String())
+	items.Add(_titleAccelerator, string(runtime.Accelerator))
+	items.Add(_titlePython, runtime.Python)
+	if runtime.CUDA != "" {
+		items.Add(_titleCUDA, runtime.CUDA)
+	}
+	items.Add(_titleFrameworks, strings.Join(runtime.Frameworks, ", "))
+	items.Add(userconfig.ImageKey, runtime.Image)
+	if runtime.TensorFlowServingImage != "" {
+		items.Add(userconfig.TensorFlowServingImageKey, runtime.TensorFlowServingImage)
+	}
+	return items.String()
+}
@@ -17,7 +17,6 @@ limitations under the License.
 package cmd
 
 import (
-	"crypto/tls"
 	"fmt"
 	"net/http"
 	"os"
@@ -35,6 +34,7 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
 	"github.com/cortexlabs/cortex/pkg/lib/slices"
 	s "github.com/cortexlabs/cortex/pkg/lib/strings"
+	"github.com/cortexlabs/cortex/pkg/lib/transport"
 	"github.com/cortexlabs/cortex/pkg/lib/urls"
 	"github.com/cortexlabs/cortex/pkg/types"
 	"github.com/cortexlabs/cortex/pkg/types/clusterconfig"
@@ -502,9 +502,7 @@ func validateOperatorEndpoint(endpoint string) (string, error) {
 	req.Header.Set("Content-Type", "application/json")
 
 	client := http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		},
+		Transport: transport.NewInsecure(),
 	}
 	response, err := client.Do(req)
 	if err != nil {
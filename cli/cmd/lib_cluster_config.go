@@ -91,6 +91,7 @@ func getClusterAccessConfig(disallowPrompt bool) (*clusterconfig.AccessConfig, e
 		if errors.HasError(errs) {
 			return nil, errors.Append(errors.FirstError(errs...), fmt.Sprintf("\n\ncluster configuration schema can be found here: https://docs.cortex.dev/v/%s/cluster-management/config", consts.CortexVersionMinor))
 		}
+		accessConfig.ImageManager = clusterconfig.MirroredImage(accessConfig.ImageManager, accessConfig.ImageRegistry)
 	}
 
 	if accessConfig.ClusterName != nil && accessConfig.Region != nil {
@@ -107,6 +108,10 @@ func getClusterAccessConfig(disallowPrompt bool) (*clusterconfig.AccessConfig, e
 		if accessConfig.Region == nil {
 			accessConfig.Region = cachedAccessConfig.Region
 		}
+		if _flagClusterConfig == "" {
+			accessConfig.ImageRegistry = cachedAccessConfig.ImageRegistry
+			accessConfig.ImageManager = cachedAccessConfig.ImageManager
+		}
 	}
 
 	if disallowPrompt {
@@ -167,6 +172,10 @@ func getInstallClusterConfig(awsCreds AWSCredentials, envName string, disallowPr
 		return nil, err
 	}
 
+	if clusterConfig.AirGapped && clusterConfig.Telemetry {
+		return nil, ErrorAirGappedTelemetryEnabled()
+	}
+
 	err = clusterConfig.Validate(awsClient)
 	if err != nil {
 		err = errors.Append(err, fmt.Sprintf("\n\ncluster configuration schema can be found here: https://docs.cortex.dev/v/%s/cluster-management/config", consts.CortexVersionMinor))
@@ -233,6 +242,10 @@ func getConfigureClusterConfig(cachedClusterConfig clusterconfig.Config, awsCred
 		return nil, err
 	}
 
+	if userClusterConfig.AirGapped && userClusterConfig.Telemetry {
+		return nil, ErrorAirGappedTelemetryEnabled()
+	}
+
 	err = userClusterConfig.Validate(awsClient)
 	if err != nil {
 		err = errors.Append(err, fmt.Sprintf("\n\ncluster configuration schema can be found here: https://docs.cortex.dev/v/%s/cluster-management/config", consts.CortexVersionMinor))
@@ -258,6 +271,16 @@ func setConfigFieldsFromCached(userClusterConfig *clusterconfig.Config, cachedCl
 	}
 	userClusterConfig.LogGroup = cachedClusterConfig.LogGroup
 
+	if userClusterConfig.MetricsNamespace != "" && userClusterConfig.MetricsNamespace != cachedClusterConfig.MetricsNamespace {
+		return clusterconfig.ErrorConfigCannotBeChangedOnUpdate(clusterconfig.MetricsNamespaceKey, cachedClusterConfig.MetricsNamespace)
+	}
+	userClusterConfig.MetricsNamespace = cachedClusterConfig.MetricsNamespace
+
+	if len(userClusterConfig.MetricsDimensions) > 0 && !reflect.DeepEqual(userClusterConfig.MetricsDimensions, cachedClusterConfig.MetricsDimensions) {
+		return clusterconfig.ErrorConfigCannotBeChangedOnUpdate(clusterconfig.MetricsDimensionsKey, s.ObjFlat(cachedClusterConfig.MetricsDimensions))
+	}
+	userClusterConfig.MetricsDimensions = cachedClusterConfig.MetricsDimensions
+
 	if userClusterConfig.InstanceType != nil && *userClusterConfig.InstanceType != *cachedClusterConfig.InstanceType {
 		return clusterconfig.ErrorConfigCannotBeChangedOnUpdate(clusterconfig.InstanceTypeKey, *cachedClusterConfig.InstanceType)
 	}
@@ -295,6 +318,21 @@ func setConfigFieldsFromCached(userClusterConfig *clusterconfig.Config, cachedCl
 	}
 	userClusterConfig.InstanceVolumeIOPS = cachedClusterConfig.InstanceVolumeIOPS
 
+	if userClusterConfig.CPUManagerPolicy != cachedClusterConfig.CPUManagerPolicy {
+		return clusterconfig.ErrorConfigCannotBeChangedOnUpdate(clusterconfig.CPUManagerPolicyKey, cachedClusterConfig.CPUManagerPolicy)
+	}
+	userClusterConfig.CPUManagerPolicy = cachedClusterConfig.CPUManagerPolicy
+
+	if userClusterConfig.TopologyManagerPolicy != cachedClusterConfig.TopologyManagerPolicy {
+		return clusterconfig.ErrorConfigCannotBeChangedOnUpdate(clusterconfig.TopologyManagerPolicyKey, cachedClusterConfig.TopologyManagerPolicy)
+	}
+	userClusterConfig.TopologyManagerPolicy = cachedClusterConfig.TopologyManagerPolicy
+
+	if userClusterConfig.InstanceHugePages != cachedClusterConfig.InstanceHugePages {
+		return clusterconfig.ErrorConfigCannotBeChangedOnUpdate(clusterconfig.InstanceHugePagesKey, cachedClusterConfig.InstanceHugePages)
+	}
+	userClusterConfig.InstanceHugePages = cachedClusterConfig.InstanceHugePages
+
 	if userClusterConfig.SubnetVisibility != cachedClusterConfig.SubnetVisibility {
 		return clusterconfig.ErrorConfigCannotBeChangedOnUpdate(clusterconfig.SubnetVisibilityKey, cachedClusterConfig.SubnetVisibility)
 	}
@@ -310,11 +348,25 @@ func setConfigFieldsFromCached(userClusterConfig *clusterconfig.Config, cachedCl
 	}
 	userClusterConfig.APILoadBalancerScheme = cachedClusterConfig.APILoadBalancerScheme
 
+	if userClusterConfig.APIIngress != cachedClusterConfig.APIIngress {
+		return clusterconfig.ErrorConfigCannotBeChangedOnUpdate(clusterconfig.APIIngressKey, cachedClusterConfig.APIIngress)
+	}
+	userClusterConfig.APIIngress = cachedClusterConfig.APIIngress
+
+	if !reflect.DeepEqual(userClusterConfig.GatewayAPIParent, cachedClusterConfig.GatewayAPIParent) {
+		return clusterconfig.ErrorConfigCannotBeChangedOnUpdate(clusterconfig.GatewayAPIParentKey, cachedClusterConfig.GatewayAPIParent)
+	}
+	userClusterConfig.GatewayAPIParent = cachedClusterConfig.GatewayAPIParent
+
 	if userClusterConfig.OperatorLoadBalancerScheme != cachedClusterConfig.OperatorLoadBalancerScheme {
 		return clusterconfig.ErrorConfigCannotBeChangedOnUpdate(clusterconfig.OperatorLoadBalancerSchemeKey, cachedClusterConfig.OperatorLoadBalancerScheme)
 	}
 	userClusterConfig.OperatorLoadBalancerScheme = cachedClusterConfig.OperatorLoadBalancerScheme
 
+	if s.Obj(cachedClusterConfig.OperatorServer.SSLCertificateARN) != s.Obj(userClusterConfig.OperatorServer.SSLCertificateARN) {
+		return clusterconfig.ErrorConfigCannotBeChangedOnUpdate(clusterconfig.OperatorServerKey+"."+clusterconfig.SSLCertificateARNKey, cachedClusterConfig.OperatorServer.SSLCertificateARN)
+	}
+
 	if userClusterConfig.Spot != nil && *userClusterConfig.Spot != *cachedClusterConfig.Spot {
 		return clusterconfig.ErrorConfigCannotBeChangedOnUpdate(clusterconfig.SpotKey, *cachedClusterConfig.Spot)
 	}
@@ -522,6 +574,15 @@ func clusterConfigConfirmationStr(clusterConfig clusterconfig.Config, awsCreds A
 	if clusterConfig.InstanceVolumeIOPS != nil {
 		items.Add(clusterconfig.InstanceVolumeIOPSUserKey, *clusterConfig.InstanceVolumeIOPS)
 	}
+	if clusterConfig.CPUManagerPolicy != defaultConfig.CPUManagerPolicy {
+		items.Add(clusterconfig.CPUManagerPolicyUserKey, clusterConfig.CPUManagerPolicy)
+	}
+	if clusterConfig.TopologyManagerPolicy != defaultConfig.TopologyManagerPolicy {
+		items.Add(clusterconfig.TopologyManagerPolicyUserKey, clusterConfig.TopologyManagerPolicy)
+	}
+	if clusterConfig.InstanceHugePages != defaultConfig.InstanceHugePages {
+		items.Add(clusterconfig.InstanceHugePagesUserKey, clusterConfig.InstanceHugePages)
+	}
 
 	if clusterConfig.SubnetVisibility != defaultConfig.SubnetVisibility {
 		items.Add(clusterconfig.SubnetVisibilityUserKey, clusterConfig.SubnetVisibility)
@@ -532,9 +593,52 @@ func clusterConfigConfirmationStr(clusterConfig clusterconfig.Config, awsCreds A
 	if clusterConfig.APILoadBalancerScheme != defaultConfig.APILoadBalancerScheme {
 		items.Add(clusterconfig.APILoadBalancerSchemeUserKey, clusterConfig.APILoadBalancerScheme)
 	}
+	if clusterConfig.APIIngress != defaultConfig.APIIngress {
+		items.Add(clusterconfig.APIIngressUserKey, clusterConfig.APIIngress)
+	}
+	if clusterConfig.GatewayAPIParent != nil {
+		items.Add(clusterconfig.GatewayAPIParentUserKey, clusterConfig.GatewayAPIParent.Namespace+"/"+clusterConfig.GatewayAPIParent.Name)
+	}
 	if clusterConfig.OperatorLoadBalancerScheme != defaultConfig.OperatorLoadBalancerScheme {
 		items.Add(clusterconfig.OperatorLoadBalancerSchemeUserKey, clusterConfig.OperatorLoadBalancerScheme)
 	}
+	if clusterConfig.OperatorServer.ReadHeaderTimeout != defaultConfig.OperatorServer.ReadHeaderTimeout {
+		items.Add(clusterconfig.OperatorReadHeaderTimeoutUserKey, clusterConfig.OperatorServer.ReadHeaderTimeout)
+	}
+	if clusterConfig.OperatorServer.ReadTimeout != defaultConfig.OperatorServer.ReadTimeout {
+		items.Add(clusterconfig.OperatorReadTimeoutUserKey, clusterConfig.OperatorServer.ReadTimeout)
+	}
+	if clusterConfig.OperatorServer.WriteTimeout != defaultConfig.OperatorServer.WriteTimeout {
+		items.Add(clusterconfig.OperatorWriteTimeoutUserKey, clusterConfig.OperatorServer.WriteTimeout)
+	}
+	if clusterConfig.OperatorServer.IdleTimeout != defaultConfig.OperatorServer.IdleTimeout {
+		items.Add(clusterconfig.OperatorIdleTimeoutUserKey, clusterConfig.OperatorServer.IdleTimeout)
+	}
+	if clusterConfig.OperatorServer.MaxHeaderSize != defaultConfig.OperatorServer.MaxHeaderSize {
+		items.Add(clusterconfig.OperatorMaxHeaderSizeUserKey, clusterConfig.OperatorServer.MaxHeaderSize)
+	}
+	if clusterConfig.OperatorServer.MaxRequestBodySize != defaultConfig.OperatorServer.MaxRequestBodySize {
+		items.Add(clusterconfig.OperatorMaxRequestBodySizeUserKey, clusterConfig.OperatorServer.MaxRequestBodySize)
+	}
+	if clusterConfig.OperatorServer.Replicas != defaultConfig.OperatorServer.Replicas {
+		items.Add(clusterconfig.OperatorReplicasUserKey, clusterConfig.OperatorServer.Replicas)
+	}
+	if clusterConfig.OperatorServer.SSLCertificateARN != nil {
+		items.Add(clusterconfig.OperatorSSLCertificateARNUserKey, *clusterConfig.OperatorServer.SSLCertificateARN)
+	}
+	if clusterConfig.Proxy != nil {
+		items.Add(clusterconfig.HTTPSProxyUserKey, clusterConfig.Proxy.HTTPSProxy)
+		if len(clusterConfig.Proxy.NoProxy) > 0 {
+			items.Add(clusterconfig.NoProxyUserKey, s.StrsAnd(clusterConfig.Proxy.NoProxy))
+		}
+		if clusterConfig.Proxy.CABundle != nil {
+			items.Add(clusterconfig.CABundleUserKey, s.YesNo(true))
+		}
+	}
+	if clusterConfig.OperatorPrivateLink != nil {
+		items.Add(clusterconfig.OperatorPrivateLinkAllowedPrincipalsUserKey, clusterConfig.OperatorPrivateLink.AllowedPrincipals)
+		items.Add(clusterconfig.OperatorPrivateLinkAcceptanceRequiredUserKey, s.YesNo(clusterConfig.OperatorPrivateLink.AcceptanceRequired))
+	}
 
 	if clusterConfig.Spot != nil && *clusterConfig.Spot != *defaultConfig.Spot {
 		items.Add(clusterconfig.SpotUserKey, s.YesNo(clusterConfig.Spot != nil && *clusterConfig.Spot))
@@ -584,6 +688,12 @@ func clusterConfigConfirmationStr(clusterConfig clusterconfig.Config, awsCreds A
 	if clusterConfig.ImageRequestMonitor != defaultConfig.ImageRequestMonitor {
 		items.Add(clusterconfig.ImageRequestMonitorUserKey, clusterConfig.ImageRequestMonitor)
 	}
+	if clusterConfig.ImageEgressProxy != defaultConfig.ImageEgressProxy {
+		items.Add(clusterconfig.ImageEgressProxyUserKey, clusterConfig.ImageEgressProxy)
+	}
+	if clusterConfig.ImageLoadTester != defaultConfig.ImageLoadTester {
+		items.Add(clusterconfig.ImageLoadTesterUserKey, clusterConfig.ImageLoadTester)
+	}
 	if clusterConfig.ImageClusterAutoscaler != defaultConfig.ImageClusterAutoscaler {
 		items.Add(clusterconfig.ImageClusterAutoscalerUserKey, clusterConfig.ImageClusterAutoscaler)
 	}
@@ -23,6 +23,7 @@ import (
 	"io"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"syscall"
 	"time"
@@ -32,6 +33,7 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/exit"
 	"github.com/cortexlabs/cortex/pkg/lib/files"
+	"github.com/cortexlabs/cortex/pkg/lib/transport"
 	"github.com/cortexlabs/cortex/pkg/types/clusterconfig"
 	"github.com/cortexlabs/yaml"
 	dockertypes "github.com/docker/docker/api/types"
@@ -42,6 +44,23 @@ import (
 func runManager(containerConfig *container.Config, addNewLineAfterPull bool) (string, *int, error) {
 	containerConfig.Env = append(containerConfig.Env, "CORTEX_CLI_VERSION="+consts.CortexVersion)
 
+	// forward the proxy and certificate authority settings to the aws and kubernetes clients which run in the manager
+	for _, envVar := range []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy", "NO_PROXY", "no_proxy"} {
+		if val := os.Getenv(envVar); val != "" {
+			containerConfig.Env = append(containerConfig.Env, envVar+"="+val)
+		}
+	}
+	if caBundlePath := os.Getenv(transport.CABundleEnvVar); caBundlePath != "" {
+		caBundle, err := files.ReadFileBytes(caBundlePath)
+		if err != nil {
+			return "", nil, errors.Wrap(err, transport.CABundleEnvVar)
+		}
+		if err := files.WriteFile(caBundle, filepath.Join(_localDir, "ca-bundle.pem")); err != nil {
+			return "", nil, err
+		}
+		containerConfig.Env = append(containerConfig.Env, "AWS_CA_BUNDLE=/.cortex/ca-bundle.pem")
+	}
+
 	// Add a slight delay before running the command to ensure logs don't start until after the container is attached
 	containerConfig.Cmd[0] = "sleep 0.1 && /root/check_cortex_version.sh && " + containerConfig.Cmd[0]
 
@@ -170,15 +189,15 @@ func runManagerUpdateCommand(entrypoint string, clusterConfig *clusterconfig.Con
 			"CORTEX_DEV_DEFAULT_PREDICTOR_IMAGE_REGISTRY=" + os.Getenv("CORTEX_DEV_DEFAULT_PREDICTOR_IMAGE_REGISTRY"),
 			"CORTEX_CLUSTER_CONFIG_FILE=" + mountedConfigPath,
 			"CORTEX_CLUSTER_WORKSPACE=" + clusterWorkspace,
-			"CORTEX_IMAGE_PYTHON_PREDICTOR_CPU=" + consts.DefaultImagePythonPredictorCPU,
-			"CORTEX_IMAGE_PYTHON_PREDICTOR_GPU=" + consts.DefaultImagePythonPredictorGPU,
-			"CORTEX_IMAGE_PYTHON_PREDICTOR_INF=" + consts.DefaultImagePythonPredictorInf,
-			"CORTEX_IMAGE_TENSORFLOW_SERVING_CPU=" + consts.DefaultImageTensorFlowServingCPU,
-			"CORTEX_IMAGE_TENSORFLOW_SERVING_GPU=" + consts.DefaultImageTensorFlowServingGPU,
-			"CORTEX_IMAGE_TENSORFLOW_SERVING_INF=" + consts.DefaultImageTensorFlowServingInf,
-			"CORTEX_IMAGE_TENSORFLOW_PREDICTOR=" + consts.DefaultImageTensorFlowPredictor,
-			"CORTEX_IMAGE_ONNX_PREDICTOR_CPU=" + consts.DefaultImageONNXPredictorCPU,
-			"CORTEX_IMAGE_ONNX_PREDICTOR_GPU=" + consts.DefaultImageONNXPredictorGPU,
+			"CORTEX_IMAGE_PYTHON_PREDICTOR_CPU=" + clusterconfig.MirroredImage(consts.DefaultImagePythonPredictorCPU, clusterConfig.ImageRegistry),
+			"CORTEX_IMAGE_PYTHON_PREDICTOR_GPU=" + clusterconfig.MirroredImage(consts.DefaultImagePythonPredictorGPU, clusterConfig.ImageRegistry),
+			"CORTEX_IMAGE_PYTHON_PREDICTOR_INF=" + clusterconfig.MirroredImage(consts.DefaultImagePythonPredictorInf, clusterConfig.ImageRegistry),
+			"CORTEX_IMAGE_TENSORFLOW_SERVING_CPU=" + clusterconfig.MirroredImage(consts.DefaultImageTensorFlowServingCPU, clusterConfig.ImageRegistry),
+			"CORTEX_IMAGE_TENSORFLOW_SERVING_GPU=" + clusterconfig.MirroredImage(consts.DefaultImageTensorFlowServingGPU, clusterConfig.ImageRegistry),
+			"CORTEX_IMAGE_TENSORFLOW_SERVING_INF=" + clusterconfig.MirroredImage(consts.DefaultImageTensorFlowServingInf, clusterConfig.ImageRegistry),
+			"CORTEX_IMAGE_TENSORFLOW_PREDICTOR=" + clusterconfig.MirroredImage(consts.DefaultImageTensorFlowPredictor, clusterConfig.ImageRegistry),
+			"CORTEX_IMAGE_ONNX_PREDICTOR_CPU=" + clusterconfig.MirroredImage(consts.DefaultImageONNXPredictorCPU, clusterConfig.ImageRegistry),
+			"CORTEX_IMAGE_ONNX_PREDICTOR_GPU=" + clusterconfig.MirroredImage(consts.DefaultImageONNXPredictorGPU, clusterConfig.ImageRegistry),
 		},
 	}
 
@@ -0,0 +1,191 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cortexlabs/cortex/cli/cluster"
+	"github.com/cortexlabs/cortex/pkg/lib/exit"
+	"github.com/cortexlabs/cortex/pkg/lib/files"
+	"github.com/cortexlabs/cortex/pkg/lib/table"
+	"github.com/cortexlabs/cortex/pkg/lib/telemetry"
+	libtime "github.com/cortexlabs/cortex/pkg/lib/time"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+	"github.com/cortexlabs/cortex/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	_flagLoadTestEnv          string
+	_flagLoadTestRPS          int
+	_flagLoadTestDuration     int
+	_flagLoadTestPayloadFile  string
+	_flagLoadTestMaxP99       float64
+	_flagLoadTestMaxErrorRate float64
+	_flagLoadTestJobID        string
+)
+
+const _loadTestPollPeriod = 5 * time.Second
+
+func loadTestInit() {
+	_loadTestCmd.Flags().SortFlags = false
+	_loadTestCmd.Flags().StringVarP(&_flagLoadTestEnv, "env", "e", getDefaultEnv(_generalCommandType), "environment to use")
+	_loadTestCmd.Flags().IntVarP(&_flagLoadTestRPS, "rps", "r", 10, "requests per second to send")
+	_loadTestCmd.Flags().IntVarP(&_flagLoadTestDuration, "duration", "d", 60, "duration of the load test in seconds")
+	_loadTestCmd.Flags().StringVarP(&_flagLoadTestPayloadFile, "payload-file", "p", "", "path to a json file to use as the request payload")
+	_loadTestCmd.Flags().Float64Var(&_flagLoadTestMaxP99, "max-p99-latency", 0, "fail if the p99 latency (in milliseconds) exceeds this value (0 to disable)")
+	_loadTestCmd.Flags().Float64Var(&_flagLoadTestMaxErrorRate, "max-error-rate", 0, "fail if the fraction of failed requests exceeds this value, e.g. 0.01 (0 to disable)")
+	_loadTestCmd.Flags().StringVar(&_flagLoadTestJobID, "job-id", "", "retrieve the results of an existing load test instead of starting a new one")
+}
+
+var _loadTestCmd = &cobra.Command{
+	Use:   "load-test API_NAME",
+	Short: "send synthetic traffic to an api from within the cluster and report latency and autoscaling behavior",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		env, err := ReadOrConfigureEnv(_flagLoadTestEnv)
+		if err != nil {
+			telemetry.Event("cli.load-test")
+			exit.Error(err)
+		}
+		telemetry.Event("cli.load-test", map[string]interface{}{"provider": env.Provider.String(), "env_name": env.Name})
+
+		err = printEnvIfNotSpecified(_flagLoadTestEnv, cmd)
+		if err != nil {
+			exit.Error(err)
+		}
+
+		if env.Provider == types.LocalProviderType {
+			exit.Error(ErrorNotSupportedInLocalEnvironment())
+		}
+
+		apiName := args[0]
+		operatorConfig := MustGetOperatorConfig(env.Name)
+
+		jobID := _flagLoadTestJobID
+		if jobID == "" {
+			if _flagLoadTestPayloadFile == "" {
+				exit.Error(ErrorLoadTestPayloadFileRequired())
+			}
+
+			payload, err := files.ReadFileBytes(_flagLoadTestPayloadFile)
+			if err != nil {
+				exit.Error(err)
+			}
+
+			loadTestRes, err := cluster.LoadTest(operatorConfig, apiName, payload, _flagLoadTestRPS, _flagLoadTestDuration)
+			if err != nil {
+				exit.Error(err)
+			}
+			jobID = loadTestRes.JobID
+
+			fmt.Printf("started load test %s, which will send %d requests per second to %s for %ds (if interrupted, its results can be retrieved with `cortex load-test %s --job-id %s`) ...\n\n", jobID, _flagLoadTestRPS, apiName, _flagLoadTestDuration, apiName, jobID)
+		}
+
+		loadTestRes, err := waitForLoadTest(operatorConfig, apiName, jobID)
+		if err != nil {
+			exit.Error(err)
+		}
+
+		fmt.Println(loadTestResultStr(loadTestRes.Result))
+
+		if err := checkLoadTestThresholds(loadTestRes.Result, _flagLoadTestMaxP99, _flagLoadTestMaxErrorRate); err != nil {
+			exit.Error(err)
+		}
+	},
+}
+
+// polls the operator until the load test has completed
+func waitForLoadTest(operatorConfig cluster.OperatorConfig, apiName string, jobID string) (*schema.LoadTestResponse, error) {
+	for {
+		loadTestRes, err := cluster.GetLoadTest(operatorConfig, apiName, jobID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch loadTestRes.Status {
+		case schema.LoadTestSucceeded:
+			return loadTestRes, nil
+		case schema.LoadTestFailed:
+			return nil, ErrorLoadTestFailed(jobID, loadTestRes.Message)
+		}
+
+		time.Sleep(_loadTestPollPeriod)
+	}
+}
+
+func loadTestResultStr(loadTestRes *schema.LoadTestResult) string {
+	var items table.KeyValuePairs
+	items.Add("requests", loadTestRes.NumRequests)
+	items.Add("requests per second", fmt.Sprintf("%.1f", loadTestRes.ActualRPS))
+	items.Add("errors", loadTestRes.NumErrors)
+	items.Add("connection errors", loadTestRes.NumConnectionErrors)
+	items.Add("p50 latency", fmt.Sprintf("%.1f ms", loadTestRes.LatencyP50))
+	items.Add("p90 latency", fmt.Sprintf("%.1f ms", loadTestRes.LatencyP90))
+	items.Add("p99 latency", fmt.Sprintf("%.1f ms", loadTestRes.LatencyP99))
+	items.Add("max latency", fmt.Sprintf("%.1f ms", loadTestRes.LatencyMax))
+	items.Add("max requested replicas", loadTestRes.MaxRequestedReplicas)
+	out := items.String()
+
+	statusCodes := make([]int, 0, len(loadTestRes.StatusCodes))
+	for statusCode := range loadTestRes.StatusCodes {
+		statusCodes = append(statusCodes, statusCode)
+	}
+	sort.Ints(statusCodes)
+
+	if len(statusCodes) > 0 {
+		var statusCodeItems table.KeyValuePairs
+		for _, statusCode := range statusCodes {
+			statusCodeItems.Add(statusCode, loadTestRes.StatusCodes[statusCode])
+		}
+		out += "\nstatus codes:\n" + statusCodeItems.String()
+	}
+
+	if len(loadTestRes.ReplicaSamples) > 0 {
+		t := table.Table{
+			Headers: []table.Header{
+				{Title: "time"},
+				{Title: "requested replicas"},
+				{Title: "ready replicas"},
+			},
+		}
+		for _, sample := range loadTestRes.ReplicaSamples {
+			t.Rows = append(t.Rows, []interface{}{libtime.LocalTimestamp(&sample.Timestamp), sample.Requested, sample.Ready})
+		}
+		out += "\n" + t.MustFormat()
+	}
+
+	return out
+}
+
+func checkLoadTestThresholds(loadTestRes *schema.LoadTestResult, maxP99 float64, maxErrorRate float64) error {
+	if maxP99 > 0 && loadTestRes.LatencyP99 > maxP99 {
+		return ErrorLoadTestLatencyThresholdExceeded(loadTestRes.LatencyP99, maxP99)
+	}
+
+	if maxErrorRate > 0 && loadTestRes.NumRequests > 0 {
+		errorRate := float64(loadTestRes.NumErrors) / float64(loadTestRes.NumRequests)
+		if errorRate > maxErrorRate {
+			return ErrorLoadTestErrorRateThresholdExceeded(errorRate, maxErrorRate)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/cortexlabs/cortex/cli/cluster"
+	"github.com/cortexlabs/cortex/pkg/lib/exit"
+	"github.com/cortexlabs/cortex/pkg/lib/print"
+	"github.com/cortexlabs/cortex/pkg/lib/telemetry"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+	"github.com/cortexlabs/cortex/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	_flagMaintenanceEnv        string
+	_flagMaintenanceOn         bool
+	_flagMaintenanceOff        bool
+	_flagMaintenanceStatusCode int
+	_flagMaintenanceMessage    string
+)
+
+func maintenanceInit() {
+	_maintenanceCmd.Flags().SortFlags = false
+	_maintenanceCmd.Flags().StringVarP(&_flagMaintenanceEnv, "env", "e", getDefaultEnv(_generalCommandType), "environment to use")
+	_maintenanceCmd.Flags().BoolVar(&_flagMaintenanceOn, "on", false, "put the api into maintenance mode")
+	_maintenanceCmd.Flags().BoolVar(&_flagMaintenanceOff, "off", false, "take the api out of maintenance mode")
+	_maintenanceCmd.Flags().IntVar(&_flagMaintenanceStatusCode, "status-code", 503, "status code to respond with while in maintenance mode")
+	_maintenanceCmd.Flags().StringVar(&_flagMaintenanceMessage, "message", "", "message describing the maintenance (shown in `cortex get`)")
+}
+
+var _maintenanceCmd = &cobra.Command{
+	Use:   "maintenance API_NAME",
+	Short: "respond to all requests for an api with an error status code (without stopping its replicas)",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		env, err := ReadOrConfigureEnv(_flagMaintenanceEnv)
+		if err != nil {
+			telemetry.Event("cli.maintenance")
+			exit.Error(err)
+		}
+		telemetry.Event("cli.maintenance", map[string]interface{}{"provider": env.Provider.String(), "env_name": env.Name})
+
+		err = printEnvIfNotSpecified(_flagMaintenanceEnv, cmd)
+		if err != nil {
+			exit.Error(err)
+		}
+
+		if env.Provider == types.LocalProviderType {
+			exit.Error(ErrorNotSupportedInLocalEnvironment())
+		}
+
+		if _flagMaintenanceOn == _flagMaintenanceOff {
+			exit.Error(ErrorMaintenanceFlagRequired())
+		}
+
+		var maintenance *schema.Maintenance
+		if _flagMaintenanceOn {
+			maintenance = &schema.Maintenance{
+				StatusCode: _flagMaintenanceStatusCode,
+				Message:    _flagMaintenanceMessage,
+			}
+		}
+
+		maintenanceResponse, err := cluster.SetMaintenance(MustGetOperatorConfig(env.Name), args[0], maintenance)
+		if err != nil {
+			exit.Error(err)
+		}
+		print.BoldFirstLine(maintenanceResponse.Message)
+	},
+}
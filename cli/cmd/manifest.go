@@ -0,0 +1,144 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cortexlabs/cortex/cli/cluster"
+	"github.com/cortexlabs/cortex/pkg/lib/exit"
+	"github.com/cortexlabs/cortex/pkg/lib/json"
+	"github.com/cortexlabs/cortex/pkg/lib/table"
+	"github.com/cortexlabs/cortex/pkg/lib/telemetry"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+	"github.com/cortexlabs/cortex/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	_flagManifestEnv    string
+	_flagManifestOutput string
+)
+
+func manifestInit() {
+	_manifestCmd.Flags().SortFlags = false
+	_manifestCmd.Flags().StringVarP(&_flagManifestEnv, "env", "e", getDefaultEnv(_generalCommandType), "environment to use")
+	_manifestCmd.Flags().StringVarP(&_flagManifestOutput, "output", "o", "", "path to write the manifest to (as json)")
+}
+
+var _manifestCmd = &cobra.Command{
+	Use:   "manifest API_NAME",
+	Short: "show the resolved environment of an api (for reproducing it)",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		env, err := ReadOrConfigureEnv(_flagManifestEnv)
+		if err != nil {
+			telemetry.Event("cli.manifest")
+			exit.Error(err)
+		}
+		telemetry.Event("cli.manifest", map[string]interface{}{"provider": env.Provider.String(), "env_name": env.Name})
+
+		err = printEnvIfNotSpecified(_flagManifestEnv, cmd)
+		if err != nil {
+			exit.Error(err)
+		}
+
+		if env.Provider == types.LocalProviderType {
+			exit.Error(ErrorNotSupportedInLocalEnvironment())
+		}
+
+		manifestRes, err := cluster.GetManifest(MustGetOperatorConfig(env.Name), args[0])
+		if err != nil {
+			exit.Error(err)
+		}
+
+		if _flagManifestOutput != "" {
+			if err := json.WriteJSON(manifestRes, _flagManifestOutput); err != nil {
+				exit.Error(err)
+			}
+			fmt.Printf("wrote the manifest of %s to %s\n", args[0], _flagManifestOutput)
+			return
+		}
+
+		fmt.Print(manifestStr(manifestRes))
+	},
+}
+
+func manifestStr(manifestRes schema.GetManifestResponse) string {
+	out := ""
+
+	if len(manifestRes.Images) > 0 {
+		t := table.Table{
+			Headers: []table.Header{
+				{Title: "container"},
+				{Title: "image"},
+				{Title: "image digest"},
+			},
+		}
+		for _, image := range manifestRes.Images {
+			t.Rows = append(t.Rows, []interface{}{image.Container, image.Image, image.ImageDigest})
+		}
+		out += t.MustFormat()
+	} else {
+		out += "image digests are not available yet (no containers are running)\n"
+	}
+
+	environment := manifestRes.Environment
+	if environment == nil {
+		return out + "\nthe environment has not been recorded yet (it is recorded when a replica starts)\n"
+	}
+
+	var items table.KeyValuePairs
+	items.Add("python version", environment.PythonVersion)
+	items.Add("cuda version", optionalStr(environment.CUDAVersion))
+	items.Add("cudnn version", optionalStr(environment.CUDNNVersion))
+	items.Add("nvidia driver version", optionalStr(environment.NvidiaDriverVersion))
+	out += "\n" + items.String()
+
+	if len(environment.PipPackages) > 0 {
+		out += "\npip packages:\n" + strings.Join(environment.PipPackages, "\n") + "\n"
+	}
+
+	if len(environment.CondaPackages) > 0 {
+		out += "\nconda packages:\n" + strings.Join(environment.CondaPackages, "\n") + "\n"
+	}
+
+	if len(environment.EnvVars) > 0 {
+		envVarNames := make([]string, 0, len(environment.EnvVars))
+		for name := range environment.EnvVars {
+			envVarNames = append(envVarNames, name)
+		}
+		sort.Strings(envVarNames)
+
+		var envVarItems table.KeyValuePairs
+		for _, name := range envVarNames {
+			envVarItems.Add(name, environment.EnvVars[name])
+		}
+		out += "\nenvironment variables:\n" + envVarItems.String()
+	}
+
+	return out
+}
+
+func optionalStr(str *string) string {
+	if str == nil {
+		return "-"
+	}
+	return *str
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/cortexlabs/cortex/cli/cluster"
+	"github.com/cortexlabs/cortex/cli/types/cliconfig"
+	"github.com/cortexlabs/cortex/pkg/lib/exit"
+	"github.com/cortexlabs/cortex/pkg/lib/print"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
+	"github.com/cortexlabs/cortex/pkg/lib/table"
+	"github.com/cortexlabs/cortex/pkg/lib/telemetry"
+	libtime "github.com/cortexlabs/cortex/pkg/lib/time"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+	"github.com/cortexlabs/cortex/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+const (
+	_titleBranch  = "branch"
+	_titleExpires = "expires in"
+)
+
+var (
+	_flagPreviewEnv    string
+	_flagPreviewRemote string
+)
+
+func previewInit() {
+	_previewListCmd.Flags().SortFlags = false
+	_previewListCmd.Flags().StringVarP(&_flagPreviewEnv, "env", "e", getDefaultEnv(_generalCommandType), "environment to use")
+	_previewCmd.AddCommand(_previewListCmd)
+
+	_previewDeleteCmd.Flags().SortFlags = false
+	_previewDeleteCmd.Flags().StringVarP(&_flagPreviewEnv, "env", "e", getDefaultEnv(_generalCommandType), "environment to use")
+	_previewCmd.AddCommand(_previewDeleteCmd)
+
+	_previewPruneCmd.Flags().SortFlags = false
+	_previewPruneCmd.Flags().StringVarP(&_flagPreviewEnv, "env", "e", getDefaultEnv(_generalCommandType), "environment to use")
+	_previewPruneCmd.Flags().StringVar(&_flagPreviewRemote, "remote", "origin", "git remote whose branches are checked")
+	_previewCmd.AddCommand(_previewPruneCmd)
+}
+
+var _previewCmd = &cobra.Command{
+	Use:   "preview",
+	Short: "manage preview apis which were deployed for git branches (via `cortex deploy --preview`)",
+}
+
+var _previewListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the branches which have preview apis deployed",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		env := mustReadPreviewEnv("cli.preview.list", cmd)
+
+		previewsResponse, err := cluster.GetPreviews(MustGetOperatorConfig(env.Name))
+		if err != nil {
+			exit.Error(err)
+		}
+
+		if len(previewsResponse.Previews) == 0 {
+			fmt.Println("no preview apis are deployed")
+			return
+		}
+
+		t := previewTable(previewsResponse.Previews)
+		t.MustPrint()
+	},
+}
+
+var _previewDeleteCmd = &cobra.Command{
+	Use:   "delete BRANCH",
+	Short: "delete all of the preview apis for a branch (e.g. when the branch is merged or deleted)",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		env := mustReadPreviewEnv("cli.preview.delete", cmd)
+
+		deleteResponse, err := cluster.DeletePreview(MustGetOperatorConfig(env.Name), args[0])
+		if err != nil {
+			exit.Error(err)
+		}
+		print.BoldFirstLine(deleteResponse.Message)
+	},
+}
+
+var _previewPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "delete the preview apis of branches which no longer exist in the git remote (e.g. from ci when a branch is deleted)",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		env := mustReadPreviewEnv("cli.preview.prune", cmd)
+
+		remoteBranches, err := gitRemoteBranches(_flagPreviewRemote)
+		if err != nil {
+			exit.Error(err)
+		}
+
+		previewsResponse, err := cluster.GetPreviews(MustGetOperatorConfig(env.Name))
+		if err != nil {
+			exit.Error(err)
+		}
+
+		numDeleted := 0
+		for _, preview := range previewsResponse.Previews {
+			if remoteBranches.Has(strings.TrimPrefix(preview.Branch, "refs/heads/")) {
+				continue
+			}
+			deleteResponse, err := cluster.DeletePreview(MustGetOperatorConfig(env.Name), preview.Branch)
+			if err != nil {
+				exit.Error(err)
+			}
+			fmt.Println(deleteResponse.Message)
+			numDeleted++
+		}
+
+		if numDeleted == 0 {
+			fmt.Println("there are no preview apis for deleted branches")
+		}
+	},
+}
+
+// Returns the names of the branches in the git remote (from the git repository in the current directory)
+func gitRemoteBranches(remote string) (strset.Set, error) {
+	output, err := exec.Command("git", "ls-remote", "--heads", remote).CombinedOutput()
+	if err != nil {
+		return nil, ErrorGitListRemoteBranches(remote, string(output))
+	}
+
+	branches := strset.New()
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		branches.Add(strings.TrimPrefix(fields[1], "refs/heads/"))
+	}
+
+	// guard against deleting every preview if the remote is misconfigured
+	if len(branches) == 0 {
+		return nil, ErrorNoGitRemoteBranches(remote)
+	}
+
+	return branches, nil
+}
+
+// Exits if the environment can't be read or uses the local provider
+func mustReadPreviewEnv(eventName string, cmd *cobra.Command) cliconfig.Environment {
+	env, err := ReadOrConfigureEnv(_flagPreviewEnv)
+	if err != nil {
+		telemetry.Event(eventName)
+		exit.Error(err)
+	}
+	telemetry.Event(eventName, map[string]interface{}{"provider": env.Provider.String(), "env_name": env.Name})
+
+	err = printEnvIfNotSpecified(_flagPreviewEnv, cmd)
+	if err != nil {
+		exit.Error(err)
+	}
+
+	if env.Provider == types.LocalProviderType {
+		exit.Error(ErrorPreviewNotSupportedLocally())
+	}
+
+	return env
+}
+
+func previewTable(previews []schema.Preview) table.Table {
+	now := time.Now()
+
+	rows := make([][]interface{}, 0, len(previews))
+	for _, preview := range previews {
+		expiresIn := "-"
+		if preview.Expiration != nil {
+			expiresIn = libtime.DifferenceStr(&now, preview.Expiration)
+		}
+		rows = append(rows, []interface{}{
+			preview.Branch,
+			strings.Join(preview.APINames, ", "),
+			expiresIn,
+		})
+	}
+
+	return table.Table{
+		Headers: []table.Header{
+			{Title: _titleBranch},
+			{Title: _titleAPIs},
+			{Title: _titleExpires},
+		},
+		Rows: rows,
+	}
+}
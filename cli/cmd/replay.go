@@ -0,0 +1,208 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cortexlabs/cortex/cli/cluster"
+	"github.com/cortexlabs/cortex/pkg/lib/exit"
+	"github.com/cortexlabs/cortex/pkg/lib/json"
+	"github.com/cortexlabs/cortex/pkg/lib/table"
+	"github.com/cortexlabs/cortex/pkg/lib/telemetry"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+	"github.com/cortexlabs/cortex/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	_flagReplayEnv             string
+	_flagReplayTarget          string
+	_flagReplayStart           string
+	_flagReplayEnd             string
+	_flagReplayLimit           int
+	_flagReplayMaxMismatchRate float64
+	_flagReplayID              string
+	_flagReplayOutput          string
+)
+
+const _replayPollPeriod = 5 * time.Second
+
+func replayInit() {
+	_replayCmd.Flags().SortFlags = false
+	_replayCmd.Flags().StringVarP(&_flagReplayEnv, "env", "e", getDefaultEnv(_generalCommandType), "environment to use")
+	_replayCmd.Flags().StringVarP(&_flagReplayTarget, "target", "t", "", "name of the api to send the logged requests to (e.g. a candidate model)")
+	_replayCmd.Flags().StringVar(&_flagReplayStart, "start", "", "start of the window of logged requests, as a date (e.g. 2020-07-01) or an RFC 3339 timestamp (default: 1 hour before the end)")
+	_replayCmd.Flags().StringVar(&_flagReplayEnd, "end", "", "end of the window of logged requests, as a date (e.g. 2020-07-01, which includes the whole day) or an RFC 3339 timestamp (default: now)")
+	_replayCmd.Flags().IntVarP(&_flagReplayLimit, "limit", "l", 100, "maximum number of requests to replay (the earliest requests in the window are replayed)")
+	_replayCmd.Flags().Float64Var(&_flagReplayMaxMismatchRate, "max-mismatch-rate", 0, "fail if the fraction of requests which received a different response exceeds this value, e.g. 0.01 (0 to disable)")
+	_replayCmd.Flags().StringVar(&_flagReplayID, "replay-id", "", "retrieve the results of an existing replay instead of starting a new one")
+	_replayCmd.Flags().StringVarP(&_flagReplayOutput, "output", "o", "", "path to write the results to (as json)")
+}
+
+var _replayCmd = &cobra.Command{
+	Use:   "replay API_NAME",
+	Short: "re-send an api's logged requests to another api and compare the responses and latencies",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		env, err := ReadOrConfigureEnv(_flagReplayEnv)
+		if err != nil {
+			telemetry.Event("cli.replay")
+			exit.Error(err)
+		}
+		telemetry.Event("cli.replay", map[string]interface{}{"provider": env.Provider.String(), "env_name": env.Name})
+
+		err = printEnvIfNotSpecified(_flagReplayEnv, cmd)
+		if err != nil {
+			exit.Error(err)
+		}
+
+		if env.Provider == types.LocalProviderType {
+			exit.Error(ErrorNotSupportedInLocalEnvironment())
+		}
+
+		apiName := args[0]
+		operatorConfig := MustGetOperatorConfig(env.Name)
+
+		replayID := _flagReplayID
+		if replayID == "" {
+			if _flagReplayTarget == "" {
+				exit.Error(ErrorReplayTargetRequired())
+			}
+
+			start, err := parseReportTime(_flagReplayStart, false)
+			if err != nil {
+				exit.Error(err)
+			}
+			end, err := parseReportTime(_flagReplayEnd, true)
+			if err != nil {
+				exit.Error(err)
+			}
+
+			replayRes, err := cluster.Replay(operatorConfig, apiName, _flagReplayTarget, start, end, _flagReplayLimit)
+			if err != nil {
+				exit.Error(err)
+			}
+			replayID = replayRes.ReplayID
+
+			fmt.Printf("started replay %s, which will send up to %d of the requests logged by %s to %s (if interrupted, its results can be retrieved with `cortex replay %s --replay-id %s`) ...\n\n", replayID, _flagReplayLimit, apiName, _flagReplayTarget, apiName, replayID)
+		}
+
+		replayRes, err := waitForReplay(operatorConfig, apiName, replayID)
+		if err != nil {
+			exit.Error(err)
+		}
+
+		if _flagReplayOutput != "" {
+			if err := json.WriteJSON(replayRes.Result, _flagReplayOutput); err != nil {
+				exit.Error(err)
+			}
+			fmt.Printf("wrote the results of replay %s to %s\n\n", replayID, _flagReplayOutput)
+		}
+
+		fmt.Println(replayResultStr(replayRes.Result))
+
+		if err := checkReplayThresholds(replayRes.Result, _flagReplayMaxMismatchRate); err != nil {
+			exit.Error(err)
+		}
+	},
+}
+
+// polls the operator until the replay has completed
+func waitForReplay(operatorConfig cluster.OperatorConfig, apiName string, replayID string) (*schema.ReplayResponse, error) {
+	for {
+		replayRes, err := cluster.GetReplay(operatorConfig, apiName, replayID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch replayRes.Status {
+		case schema.ReplaySucceeded:
+			return replayRes, nil
+		case schema.ReplayFailed:
+			return nil, ErrorReplayFailed(replayID, replayRes.Message)
+		}
+
+		time.Sleep(_replayPollPeriod)
+	}
+}
+
+func replayResultStr(replayRes *schema.ReplayResult) string {
+	var items table.KeyValuePairs
+	items.Add("requests", replayRes.NumRequests)
+	items.Add("matching responses", replayRes.NumMatches)
+	items.Add("different responses", replayRes.NumMismatches)
+	items.Add("connection errors", replayRes.NumConnectionErrors)
+	items.Add(fmt.Sprintf("p50 latency (%s)", replayRes.APIName), fmt.Sprintf("%.1f ms", replayRes.LoggedLatencyP50))
+	items.Add(fmt.Sprintf("p99 latency (%s)", replayRes.APIName), fmt.Sprintf("%.1f ms", replayRes.LoggedLatencyP99))
+	items.Add(fmt.Sprintf("p50 latency (%s)", replayRes.TargetAPIName), fmt.Sprintf("%.1f ms", replayRes.TargetLatencyP50))
+	items.Add(fmt.Sprintf("p99 latency (%s)", replayRes.TargetAPIName), fmt.Sprintf("%.1f ms", replayRes.TargetLatencyP99))
+	out := items.String()
+
+	statusCodes := make([]int, 0, len(replayRes.StatusCodes))
+	for statusCode := range replayRes.StatusCodes {
+		statusCodes = append(statusCodes, statusCode)
+	}
+	sort.Ints(statusCodes)
+
+	if len(statusCodes) > 0 {
+		var statusCodeItems table.KeyValuePairs
+		for _, statusCode := range statusCodes {
+			statusCodeItems.Add(statusCode, replayRes.StatusCodes[statusCode])
+		}
+		out += fmt.Sprintf("\nstatus codes (%s):\n", replayRes.TargetAPIName) + statusCodeItems.String()
+	}
+
+	if len(replayRes.Mismatches) > 0 {
+		t := table.Table{
+			Headers: []table.Header{
+				{Title: "request id"},
+				{Title: "status"},
+				{Title: "logged response", MaxWidth: 50},
+				{Title: "target response", MaxWidth: 50},
+			},
+		}
+		for _, mismatch := range replayRes.Mismatches {
+			status := fmt.Sprintf("%d", mismatch.LoggedStatusCode)
+			if mismatch.TargetStatusCode != mismatch.LoggedStatusCode {
+				status += fmt.Sprintf(" -> %d", mismatch.TargetStatusCode)
+			}
+			t.Rows = append(t.Rows, []interface{}{mismatch.RequestID, status, singleLine(mismatch.LoggedResponse), singleLine(mismatch.TargetResponse)})
+		}
+		out += "\ndifferent responses:\n" + t.MustFormat()
+	}
+
+	return out
+}
+
+func singleLine(str string) string {
+	return strings.Join(strings.Fields(str), " ")
+}
+
+func checkReplayThresholds(replayRes *schema.ReplayResult, maxMismatchRate float64) error {
+	if maxMismatchRate > 0 && replayRes.NumRequests > 0 {
+		mismatchRate := float64(replayRes.NumMismatches+replayRes.NumConnectionErrors) / float64(replayRes.NumRequests)
+		if mismatchRate > maxMismatchRate {
+			return ErrorReplayMismatchRateThresholdExceeded(mismatchRate, maxMismatchRate)
+		}
+	}
+
+	return nil
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/exit"
 	s "github.com/cortexlabs/cortex/pkg/lib/strings"
 	"github.com/cortexlabs/cortex/pkg/lib/telemetry"
+	"github.com/cortexlabs/cortex/pkg/lib/transport"
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -89,6 +90,10 @@ func init() {
 		_cmdStr += " " + arg
 	}
 
+	if err := transport.AddCertificatesFromEnv(); err != nil {
+		exit.Error(err)
+	}
+
 	enableTelemetry, err := readTelemetryConfig()
 	if err != nil {
 		exit.Error(err)
@@ -103,9 +108,18 @@ func init() {
 	deployInit()
 	envInit()
 	getInit()
+	loadTestInit()
+	replayInit()
 	logsInit()
 	predictInit()
 	refreshInit()
+	maintenanceInit()
+	extendInit()
+	previewInit()
+	accessReportInit()
+	usageInit()
+	imagesInit()
+	manifestInit()
 	versionInit()
 }
 
@@ -136,10 +150,19 @@ func Execute() {
 
 	_rootCmd.AddCommand(_deployCmd)
 	_rootCmd.AddCommand(_refreshCmd)
+	_rootCmd.AddCommand(_maintenanceCmd)
+	_rootCmd.AddCommand(_extendCmd)
+	_rootCmd.AddCommand(_previewCmd)
 	_rootCmd.AddCommand(_getCmd)
+	_rootCmd.AddCommand(_manifestCmd)
 	_rootCmd.AddCommand(_logsCmd)
 	_rootCmd.AddCommand(_predictCmd)
+	_rootCmd.AddCommand(_loadTestCmd)
+	_rootCmd.AddCommand(_replayCmd)
 	_rootCmd.AddCommand(_deleteCmd)
+	_rootCmd.AddCommand(_imagesCmd)
+	_rootCmd.AddCommand(_accessReportCmd)
+	_rootCmd.AddCommand(_usageCmd)
 
 	_rootCmd.AddCommand(_clusterCmd)
 	_rootCmd.AddCommand(_versionCmd)
@@ -0,0 +1,123 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cortexlabs/cortex/cli/cluster"
+	"github.com/cortexlabs/cortex/pkg/lib/exit"
+	"github.com/cortexlabs/cortex/pkg/lib/json"
+	s "github.com/cortexlabs/cortex/pkg/lib/strings"
+	"github.com/cortexlabs/cortex/pkg/lib/table"
+	"github.com/cortexlabs/cortex/pkg/lib/telemetry"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+	"github.com/cortexlabs/cortex/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	_flagUsageEnv    string
+	_flagUsageStart  string
+	_flagUsageEnd    string
+	_flagUsageOutput string
+)
+
+func usageInit() {
+	_usageCmd.Flags().SortFlags = false
+	_usageCmd.Flags().StringVarP(&_flagUsageEnv, "env", "e", getDefaultEnv(_generalCommandType), "environment to use")
+	_usageCmd.Flags().StringVar(&_flagUsageStart, "start", "", "start of the report, as a date (e.g. 2020-07-01) or an RFC 3339 timestamp (default: 24 hours before the end)")
+	_usageCmd.Flags().StringVar(&_flagUsageEnd, "end", "", "end of the report, as a date (e.g. 2020-07-31, which includes the whole day) or an RFC 3339 timestamp (default: now)")
+	_usageCmd.Flags().StringVarP(&_flagUsageOutput, "output", "o", "", "path to write the report to (as json)")
+}
+
+var _usageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "report the requests, compute, and storage used by each project over a time range",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		env, err := ReadOrConfigureEnv(_flagUsageEnv)
+		if err != nil {
+			telemetry.Event("cli.usage")
+			exit.Error(err)
+		}
+		telemetry.Event("cli.usage", map[string]interface{}{"provider": env.Provider.String(), "env_name": env.Name})
+
+		if env.Provider == types.LocalProviderType {
+			exit.Error(ErrorNotSupportedInLocalEnvironment())
+		}
+
+		start, err := parseReportTime(_flagUsageStart, false)
+		if err != nil {
+			exit.Error(err)
+		}
+		end, err := parseReportTime(_flagUsageEnd, true)
+		if err != nil {
+			exit.Error(err)
+		}
+
+		usageRes, err := cluster.GetUsage(MustGetOperatorConfig(env.Name), start, end)
+		if err != nil {
+			exit.Error(err)
+		}
+
+		if _flagUsageOutput != "" {
+			if err := json.WriteJSON(usageRes, _flagUsageOutput); err != nil {
+				exit.Error(err)
+			}
+			fmt.Printf("wrote the usage report to %s\n", _flagUsageOutput)
+			return
+		}
+
+		fmt.Print(usageStr(usageRes))
+	},
+}
+
+func usageStr(usageRes schema.UsageResponse) string {
+	out := fmt.Sprintf("usage from %s to %s\n\n", usageRes.Start.Local().Format(time.RFC3339), usageRes.End.Local().Format(time.RFC3339))
+
+	if len(usageRes.Projects) == 0 {
+		return out + "no apis were running during this time\n"
+	}
+
+	t := table.Table{
+		Headers: []table.Header{
+			{Title: "project"},
+			{Title: _titleAPIs},
+			{Title: "requests"},
+			{Title: "replica hours"},
+			{Title: "cpu hours"},
+			{Title: "gpu hours"},
+			{Title: "storage GB-hours"},
+		},
+	}
+	for _, project := range usageRes.Projects {
+		t.Rows = append(t.Rows, []interface{}{
+			project.Project,
+			s.TruncateEllipses(strings.Join(project.APINames, " "), 100),
+			project.RequestCount,
+			s.Round(project.ReplicaHours, 2, 0),
+			s.Round(project.CPUHours, 2, 0),
+			s.Round(project.GPUHours, 2, 0),
+			s.Round(project.StorageGBHours, 2, 0),
+		})
+	}
+
+	return out + t.MustFormat()
+}
@@ -103,6 +103,10 @@ func getAPIEnv(api *spec.API, awsClient *aws.Client) []string {
 	}
 	envs = append(envs, "CORTEX_PYTHON_PATH="+cortexPythonPath)
 
+	if api.Networking.MaxPayloadSize != nil {
+		envs = append(envs, "CORTEX_MAX_PAYLOAD_SIZE="+s.Int64(api.Networking.MaxPayloadSize.Value()))
+	}
+
 	if awsAccessKeyID := awsClient.AccessKeyID(); awsAccessKeyID != nil {
 		envs = append(envs, "AWS_ACCESS_KEY_ID="+*awsAccessKeyID)
 	}
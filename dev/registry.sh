@@ -89,6 +89,8 @@ function create_registry() {
   aws ecr create-repository --repository-name=cortexlabs/istio-citadel --region=$REGISTRY_REGION || true
   aws ecr create-repository --repository-name=cortexlabs/istio-galley --region=$REGISTRY_REGION || true
   aws ecr create-repository --repository-name=cortexlabs/request-monitor --region=$REGISTRY_REGION || true
+  aws ecr create-repository --repository-name=cortexlabs/egress-proxy --region=$REGISTRY_REGION || true
+  aws ecr create-repository --repository-name=cortexlabs/load-tester --region=$REGISTRY_REGION || true
 }
 
 ### HELPERS ###
@@ -212,6 +214,8 @@ elif [ "$cmd" = "update" ]; then
     build_and_push $ROOT/images/istio-pilot istio-pilot latest
     build_and_push $ROOT/images/istio-citadel istio-citadel latest
     build_and_push $ROOT/images/istio-galley istio-galley latest
+    build_and_push $ROOT/images/egress-proxy egress-proxy latest
+    build_and_push $ROOT/images/load-tester load-tester latest
   fi
 
   if [[ "$sub_cmd" == "all" || "$sub_cmd" == "dev" ]]; then
@@ -0,0 +1,64 @@
+#!/bin/bash
+
+# Copyright 2020 Cortex Labs, Inc.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+set -e
+
+# usage:
+#   run.sh init   install the pod's iptables rules so that only the proxy can make outbound connections (requires NET_ADMIN)
+#   run.sh proxy  run the proxy, allowing connections only to the domains in $CORTEX_EGRESS_ALLOWLIST (comma-separated)
+
+if [ "$1" = "init" ]; then
+    iptables -A OUTPUT -o lo -j ACCEPT
+    iptables -A OUTPUT -m conntrack --ctstate ESTABLISHED,RELATED -j ACCEPT
+    iptables -A OUTPUT -p udp --dport 53 -j ACCEPT
+    iptables -A OUTPUT -p tcp --dport 53 -j ACCEPT
+    iptables -A OUTPUT -p udp --dport 8125 -j ACCEPT  # statsd on the node
+    iptables -A OUTPUT -m owner --uid-owner $CORTEX_EGRESS_PROXY_UID -j ACCEPT
+    iptables -A OUTPUT -j REJECT
+    echo "egress is restricted to the egress proxy"
+    exit 0
+fi
+
+if [ "$1" = "proxy" ]; then
+    filter_file=/tmp/filter
+    : > $filter_file
+    IFS=',' read -ra domains <<< "$CORTEX_EGRESS_ALLOWLIST"
+    for domain in "${domains[@]}"; do
+        if [[ "$domain" == \*.* ]]; then
+            # *.example.com matches any subdomain of example.com
+            echo "\\.$(echo "${domain:2}" | sed 's/\./\\./g')\$" >> $filter_file
+        else
+            echo "^$(echo "$domain" | sed 's/\./\\./g')\$" >> $filter_file
+        fi
+    done
+
+    cat > /tmp/tinyproxy.conf <<EOT
+Port $CORTEX_EGRESS_PROXY_PORT
+Listen 127.0.0.1
+Allow 127.0.0.1
+Timeout 600
+LogLevel Connect
+Filter "$filter_file"
+FilterURLs Off
+FilterExtended On
+FilterDefaultDeny Yes
+EOT
+
+    exec tinyproxy -d -c /tmp/tinyproxy.conf
+fi
+
+echo "error: expected \"init\" or \"proxy\" as the first argument"
+exit 1
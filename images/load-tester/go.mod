@@ -0,0 +1,5 @@
+module github.com/cortexlabs/cortex/images/load-tester
+
+go 1.14
+
+require github.com/aws/aws-sdk-go v1.30.25
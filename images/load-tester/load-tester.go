@@ -0,0 +1,153 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+const _requestTimeout = 60 * time.Second
+
+var client = &http.Client{
+	Timeout: _requestTimeout,
+}
+
+// must be kept in sync with loadTestResults in pkg/operator/resources/syncapi/loadtest.go
+type results struct {
+	ElapsedSeconds float64   `json:"elapsed_seconds"`
+	StatusCodes    []int     `json:"status_codes"` // 0 if the request could not be made
+	LatenciesMs    []float64 `json:"latencies_ms"`
+}
+
+// ./load-tester url rps duration_seconds payload_key results_key
+func main() {
+	if len(os.Args) != 6 {
+		log.Fatal("usage: load-tester url rps duration_seconds payload_key results_key")
+	}
+
+	url := os.Args[1]
+	rps, err := strconv.Atoi(os.Args[2])
+	if err != nil || rps <= 0 {
+		log.Fatalf("invalid rps %q", os.Args[2])
+	}
+	durationSecs, err := strconv.Atoi(os.Args[3])
+	if err != nil || durationSecs <= 0 {
+		log.Fatalf("invalid duration %q", os.Args[3])
+	}
+	payloadKey := os.Args[4]
+	resultsKey := os.Args[5]
+	bucket := os.Getenv("CORTEX_BUCKET")
+
+	sess, err := session.NewSession(&aws.Config{
+		Credentials: nil,
+		Region:      aws.String(os.Getenv("CORTEX_REGION")),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	s3Client := s3.New(sess)
+
+	payloadObj, err := s3Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(payloadKey),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	payload, err := ioutil.ReadAll(payloadObj.Body)
+	payloadObj.Body.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("sending %d requests per second to %s for %ds", rps, url, durationSecs)
+	res := run(url, payload, rps, time.Duration(durationSecs)*time.Second)
+	log.Printf("sent %d requests in %.1fs", len(res.StatusCodes), res.ElapsedSeconds)
+
+	resBytes, err := json.Marshal(res)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:        bytes.NewReader(resBytes),
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(resultsKey),
+		ContentType: aws.String("application/json"),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// sends requests at a fixed rate, and records the status code and latency of each request
+func run(url string, payload []byte, rps int, duration time.Duration) results {
+	var res results
+	var resMux sync.Mutex
+	var wg sync.WaitGroup
+
+	start := time.Now()
+	ticker := time.NewTicker(time.Second / time.Duration(rps))
+	for time.Since(start) < duration {
+		<-ticker.C
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statusCode, latency := sendRequest(url, payload)
+			resMux.Lock()
+			res.StatusCodes = append(res.StatusCodes, statusCode)
+			res.LatenciesMs = append(res.LatenciesMs, float64(latency)/float64(time.Millisecond))
+			resMux.Unlock()
+		}()
+	}
+	ticker.Stop()
+	wg.Wait()
+	res.ElapsedSeconds = time.Since(start).Seconds()
+
+	return res
+}
+
+func sendRequest(url string, payload []byte) (int, time.Duration) {
+	start := time.Now()
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, time.Since(start)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	response, err := client.Do(req)
+	if err != nil {
+		return 0, time.Since(start)
+	}
+	ioutil.ReadAll(response.Body)
+	response.Body.Close()
+
+	return response.StatusCode, time.Since(start)
+}
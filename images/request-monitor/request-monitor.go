@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -33,10 +34,11 @@ const _tickInterval = 10 * time.Second
 const _requestSampleInterval = 1 * time.Second
 
 var (
-	client      *cloudwatch.CloudWatch
-	apiName     string
-	region      string
-	clusterName string
+	client           *cloudwatch.CloudWatch
+	apiName          string
+	region           string
+	metricsNamespace string
+	extraDimensions  []*cloudwatch.Dimension
 )
 
 type Counter struct {
@@ -59,10 +61,20 @@ func (c *Counter) GetAllAndDelete() []int {
 	return output
 }
 
-// ./request-monitor api_name cluster_name
+// ./request-monitor api_name metrics_namespace [dimension_name=dimension_value ...]
 func main() {
 	apiName = os.Args[1]
-	clusterName = os.Args[2]
+	metricsNamespace = os.Args[2]
+	for _, arg := range os.Args[3:] {
+		split := strings.SplitN(arg, "=", 2)
+		if len(split) != 2 {
+			log.Fatalf("invalid metrics dimension %q (expected name=value)", arg)
+		}
+		extraDimensions = append(extraDimensions, &cloudwatch.Dimension{
+			Name:  aws.String(split[0]),
+			Value: aws.String(split[1]),
+		})
+	}
 	region = os.Getenv("CORTEX_REGION")
 
 	sess, err := session.NewSession(&aws.Config{
@@ -76,7 +88,7 @@ func main() {
 	client = cloudwatch.New(sess)
 	requestCounter := Counter{}
 
-	os.OpenFile("/request_monitor_ready.txt", os.O_RDONLY|os.O_CREATE, 0666)
+	os.OpenFile("/mnt/request_monitor_ready.txt", os.O_RDONLY|os.O_CREATE, 0666)
 
 	for {
 		if _, err := os.Stat("/mnt/workspace/api_readiness.txt"); err == nil {
@@ -140,16 +152,19 @@ func publishStats(apiName string, counter *Counter, client *cloudwatch.CloudWatc
 	log.Printf("recorded %.2f in-flight requests on replica", total)
 	curTime := time.Now()
 	metricData := cloudwatch.PutMetricDataInput{
-		Namespace: aws.String(clusterName),
+		Namespace: aws.String(metricsNamespace),
 		MetricData: []*cloudwatch.MetricDatum{
 			{
 				MetricName: aws.String("in-flight"),
-				Dimensions: []*cloudwatch.Dimension{
-					{
-						Name:  aws.String("apiName"),
-						Value: aws.String(apiName),
+				Dimensions: append(
+					[]*cloudwatch.Dimension{
+						{
+							Name:  aws.String("apiName"),
+							Value: aws.String(apiName),
+						},
 					},
-				},
+					extraDimensions...,
+				),
 				Timestamp:         &curTime,
 				Value:             aws.Float64(total),
 				Unit:              aws.String("Count"),
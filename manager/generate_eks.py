@@ -74,6 +74,27 @@ def apply_clusterconfig(nodegroup, config):
     if config["instance_volume_type"] == "io1":
         clusterconfig_settings["volumeIOPS"] = config["instance_volume_iops"]
 
+    # the static cpu manager policy gives exclusive cores to containers of guaranteed pods which request whole cpus
+    if config.get("cpu_manager_policy", "none") == "static":
+        clusterconfig_settings["kubeletExtraConfig"] = {
+            "cpuManagerPolicy": "static",
+            "featureGates": {"CPUManager": True},
+        }
+        if config.get("topology_manager_policy", "none") != "none":
+            clusterconfig_settings["kubeletExtraConfig"]["topologyManagerPolicy"] = config[
+                "topology_manager_policy"
+            ].replace("_", "-")
+            clusterconfig_settings["kubeletExtraConfig"]["featureGates"]["TopologyManager"] = True
+
+    # huge pages must be preallocated before the kubelet starts so that it can advertise them
+    if config.get("instance_hugepages", 0) > 0:
+        clusterconfig_settings["preBootstrapCommands"] = [
+            f"sysctl -w vm.nr_hugepages={config['instance_hugepages']}"
+        ]
+        clusterconfig_settings["tags"] = {
+            "k8s.io/cluster-autoscaler/node-template/resources/hugepages-2Mi": f"{2 * config['instance_hugepages']}Mi"
+        }
+
     return merge_override(nodegroup, clusterconfig_settings)
 
 
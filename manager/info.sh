@@ -21,6 +21,15 @@ function get_operator_endpoint() {
 }
 
 function get_api_load_balancer_endpoint() {
+  if [ "$CORTEX_API_INGRESS" == "gateway-api" ]; then
+    kubectl -n="$CORTEX_GATEWAY_API_PARENT_NAMESPACE" get gateway "$CORTEX_GATEWAY_API_PARENT_NAME" -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || true
+    return
+  fi
+  if [ "$CORTEX_API_INGRESS" != "istio" ]; then
+    # all of the apis' ingresses share the load balancer provisioned by the api ingress controller
+    kubectl -n=istio-system get ingress -l apiName -o jsonpath='{.items[*].status.loadBalancer.ingress[0].hostname}' | tr ' ' '\n' | head -1
+    return
+  fi
   kubectl -n=istio-system get service ingressgateway-apis -o json | tr -d '[:space:]' | sed 's/.*{\"hostname\":\"\(.*\)\".*/\1/'
 }
 
@@ -43,3 +52,8 @@ echo -e "\033[1mendpoints:\033[0m"
 echo "operator:          $operator_endpoint"  # before modifying this, search for this prefix
 echo "api load balancer: $api_load_balancer_endpoint"
 echo "api gateway:       $api_gateway_endpoint"
+
+operator_endpoint_service_name=$(python operator_endpoint_service.py get)
+if [ "$operator_endpoint_service_name" != "" ]; then
+  echo "operator private link endpoint service: $operator_endpoint_service_name"
+fi
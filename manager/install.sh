@@ -166,8 +166,8 @@ function main() {
   # create cluster (if it doesn't already exist)
   ensure_eks
 
-  # create VPC Link for API Gateway
-  if [ "$arg1" != "--update" ] && [ "$CORTEX_API_LOAD_BALANCER_SCHEME" == "internal" ]; then
+  # create VPC Link for API Gateway (it integrates with the istio gateway's load balancer, which other api ingresses don't use)
+  if [ "$arg1" != "--update" ] && [ "$CORTEX_API_LOAD_BALANCER_SCHEME" == "internal" ] && [ "$CORTEX_API_INGRESS" == "istio" ]; then
     vpc_id=$(aws ec2 describe-vpcs --region $CORTEX_REGION --filters Name=tag:eksctl.cluster.k8s.io/v1alpha1/cluster-name,Values=$CORTEX_CLUSTER_NAME | jq .Vpcs[0].VpcId | tr -d '"')
     if [ "$vpc_id" = "" ] || [ "$vpc_id" = "null" ]; then
       echo "unable to find cortex vpc"
@@ -247,7 +247,7 @@ function main() {
   fi
 
   # add VPC Link integration to API Gateway
-  if [ "$arg1" != "--update" ] && [ "$CORTEX_API_LOAD_BALANCER_SCHEME" == "internal" ]; then
+  if [ "$arg1" != "--update" ] && [ "$CORTEX_API_LOAD_BALANCER_SCHEME" == "internal" ] && [ "$CORTEX_API_INGRESS" == "istio" ]; then
     echo -n "￮ creating api gateway vpc link integration "
     api_id=$(python get_api_gateway_id.py)
     python create_gateway_integration.py $api_id $vpc_link_id
@@ -266,6 +266,12 @@ function main() {
 
   validate_cortex
 
+  # create, update, or remove the PrivateLink endpoint service in front of the operator load balancer
+  operator_endpoint_service_name=$(python operator_endpoint_service.py sync $CORTEX_CLUSTER_CONFIG_FILE)
+  if [ "$operator_endpoint_service_name" != "" ]; then
+    echo "￮ operator private link endpoint service: $operator_endpoint_service_name"
+  fi
+
   if kubectl get daemonset image-downloader -n=default &>/dev/null; then
     echo -n "￮ downloading docker images "
     printed_dot="false"
@@ -320,13 +326,21 @@ function setup_istio() {
   echo -n "."
   envsubst < manifests/istio-namespace.yaml | kubectl apply -f - >/dev/null
 
+  # the istio charts don't support overriding the kubectl and install-cni images, so point their hub at the mirror instead
+  istio_hub_args=""
+  istio_cni_hub_args=""
+  if [ -n "$CORTEX_IMAGE_REGISTRY" ]; then
+    istio_hub_args="--set global.hub=$CORTEX_IMAGE_REGISTRY/istio"
+    istio_cni_hub_args="--set hub=$CORTEX_IMAGE_REGISTRY/istio"
+  fi
+
   if ! grep -q "istio-customgateway-certs" <<< $(kubectl get secret -n istio-system); then
     WEBSITE=localhost
     openssl req -subj "/C=US/CN=$WEBSITE" -newkey rsa:2048 -nodes -keyout $WEBSITE.key -x509 -days 3650 -out $WEBSITE.crt >/dev/null 2>&1
     kubectl create -n istio-system secret tls istio-customgateway-certs --key $WEBSITE.key --cert $WEBSITE.crt >/dev/null
   fi
 
-  helm template istio-manifests/istio-init --name istio-init --namespace istio-system | kubectl apply -f - >/dev/null
+  helm template istio-manifests/istio-init --name istio-init --namespace istio-system $istio_hub_args | kubectl apply -f - >/dev/null
   until grep -q "virtualservice" <<< $(kubectl api-resources); do
     echo -n "."
     sleep 3
@@ -335,12 +349,18 @@ function setup_istio() {
   sleep 3  # Sleep a bit longer to be safe, since there are multiple Istio initialization containers
   echo -n "."
 
-  helm template istio-manifests/istio-cni --name istio-cni --namespace kube-system | kubectl apply -f - >/dev/null
+  helm template istio-manifests/istio-cni --name istio-cni --namespace kube-system $istio_cni_hub_args | kubectl apply -f - >/dev/null
   until [ "$(kubectl get daemonset istio-cni-node -n kube-system -o 'jsonpath={.status.numberReady}')" == "$(kubectl get daemonset istio-cni-node -n kube-system -o 'jsonpath={.status.desiredNumberScheduled}')" ]; do
     echo -n "."
     sleep 3
   done
 
+  # other api ingress controllers provision their own load balancer, which forwards requests to the gateway's node ports
+  export CORTEX_API_GATEWAY_SERVICE_TYPE="LoadBalancer"
+  if [ "$CORTEX_API_INGRESS" != "istio" ]; then
+    export CORTEX_API_GATEWAY_SERVICE_TYPE="NodePort"
+  fi
+
   export CORTEX_API_LOAD_BALANCER_ANNOTATION=""
   if [ "$CORTEX_API_LOAD_BALANCER_SCHEME" == "internal" ]; then
     export CORTEX_API_LOAD_BALANCER_ANNOTATION='service.beta.kubernetes.io/aws-load-balancer-internal: "true"'
@@ -355,7 +375,17 @@ function setup_istio() {
     export CORTEX_SSL_CERTIFICATE_ANNOTATION="service.beta.kubernetes.io/aws-load-balancer-ssl-cert: $CORTEX_SSL_CERTIFICATE_ARN"
   fi
 
-  envsubst < manifests/istio-values.yaml | helm template istio-manifests/istio --values - --name istio --namespace istio-system | kubectl apply -f - >/dev/null
+  # when a certificate is provided, the operator load balancer terminates tls and forwards plain http to the gateway
+  export CORTEX_OPERATOR_SSL_CERTIFICATE_ANNOTATION=""
+  export CORTEX_OPERATOR_SSL_PORTS_ANNOTATION=""
+  export CORTEX_OPERATOR_HTTPS_TARGET_PORT="443"
+  if [[ -n "$CORTEX_OPERATOR_SERVER_SSL_CERTIFICATE_ARN" ]]; then
+    export CORTEX_OPERATOR_SSL_CERTIFICATE_ANNOTATION="service.beta.kubernetes.io/aws-load-balancer-ssl-cert: $CORTEX_OPERATOR_SERVER_SSL_CERTIFICATE_ARN"
+    export CORTEX_OPERATOR_SSL_PORTS_ANNOTATION='service.beta.kubernetes.io/aws-load-balancer-ssl-ports: "443"'
+    export CORTEX_OPERATOR_HTTPS_TARGET_PORT="80"
+  fi
+
+  envsubst < manifests/istio-values.yaml | helm template istio-manifests/istio --values - --name istio --namespace istio-system $istio_hub_args | kubectl apply -f - >/dev/null
 }
 
 function validate_cortex() {
@@ -367,6 +397,9 @@ function validate_cortex() {
 
   operator_load_balancer="waiting"
   api_load_balancer="waiting"
+  if [ "$CORTEX_API_INGRESS" != "istio" ]; then
+    api_load_balancer="ready"  # the api ingress controller creates its load balancer once an api is deployed
+  fi
   operator_endpoint_reachable="waiting"
   operator_pod_ready_cycles=0
   operator_endpoint=""
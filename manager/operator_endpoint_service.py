@@ -0,0 +1,149 @@
+# Copyright 2020 Cortex Labs, Inc.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import boto3
+import os
+import sys
+import yaml
+
+
+def get_istio_operator_elb_arn(client_elb, cluster_name):
+    paginator = client_elb.get_paginator("describe_load_balancers")
+    for elb_page in paginator.paginate():
+        for elb in elb_page["LoadBalancers"]:
+            elb_arn = elb["LoadBalancerArn"]
+            elb_tags = client_elb.describe_tags(ResourceArns=[elb_arn])["TagDescriptions"][0][
+                "Tags"
+            ]
+
+            is_from_cluster = False
+            is_operator_load_balancer = False
+            for tag in elb_tags:
+                if tag["Key"] == "cortex.dev/cluster-name" and tag["Value"] == cluster_name:
+                    is_from_cluster = True
+                if (
+                    tag["Key"] == "kubernetes.io/service-name"
+                    and tag["Value"] == "istio-system/ingressgateway-operator"
+                ):
+                    is_operator_load_balancer = True
+
+            if is_from_cluster and is_operator_load_balancer:
+                return elb_arn
+
+    raise Exception("Could not find ingressgateway-operator ELB")
+
+
+def get_endpoint_service(client_ec2, cluster_name):
+    response = client_ec2.describe_vpc_endpoint_service_configurations(
+        Filters=[{"Name": "tag:cortex.dev/cluster-name", "Values": [cluster_name]}]
+    )
+    for service in response["ServiceConfigurations"]:
+        if service["ServiceState"] not in ("Deleting", "Deleted"):
+            return service
+    return None
+
+
+def sync_endpoint_service(cluster_config_path):
+    with open(cluster_config_path, "r") as f:
+        cluster_config = yaml.safe_load(f)
+
+    cluster_name = cluster_config["cluster_name"]
+    region = cluster_config["region"]
+    private_link = cluster_config.get("operator_private_link")
+
+    client_ec2 = boto3.client("ec2", region_name=region)
+    service = get_endpoint_service(client_ec2, cluster_name)
+
+    if private_link is None:
+        if service is not None:
+            delete_endpoint_service(client_ec2, service)
+        return
+
+    if service is None:
+        client_elb = boto3.client("elbv2", region_name=region)
+        elb_arn = get_istio_operator_elb_arn(client_elb, cluster_name)
+        service = client_ec2.create_vpc_endpoint_service_configuration(
+            NetworkLoadBalancerArns=[elb_arn],
+            AcceptanceRequired=private_link["acceptance_required"],
+            TagSpecifications=[
+                {
+                    "ResourceType": "vpc-endpoint-service",
+                    "Tags": [{"Key": k, "Value": v} for k, v in cluster_config["tags"].items()],
+                }
+            ],
+        )["ServiceConfiguration"]
+    elif service["AcceptanceRequired"] != private_link["acceptance_required"]:
+        client_ec2.modify_vpc_endpoint_service_configuration(
+            ServiceId=service["ServiceId"], AcceptanceRequired=private_link["acceptance_required"]
+        )
+
+    desired_principals = set(private_link.get("allowed_principals") or [])
+    current_principals = set(
+        p["Principal"]
+        for p in client_ec2.describe_vpc_endpoint_service_permissions(
+            ServiceId=service["ServiceId"]
+        )["AllowedPrincipals"]
+    )
+    if desired_principals != current_principals:
+        client_ec2.modify_vpc_endpoint_service_permissions(
+            ServiceId=service["ServiceId"],
+            AddAllowedPrincipals=list(desired_principals - current_principals),
+            RemoveAllowedPrincipals=list(current_principals - desired_principals),
+        )
+
+    print(service["ServiceName"], end="")
+
+
+def delete_endpoint_service(client_ec2, service):
+    # endpoint services can only be deleted once no interface endpoints are connected to them
+    connections = client_ec2.describe_vpc_endpoint_connections(
+        Filters=[{"Name": "service-id", "Values": [service["ServiceId"]]}]
+    )["VpcEndpointConnections"]
+    endpoint_ids = [
+        c["VpcEndpointId"]
+        for c in connections
+        if c["VpcEndpointState"] in ("pendingAcceptance", "pending", "available")
+    ]
+    if len(endpoint_ids) > 0:
+        client_ec2.reject_vpc_endpoint_connections(
+            ServiceId=service["ServiceId"], VpcEndpointIds=endpoint_ids
+        )
+
+    client_ec2.delete_vpc_endpoint_service_configurations(ServiceIds=[service["ServiceId"]])
+
+
+def get_endpoint_service_name():
+    client_ec2 = boto3.client("ec2", region_name=os.environ["CORTEX_REGION"])
+    service = get_endpoint_service(client_ec2, os.environ["CORTEX_CLUSTER_NAME"])
+    if service is not None:
+        print(service["ServiceName"], end="")
+
+
+def delete():
+    client_ec2 = boto3.client("ec2", region_name=os.environ["CORTEX_REGION"])
+    service = get_endpoint_service(client_ec2, os.environ["CORTEX_CLUSTER_NAME"])
+    if service is not None:
+        delete_endpoint_service(client_ec2, service)
+
+
+if __name__ == "__main__":
+    command = sys.argv[1]
+    if command == "sync":
+        sync_endpoint_service(cluster_config_path=sys.argv[2])
+    elif command == "get":
+        get_endpoint_service_name()
+    elif command == "delete":
+        delete()
+    else:
+        raise Exception(f"unknown command: {command}")
@@ -26,6 +26,14 @@ function get_operator_endpoint() {
 }
 operator_endpoint=$(get_operator_endpoint)
 
+# the operator load balancer can't be deleted while a PrivateLink endpoint service references it
+python operator_endpoint_service.py delete
+
+# the api ingress controller only deletes its load balancer once the apis' ingresses are deleted
+if [ "$CORTEX_API_INGRESS" == "alb" ] || [ "$CORTEX_API_INGRESS" == "nginx" ]; then
+  kubectl -n=istio-system delete ingress -l apiName --ignore-not-found=true --timeout=5m >/dev/null 2>&1 || true
+fi
+
 echo
 
 eksctl delete cluster --wait --name=$CORTEX_CLUSTER_NAME --region=$CORTEX_REGION --timeout=$EKSCTL_TIMEOUT
@@ -47,6 +47,9 @@ def update_cli_config(
     replaced = False
     for i, prev_env in enumerate(cli_config["environments"]):
         if prev_env.get("name") == env_name:
+            # keep connecting through a PrivateLink interface endpoint if the environment was configured to use one
+            if ".vpce.amazonaws.com" in prev_env.get("operator_endpoint", ""):
+                new_env["operator_endpoint"] = prev_env["operator_endpoint"]
             cli_config["environments"][i] = new_env
             replaced = True
             break
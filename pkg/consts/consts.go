@@ -54,6 +54,7 @@ var (
 	DashboardTitle                 = "# cortex monitoring dashboard"
 	DefaultMaxReplicaConcurrency   = int64(1024)
 	NeuronCoresPerInf              = int64(4)
+	HugePageSize                   = int64(2 * 1024 * 1024) // bytes (2Mi)
 )
 
 func defaultDockerImage(imageName string) string {
@@ -64,3 +65,14 @@ func defaultDockerImage(imageName string) string {
 
 	return fmt.Sprintf("cortexlabs/%s:%s", imageName, CortexVersion)
 }
+
+// RuntimeImage returns the image of a runtime in the runtime catalog. Runtime images are tagged with the cortex version
+// and the runtime name (which identifies the framework versions), and each of these tags is only pushed once
+func RuntimeImage(imageName string, runtimeName string) string {
+	// override runtime image paths in development
+	if imageOverride := os.Getenv("CORTEX_DEV_DEFAULT_PREDICTOR_IMAGE_REGISTRY"); imageOverride != "" {
+		return fmt.Sprintf("%s/%s:latest", imageOverride, imageName)
+	}
+
+	return fmt.Sprintf("cortexlabs/%s:%s-%s", imageName, CortexVersion, runtimeName)
+}
@@ -20,10 +20,13 @@ limitations under the License.
 package aws
 
 import (
+	"net/http"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/transport"
 )
 
 type Client struct {
@@ -33,6 +36,7 @@ type Client struct {
 	clients         clients
 	accountID       *string
 	hashedAccountID *string
+	callerARN       *string
 }
 
 func NewFromEnv(region string) (*Client, error) {
@@ -97,6 +101,7 @@ func New(region string, creds *credentials.Credentials) (*Client, error) {
 		Config: aws.Config{
 			Credentials: creds,
 			Region:      aws.String(region),
+			HTTPClient:  newHTTPClient(),
 		},
 		SharedConfigState: session.SharedConfigEnable,
 	})
@@ -119,6 +124,7 @@ func NewAnonymousClientWithRegion(region string) (*Client, error) {
 	sess, err := session.NewSession(&aws.Config{
 		Credentials: credentials.AnonymousCredentials,
 		Region:      aws.String(region),
+		HTTPClient:  newHTTPClient(),
 	})
 	if err != nil {
 		return nil, err
@@ -129,3 +135,7 @@ func NewAnonymousClientWithRegion(region string) (*Client, error) {
 		IsAnonymous: true,
 	}, nil
 }
+
+func newHTTPClient() *http.Client {
+	return &http.Client{Transport: transport.New()}
+}
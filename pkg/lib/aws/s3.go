@@ -132,7 +132,7 @@ func GetBucketRegionFromS3Path(s3Path string) (string, error) {
 }
 
 func GetBucketRegion(bucket string) (string, error) {
-	sess := session.Must(session.NewSession()) // credentials are not necessary for this request, and will not be used
+	sess := session.Must(session.NewSession(&aws.Config{HTTPClient: newHTTPClient()})) // credentials are not necessary for this request, and will not be used
 	region, err := s3manager.GetBucketRegion(aws.BackgroundContext(), sess, bucket, endpoints.UsWest2RegionID)
 	if err != nil {
 		return "", ErrorBucketNotFound(bucket)
@@ -605,6 +605,34 @@ func (c *Client) DeleteS3Prefix(bucket string, prefix string, continueIfFailure
 	return nil
 }
 
+// DeleteS3Objects deletes the objects with the given keys (in batches of up to 1000, the maximum per request)
+func (c *Client) DeleteS3Objects(bucket string, keys []string) error {
+	for start := 0; start < len(keys); start += 1000 {
+		end := start + 1000
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		deleteObjects := make([]*s3.ObjectIdentifier, 0, end-start)
+		for _, key := range keys[start:end] {
+			deleteObjects = append(deleteObjects, &s3.ObjectIdentifier{Key: aws.String(key)})
+		}
+
+		_, err := c.S3().DeleteObjects(&s3.DeleteObjectsInput{
+			Bucket: aws.String(bucket),
+			Delete: &s3.Delete{
+				Objects: deleteObjects,
+				Quiet:   aws.Bool(true),
+			},
+		})
+		if err != nil {
+			return errors.Wrap(err, S3Path(bucket, keys[start]))
+		}
+	}
+
+	return nil
+}
+
 func (c *Client) HashS3Dir(bucket string, prefix string, maxResults *int64) (string, error) {
 	md5Hash := md5.New()
 
@@ -31,6 +31,7 @@ func (c *Client) CheckCredentials() (string, string, error) {
 
 	c.accountID = response.Account
 	c.hashedAccountID = pointer.String(hash.String(*c.accountID))
+	c.callerARN = response.Arn
 
 	return *c.accountID, *c.hashedAccountID, nil
 }
@@ -44,3 +45,14 @@ func (c *Client) GetCachedAccountID() (string, string, error) {
 	}
 	return *c.accountID, *c.hashedAccountID, nil
 }
+
+// Returns the ARN of the IAM user or role which the credentials belong to
+// Only re-checks the credentials if they have never been checked (so will not catch e.g. credentials expiring or getting revoked)
+func (c *Client) GetCachedCallerARN() (string, error) {
+	if c.callerARN == nil {
+		if _, _, err := c.CheckCredentials(); err != nil {
+			return "", err
+		}
+	}
+	return *c.callerARN, nil
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cosign
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/cortexlabs/cortex/pkg/lib/transport"
+)
+
+const (
+	SimpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	SignatureAnnotationKey = "dev.cosignproject.cosign/signature"
+
+	_signatureType = "cosign container image signature"
+)
+
+// Signature describes a verified cosign signature
+type Signature struct {
+	Digest string // the digest of the image's manifest
+	KeyID  string // the fingerprint of the public key which the signature was verified with
+}
+
+// payload is the "simple signing" payload which cosign signs
+type payload struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+type publicKey struct {
+	id  string
+	key crypto.PublicKey
+}
+
+type Verifier struct {
+	publicKeys []publicKey
+	HTTPClient *http.Client
+}
+
+func NewVerifier(publicKeysPEM []string) (*Verifier, error) {
+	verifier := &Verifier{
+		HTTPClient: &http.Client{Transport: transport.New()},
+	}
+
+	for _, publicKeyPEM := range publicKeysPEM {
+		key, err := parsePublicKey(publicKeyPEM)
+		if err != nil {
+			return nil, err
+		}
+		verifier.publicKeys = append(verifier.publicKeys, *key)
+	}
+
+	return verifier, nil
+}
+
+// ValidatePublicKeys can be used as a configreader validator for a list of PEM-encoded public keys
+func ValidatePublicKeys(publicKeysPEM []string) ([]string, error) {
+	for _, publicKeyPEM := range publicKeysPEM {
+		if _, err := parsePublicKey(publicKeyPEM); err != nil {
+			return nil, err
+		}
+	}
+	return publicKeysPEM, nil
+}
+
+// KeyID returns the fingerprint which is used to identify the public key in verification results
+func KeyID(publicKeyPEM string) (string, error) {
+	key, err := parsePublicKey(publicKeyPEM)
+	if err != nil {
+		return "", err
+	}
+	return key.id, nil
+}
+
+func parsePublicKey(publicKeyPEM string) (*publicKey, error) {
+	block, rest := pem.Decode([]byte(strings.TrimSpace(publicKeyPEM)))
+	if block == nil {
+		return nil, ErrorInvalidPublicKey("unable to decode PEM block")
+	}
+	if len(strings.TrimSpace(string(rest))) > 0 {
+		return nil, ErrorInvalidPublicKey("each public key must contain exactly one PEM block")
+	}
+	if block.Type != "PUBLIC KEY" {
+		return nil, ErrorInvalidPublicKey("expected a PEM block of type PUBLIC KEY, got " + block.Type)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, ErrorInvalidPublicKey(err.Error())
+	}
+
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+	default:
+		return nil, ErrorInvalidPublicKey("unsupported key type")
+	}
+
+	fingerprint := sha256.Sum256(block.Bytes)
+	return &publicKey{
+		id:  "sha256:" + hex.EncodeToString(fingerprint[:]),
+		key: key,
+	}, nil
+}
+
+// Verify resolves the image's digest, and checks that cosign's signature image for the digest contains a signature from
+// one of the verifier's public keys (credentials are optional, and are only needed for private registries)
+func (verifier *Verifier) Verify(image string, credentials *Credentials) (*Signature, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &registryClient{
+		httpClient:  verifier.HTTPClient,
+		ref:         ref,
+		credentials: credentials,
+	}
+
+	digest, err := client.resolveDigest()
+	if err != nil {
+		return nil, err
+	}
+
+	signatureManifest, err := client.signatureManifest(digest)
+	if err != nil {
+		return nil, err
+	}
+	if signatureManifest == nil {
+		return nil, ErrorImageNotSigned(image, digest)
+	}
+
+	for _, layer := range signatureManifest.Layers {
+		encodedSignature, ok := layer.Annotations[SignatureAnnotationKey]
+		if !ok || layer.MediaType != SimpleSigningMediaType {
+			continue
+		}
+		signature, err := base64.StdEncoding.DecodeString(encodedSignature)
+		if err != nil {
+			continue
+		}
+
+		payloadBytes, err := client.blob(layer.Digest)
+		if err != nil {
+			return nil, err
+		}
+		if !isPayloadForImage(payloadBytes, ref, digest) {
+			continue
+		}
+
+		for _, publicKey := range verifier.publicKeys {
+			if verifySignature(publicKey.key, payloadBytes, signature) {
+				return &Signature{
+					Digest: digest,
+					KeyID:  publicKey.id,
+				}, nil
+			}
+		}
+	}
+
+	return nil, ErrorNoValidSignature(image, digest)
+}
+
+// isPayloadForImage checks that the signed payload refers to this image, so that a signature can't be copied from another
+// image or repository
+func isPayloadForImage(payloadBytes []byte, ref *Reference, digest string) bool {
+	var signedPayload payload
+	if err := json.Unmarshal(payloadBytes, &signedPayload); err != nil {
+		return false
+	}
+
+	if signedPayload.Critical.Type != _signatureType || signedPayload.Critical.Image.DockerManifestDigest != digest {
+		return false
+	}
+
+	signedRef, err := ParseReference(signedPayload.Critical.Identity.DockerReference)
+	if err != nil {
+		return false
+	}
+	return signedRef.Name() == ref.Name()
+}
+
+func verifySignature(key crypto.PublicKey, payloadBytes []byte, signature []byte) bool {
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		var ecdsaSignature struct {
+			R, S *big.Int
+		}
+		if rest, err := asn1.Unmarshal(signature, &ecdsaSignature); err != nil || len(rest) > 0 {
+			return false
+		}
+		hash := sha256.Sum256(payloadBytes)
+		return ecdsa.Verify(key, hash[:], ecdsaSignature.R, ecdsaSignature.S)
+	case *rsa.PublicKey:
+		hash := sha256.Sum256(payloadBytes)
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, payloadBytes, signature)
+	}
+	return false
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cosign
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRegistry serves manifests and blobs for a single repository, and requires a bearer token like docker hub does
+type fakeRegistry struct {
+	server    *httptest.Server
+	manifests map[string][]byte // tag or digest -> manifest
+	blobs     map[string][]byte // digest -> blob
+}
+
+func newFakeRegistry(t *testing.T) *fakeRegistry {
+	registry := &fakeRegistry{
+		manifests: map[string][]byte{},
+		blobs:     map[string][]byte{},
+	}
+
+	registry.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			require.Equal(t, "repository:team/model:pull", r.URL.Query().Get("scope"))
+			w.Write([]byte(`{"token": "secret"}`))
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="fake"`, registry.server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var body []byte
+		if strings.HasPrefix(r.URL.Path, "/v2/team/model/manifests/") {
+			body = registry.manifests[strings.TrimPrefix(r.URL.Path, "/v2/team/model/manifests/")]
+		} else if strings.HasPrefix(r.URL.Path, "/v2/team/model/blobs/") {
+			body = registry.blobs[strings.TrimPrefix(r.URL.Path, "/v2/team/model/blobs/")]
+		}
+		if body == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(body)
+	}))
+
+	return registry
+}
+
+func (registry *fakeRegistry) image() string {
+	return strings.TrimPrefix(registry.server.URL, "https://") + "/team/model:v1"
+}
+
+// push adds an image manifest with the v1 tag, and returns its digest
+func (registry *fakeRegistry) push(contents string) string {
+	manifestBytes := []byte(fmt.Sprintf(`{"schemaVersion": 2, "config": {"digest": "%s"}}`, sha256Digest([]byte(contents))))
+	digest := sha256Digest(manifestBytes)
+	registry.manifests["v1"] = manifestBytes
+	registry.manifests[digest] = manifestBytes
+	return digest
+}
+
+// sign adds a cosign signature image for the digest, in the same layout as `cosign sign`
+func (registry *fakeRegistry) sign(t *testing.T, key *ecdsa.PrivateKey, dockerReference string, digest string) {
+	payloadBytes := []byte(fmt.Sprintf(`{"critical": {"identity": {"docker-reference": "%s"}, "image": {"docker-manifest-digest": "%s"}, "type": "cosign container image signature"}, "optional": null}`, dockerReference, digest))
+	hash := sha256.Sum256(payloadBytes)
+	r, s, err := ecdsa.Sign(rand.Reader, key, hash[:])
+	require.NoError(t, err)
+	signature, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+	require.NoError(t, err)
+
+	payloadDigest := sha256Digest(payloadBytes)
+	registry.blobs[payloadDigest] = payloadBytes
+
+	signatureManifest, err := json.Marshal(manifest{Layers: []descriptor{{
+		MediaType:   SimpleSigningMediaType,
+		Digest:      payloadDigest,
+		Annotations: map[string]string{SignatureAnnotationKey: base64.StdEncoding.EncodeToString(signature)},
+	}}})
+	require.NoError(t, err)
+	registry.manifests[strings.Replace(digest, ":", "-", 1)+".sig"] = signatureManifest
+}
+
+func generateKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func newTestVerifier(t *testing.T, registry *fakeRegistry, publicKeysPEM ...string) *Verifier {
+	verifier, err := NewVerifier(publicKeysPEM)
+	require.NoError(t, err)
+	verifier.HTTPClient = registry.server.Client()
+	return verifier
+}
+
+func TestVerify(t *testing.T) {
+	registry := newFakeRegistry(t)
+	defer registry.server.Close()
+
+	key, publicKeyPEM := generateKey(t)
+	_, otherPublicKeyPEM := generateKey(t)
+	digest := registry.push("model")
+	registry.sign(t, key, strings.Split(registry.image(), ":v1")[0], digest)
+
+	signature, err := newTestVerifier(t, registry, otherPublicKeyPEM, publicKeyPEM).Verify(registry.image(), nil)
+	require.NoError(t, err)
+	require.Equal(t, digest, signature.Digest)
+	keyID, err := KeyID(publicKeyPEM)
+	require.NoError(t, err)
+	require.Equal(t, keyID, signature.KeyID)
+
+	// referencing the image by digest skips resolving the tag
+	signature, err = newTestVerifier(t, registry, publicKeyPEM).Verify(PinDigest(registry.image(), digest), nil)
+	require.NoError(t, err)
+	require.Equal(t, digest, signature.Digest)
+
+	_, err = newTestVerifier(t, registry, otherPublicKeyPEM).Verify(registry.image(), nil)
+	require.Equal(t, ErrNoValidSignature, errors.GetKind(err))
+}
+
+func TestVerifyUnsigned(t *testing.T) {
+	registry := newFakeRegistry(t)
+	defer registry.server.Close()
+
+	_, publicKeyPEM := generateKey(t)
+	registry.push("model")
+
+	_, err := newTestVerifier(t, registry, publicKeyPEM).Verify(registry.image(), nil)
+	require.Equal(t, ErrImageNotSigned, errors.GetKind(err))
+}
+
+func TestVerifyRejectsCopiedSignatures(t *testing.T) {
+	registry := newFakeRegistry(t)
+	defer registry.server.Close()
+
+	key, publicKeyPEM := generateKey(t)
+
+	// a signature for a different repository
+	digest := registry.push("model")
+	registry.sign(t, key, "docker.io/team/model", digest)
+	_, err := newTestVerifier(t, registry, publicKeyPEM).Verify(registry.image(), nil)
+	require.Equal(t, ErrNoValidSignature, errors.GetKind(err))
+
+	// a signature for a previous version of the image, which has been copied to the tag's current digest
+	registry.sign(t, key, strings.Split(registry.image(), ":v1")[0], digest)
+	oldSignature := registry.manifests[strings.Replace(digest, ":", "-", 1)+".sig"]
+	_, err = newTestVerifier(t, registry, publicKeyPEM).Verify(registry.image(), nil)
+	require.NoError(t, err)
+	digest = registry.push("model v2")
+	registry.manifests[strings.Replace(digest, ":", "-", 1)+".sig"] = oldSignature
+	_, err = newTestVerifier(t, registry, publicKeyPEM).Verify(registry.image(), nil)
+	require.Equal(t, ErrNoValidSignature, errors.GetKind(err))
+}
+
+func TestValidatePublicKeys(t *testing.T) {
+	_, publicKeyPEM := generateKey(t)
+
+	_, err := ValidatePublicKeys([]string{publicKeyPEM})
+	require.NoError(t, err)
+
+	_, err = ValidatePublicKeys([]string{"not a key"})
+	require.Equal(t, ErrInvalidPublicKey, errors.GetKind(err))
+
+	_, err = ValidatePublicKeys([]string{publicKeyPEM + publicKeyPEM})
+	require.Equal(t, ErrInvalidPublicKey, errors.GetKind(err))
+
+	privateKeyPEM := strings.Replace(publicKeyPEM, "PUBLIC KEY", "PRIVATE KEY", -1)
+	_, err = ValidatePublicKeys([]string{privateKeyPEM})
+	require.Equal(t, ErrInvalidPublicKey, errors.GetKind(err))
+}
+
+func TestParseReference(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+
+	for image, expected := range map[string]Reference{
+		"ubuntu":                             {Registry: "index.docker.io", Repository: "library/ubuntu", Tag: "latest"},
+		"docker.io/cortexlabs/python:0.19.0": {Registry: "index.docker.io", Repository: "cortexlabs/python", Tag: "0.19.0"},
+		"localhost:5000/model":               {Registry: "localhost:5000", Repository: "model", Tag: "latest"},
+		"764403040460.dkr.ecr.us-west-2.amazonaws.com/team/model:v1@" + digest: {Registry: "764403040460.dkr.ecr.us-west-2.amazonaws.com", Repository: "team/model", Tag: "v1", Digest: digest},
+		"team/model@" + digest: {Registry: "index.docker.io", Repository: "team/model", Digest: digest},
+	} {
+		ref, err := ParseReference(image)
+		require.NoError(t, err, image)
+		require.Equal(t, expected, *ref, image)
+	}
+
+	for _, image := range []string{"team/model:", "team//model", "Team/model", "team/model@sha256:abc"} {
+		_, err := ParseReference(image)
+		require.Equal(t, ErrInvalidImageReference, errors.GetKind(err), image)
+	}
+}
+
+func TestPinDigest(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+
+	require.Equal(t, "team/model@"+digest, PinDigest("team/model", digest))
+	require.Equal(t, "team/model@"+digest, PinDigest("team/model:v1", digest))
+	require.Equal(t, "localhost:5000/model@"+digest, PinDigest("localhost:5000/model:v1@sha256:"+strings.Repeat("b", 64), digest))
+}
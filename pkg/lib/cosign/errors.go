@@ -0,0 +1,67 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cosign
+
+import (
+	"fmt"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	s "github.com/cortexlabs/cortex/pkg/lib/strings"
+)
+
+const (
+	ErrInvalidImageReference = "cosign.invalid_image_reference"
+	ErrInvalidPublicKey      = "cosign.invalid_public_key"
+	ErrRegistryRequest       = "cosign.registry_request"
+	ErrImageNotSigned        = "cosign.image_not_signed"
+	ErrNoValidSignature      = "cosign.no_valid_signature"
+)
+
+func ErrorInvalidImageReference(image string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidImageReference,
+		Message: fmt.Sprintf("%s is not a valid image reference", s.UserStr(image)),
+	})
+}
+
+func ErrorInvalidPublicKey(reason string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidPublicKey,
+		Message: fmt.Sprintf("invalid public key: %s (expected a PEM-encoded ECDSA, RSA, or Ed25519 public key, e.g. the cosign.pub file generated by `cosign generate-key-pair`)", reason),
+	})
+}
+
+func ErrorRegistryRequest(url string, statusCode int) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrRegistryRequest,
+		Message: fmt.Sprintf("request to %s failed with status code %d", url, statusCode),
+	})
+}
+
+func ErrorImageNotSigned(image string, digest string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrImageNotSigned,
+		Message: fmt.Sprintf("%s (%s) has not been signed with cosign", image, digest),
+	})
+}
+
+func ErrorNoValidSignature(image string, digest string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrNoValidSignature,
+		Message: fmt.Sprintf("%s (%s) does not have a valid cosign signature from any of the public keys in the cluster's image signature policy", image, digest),
+	})
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cosign
+
+import (
+	"strings"
+)
+
+const (
+	_dockerHubRegistry    = "index.docker.io"
+	_dockerHubAPIEndpoint = "registry-1.docker.io"
+)
+
+// Reference is a parsed docker image reference (e.g. cortexlabs/python-predictor-cpu:0.19.0)
+type Reference struct {
+	Registry   string // e.g. index.docker.io or 764403040460.dkr.ecr.us-west-2.amazonaws.com
+	Repository string // e.g. library/ubuntu
+	Tag        string // empty if the image is referenced by digest only
+	Digest     string // empty if the image is referenced by tag
+}
+
+func ParseReference(image string) (*Reference, error) {
+	ref := &Reference{}
+	name := image
+
+	if i := strings.Index(name, "@"); i != -1 {
+		name, ref.Digest = name[:i], name[i+1:]
+		if !strings.HasPrefix(ref.Digest, "sha256:") || len(ref.Digest) != len("sha256:")+64 {
+			return nil, ErrorInvalidImageReference(image)
+		}
+	}
+
+	if i := strings.LastIndex(name, ":"); i != -1 && !strings.Contains(name[i:], "/") {
+		name, ref.Tag = name[:i], name[i+1:]
+		if ref.Tag == "" {
+			return nil, ErrorInvalidImageReference(image)
+		}
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+
+	components := strings.Split(name, "/")
+	if len(components) > 1 && (strings.ContainsAny(components[0], ".:") || components[0] == "localhost") {
+		ref.Registry = components[0]
+		components = components[1:]
+	} else {
+		ref.Registry = _dockerHubRegistry
+		if len(components) == 1 {
+			components = []string{"library", components[0]}
+		}
+	}
+	if ref.Registry == "docker.io" || ref.Registry == _dockerHubAPIEndpoint {
+		ref.Registry = _dockerHubRegistry
+	}
+
+	ref.Repository = strings.Join(components, "/")
+	for _, component := range components {
+		if component == "" || component != strings.ToLower(component) {
+			return nil, ErrorInvalidImageReference(image)
+		}
+	}
+
+	return ref, nil
+}
+
+// Name returns the fully-qualified repository (without a tag or digest), which is what signatures identify images by
+func (ref *Reference) Name() string {
+	return ref.Registry + "/" + ref.Repository
+}
+
+func (ref *Reference) apiEndpoint() string {
+	if ref.Registry == _dockerHubRegistry {
+		return _dockerHubAPIEndpoint
+	}
+	return ref.Registry
+}
+
+// PinDigest replaces the tag (or digest) of the image with the given digest, leaving the rest of the image as-is
+func PinDigest(image string, digest string) string {
+	if i := strings.Index(image, "@"); i != -1 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i != -1 && !strings.Contains(image[i:], "/") {
+		image = image[:i]
+	}
+	return image + "@" + digest
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cosign
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+)
+
+var _authParamRegex = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+var _manifestMediaTypes = []string{
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+}
+
+type Credentials struct {
+	Username string
+	Password string
+}
+
+type manifest struct {
+	Layers []descriptor `json:"layers"`
+}
+
+type descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// registryClient is a minimal, read-only client for the docker registry HTTP API (v2)
+type registryClient struct {
+	httpClient    *http.Client
+	ref           *Reference
+	credentials   *Credentials
+	authorization string
+}
+
+// get returns the response and its body (the body is nil if the object does not exist)
+func (rc *registryClient) get(method string, path string, accept []string) (*http.Response, []byte, error) {
+	url := fmt.Sprintf("https://%s/v2/%s/%s", rc.ref.apiEndpoint(), rc.ref.Repository, path)
+
+	for attempt := 0; attempt < 2; attempt++ {
+		req, err := http.NewRequest(method, url, nil)
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+		if len(accept) > 0 {
+			req.Header.Set("Accept", strings.Join(accept, ", "))
+		}
+		if rc.authorization != "" {
+			req.Header.Set("Authorization", rc.authorization)
+		}
+
+		res, err := rc.httpClient.Do(req)
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+		body, err := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+
+		switch {
+		case res.StatusCode == http.StatusUnauthorized && attempt == 0:
+			if err := rc.authorize(res.Header.Get("WWW-Authenticate")); err != nil {
+				return nil, nil, err
+			}
+			continue
+		case res.StatusCode == http.StatusNotFound:
+			return res, nil, nil
+		case res.StatusCode != http.StatusOK:
+			return nil, nil, ErrorRegistryRequest(url, res.StatusCode)
+		}
+
+		return res, body, nil
+	}
+
+	return nil, nil, ErrorRegistryRequest(url, http.StatusUnauthorized)
+}
+
+// authorize handles the registry's authentication challenge (basic auth, or a bearer token for pulling from the repository)
+func (rc *registryClient) authorize(challenge string) error {
+	scheme := strings.ToLower(strings.SplitN(challenge, " ", 2)[0])
+	params := map[string]string{}
+	for _, match := range _authParamRegex.FindAllStringSubmatch(challenge, -1) {
+		params[strings.ToLower(match[1])] = match[2]
+	}
+
+	if scheme == "basic" {
+		if rc.credentials == nil {
+			return ErrorRegistryRequest(rc.ref.apiEndpoint(), http.StatusUnauthorized)
+		}
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+		req.SetBasicAuth(rc.credentials.Username, rc.credentials.Password)
+		rc.authorization = req.Header.Get("Authorization")
+		return nil
+	}
+
+	if scheme != "bearer" || params["realm"] == "" {
+		return ErrorRegistryRequest(rc.ref.apiEndpoint(), http.StatusUnauthorized)
+	}
+
+	query := url.Values{}
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull", rc.ref.Repository))
+
+	tokenURL := params["realm"] + "?" + query.Encode()
+	req, err := http.NewRequest(http.MethodGet, tokenURL, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if rc.credentials != nil {
+		req.SetBasicAuth(rc.credentials.Username, rc.credentials.Password)
+	}
+
+	res, err := rc.httpClient.Do(req)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return ErrorRegistryRequest(params["realm"], res.StatusCode)
+	}
+
+	var tokenResponse struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&tokenResponse); err != nil {
+		return errors.WithStack(err)
+	}
+
+	token := tokenResponse.Token
+	if token == "" {
+		token = tokenResponse.AccessToken
+	}
+	rc.authorization = "Bearer " + token
+	return nil
+}
+
+// resolveDigest returns the digest of the manifest which the reference's tag currently points to
+func (rc *registryClient) resolveDigest() (string, error) {
+	if rc.ref.Digest != "" {
+		return rc.ref.Digest, nil
+	}
+
+	res, body, err := rc.get(http.MethodGet, "manifests/"+rc.ref.Tag, _manifestMediaTypes)
+	if err != nil {
+		return "", err
+	}
+	if body == nil {
+		return "", ErrorRegistryRequest(rc.ref.Name()+":"+rc.ref.Tag, res.StatusCode)
+	}
+
+	if digest := res.Header.Get("Docker-Content-Digest"); digest != "" {
+		return digest, nil
+	}
+	return sha256Digest(body), nil
+}
+
+// signatureManifest returns the manifest of cosign's signature image for the digest, or nil if the image isn't signed
+func (rc *registryClient) signatureManifest(digest string) (*manifest, error) {
+	signatureTag := strings.Replace(digest, ":", "-", 1) + ".sig"
+
+	_, body, err := rc.get(http.MethodGet, "manifests/"+signatureTag, _manifestMediaTypes)
+	if err != nil || body == nil {
+		return nil, err
+	}
+
+	var signatureManifest manifest
+	if err := json.Unmarshal(body, &signatureManifest); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &signatureManifest, nil
+}
+
+// blob returns the contents of the blob, after checking that they match its digest
+func (rc *registryClient) blob(digest string) ([]byte, error) {
+	res, body, err := rc.get(http.MethodGet, "blobs/"+digest, nil)
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		return nil, ErrorRegistryRequest(rc.ref.Name()+"@"+digest, res.StatusCode)
+	}
+	if sha256Digest(body) != digest {
+		return nil, errors.ErrorUnexpected("blob contents do not match their digest", digest)
+	}
+	return body, nil
+}
+
+func sha256Digest(bytes []byte) string {
+	sum := sha256.Sum256(bytes)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
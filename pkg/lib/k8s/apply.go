@@ -0,0 +1,130 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	kmeta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	kclientrest "k8s.io/client-go/rest"
+)
+
+const (
+	// FieldManager is the field manager used for server-side applies of resources created by cortex
+	FieldManager = "cortex"
+
+	// ScaleFieldManager is the field manager which owns the replica count of deployments which are autoscaled by cortex
+	ScaleFieldManager = "cortex-autoscaler"
+)
+
+// apply performs a server-side apply of obj as fieldManager. Only the fields set in obj are owned by fieldManager,
+// so fields set by other managers (e.g. admission controllers or users) are left as-is. If force is false and
+// another manager owns a field in obj with a different value, ErrorApplyConflict is returned
+func (c *Client) apply(restClient kclientrest.Interface, resource string, name string, obj interface{}, result kruntime.Object, fieldManager string, force bool) error {
+	body, err := json.Marshal(obj)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = restClient.Patch(ktypes.ApplyPatchType).
+		Namespace(c.Namespace).
+		Resource(resource).
+		Name(name).
+		Param("fieldManager", fieldManager).
+		Param("force", strconv.FormatBool(force)).
+		Body(body).
+		Do().
+		Into(result)
+
+	if kerrors.IsConflict(err) && !force {
+		// objects which were created or updated before cortex used server-side apply have their fields owned by
+		// "Update" managers, so fieldManager takes ownership of them on its first apply
+		if isLegacy, legacyErr := c.isUnappliedObject(restClient, resource, name, fieldManager); legacyErr == nil && isLegacy {
+			return c.apply(restClient, resource, name, obj, result, fieldManager, true)
+		}
+	}
+	if kerrors.IsConflict(err) {
+		return ErrorApplyConflict(resource, name, err)
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// returns true if the object exists and has never been applied by fieldManager
+func (c *Client) isUnappliedObject(restClient kclientrest.Interface, resource string, name string, fieldManager string) (bool, error) {
+	body, err := restClient.Get().
+		Namespace(c.Namespace).
+		Resource(resource).
+		Name(name).
+		Do().
+		Raw()
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	var obj struct {
+		Metadata kmeta.ObjectMeta `json:"metadata"`
+	}
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	for _, entry := range obj.Metadata.ManagedFields {
+		if entry.Manager == fieldManager && entry.Operation == kmeta.ManagedFieldsOperationApply {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// IsFieldManagedBy returns true if fieldManager owns the field of obj at path (e.g. "spec", "replicas")
+func IsFieldManagedBy(obj kmeta.Object, fieldManager string, path ...string) bool {
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager != fieldManager || entry.FieldsV1 == nil {
+			continue
+		}
+
+		var fields map[string]interface{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			continue
+		}
+
+		found := true
+		for _, key := range path {
+			child, ok := fields["f:"+key].(map[string]interface{})
+			if !ok {
+				found = false
+				break
+			}
+			fields = child
+		}
+		if found {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kapps "k8s.io/api/apps/v1"
+	kmeta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsFieldManagedBy(t *testing.T) {
+	deployment := &kapps.Deployment{
+		ObjectMeta: kmeta.ObjectMeta{
+			ManagedFields: []kmeta.ManagedFieldsEntry{
+				{
+					Manager:   FieldManager,
+					Operation: kmeta.ManagedFieldsOperationApply,
+					FieldsV1:  &kmeta.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:apiName":{}}},"f:spec":{"f:template":{}}}`)},
+				},
+				{
+					Manager:   ScaleFieldManager,
+					Operation: kmeta.ManagedFieldsOperationApply,
+					FieldsV1:  &kmeta.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+				},
+			},
+		},
+	}
+
+	require.True(t, IsFieldManagedBy(deployment, ScaleFieldManager, "spec", "replicas"))
+	require.False(t, IsFieldManagedBy(deployment, FieldManager, "spec", "replicas"))
+	require.True(t, IsFieldManagedBy(deployment, FieldManager, "metadata", "labels", "apiName"))
+	require.False(t, IsFieldManagedBy(deployment, "kubectl", "spec", "replicas"))
+	require.False(t, IsFieldManagedBy(&kapps.Deployment{}, ScaleFieldManager, "spec", "replicas"))
+}
@@ -68,14 +68,12 @@ func (c *Client) UpdateConfigMap(configMap *kcore.ConfigMap) (*kcore.ConfigMap,
 }
 
 func (c *Client) ApplyConfigMap(configMap *kcore.ConfigMap) (*kcore.ConfigMap, error) {
-	existing, err := c.GetConfigMap(configMap.Name)
-	if err != nil {
+	configMap.TypeMeta = _configMapTypeMeta
+	result := &kcore.ConfigMap{}
+	if err := c.apply(c.clientset.CoreV1().RESTClient(), "configmaps", configMap.Name, configMap, result, FieldManager, false); err != nil {
 		return nil, err
 	}
-	if existing == nil {
-		return c.CreateConfigMap(configMap)
-	}
-	return c.UpdateConfigMap(configMap)
+	return result, nil
 }
 
 func (c *Client) GetConfigMap(name string) (*kcore.ConfigMap, error) {
@@ -0,0 +1,180 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	kbatchv1beta1 "k8s.io/api/batch/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	kmeta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	kwatch "k8s.io/apimachinery/pkg/watch"
+)
+
+var _cronJobTypeMeta = kmeta.TypeMeta{
+	APIVersion: "batch/v1beta1",
+	Kind:       "CronJob",
+}
+
+type CronJobSpec struct {
+	Name                       string
+	Schedule                   string // cron format, e.g. "*/5 * * * *"
+	ConcurrencyPolicy          kbatchv1beta1.ConcurrencyPolicy
+	Suspend                    bool
+	SuccessfulJobsHistoryLimit *int32
+	FailedJobsHistoryLimit     *int32
+	JobSpec                    JobSpec
+	Labels                     map[string]string
+	Annotations                map[string]string
+}
+
+func CronJob(spec *CronJobSpec) *kbatchv1beta1.CronJob {
+	if spec.JobSpec.Name == "" {
+		spec.JobSpec.Name = spec.Name
+	}
+	if spec.ConcurrencyPolicy == "" {
+		spec.ConcurrencyPolicy = kbatchv1beta1.ForbidConcurrent
+	}
+
+	job := Job(&spec.JobSpec)
+
+	cronJob := &kbatchv1beta1.CronJob{
+		TypeMeta: _cronJobTypeMeta,
+		ObjectMeta: kmeta.ObjectMeta{
+			Name:        spec.Name,
+			Labels:      spec.Labels,
+			Annotations: spec.Annotations,
+		},
+		Spec: kbatchv1beta1.CronJobSpec{
+			Schedule:                   spec.Schedule,
+			ConcurrencyPolicy:          spec.ConcurrencyPolicy,
+			Suspend:                    &spec.Suspend,
+			SuccessfulJobsHistoryLimit: spec.SuccessfulJobsHistoryLimit,
+			FailedJobsHistoryLimit:     spec.FailedJobsHistoryLimit,
+			JobTemplate: kbatchv1beta1.JobTemplateSpec{
+				ObjectMeta: kmeta.ObjectMeta{
+					Name:        job.Name,
+					Labels:      job.Labels,
+					Annotations: job.Annotations,
+				},
+				Spec: job.Spec,
+			},
+		},
+	}
+	return cronJob
+}
+
+func (c *Client) CreateCronJob(cronJob *kbatchv1beta1.CronJob) (*kbatchv1beta1.CronJob, error) {
+	cronJob.TypeMeta = _cronJobTypeMeta
+	cronJob, err := c.cronJobClient.Create(cronJob)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return cronJob, nil
+}
+
+func (c *Client) UpdateCronJob(cronJob *kbatchv1beta1.CronJob) (*kbatchv1beta1.CronJob, error) {
+	cronJob.TypeMeta = _cronJobTypeMeta
+	cronJob, err := c.cronJobClient.Update(cronJob)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return cronJob, nil
+}
+
+func (c *Client) ApplyCronJob(cronJob *kbatchv1beta1.CronJob) (*kbatchv1beta1.CronJob, error) {
+	cronJob.TypeMeta = _cronJobTypeMeta
+	result := &kbatchv1beta1.CronJob{}
+	if err := c.apply(c.clientset.BatchV1beta1().RESTClient(), "cronjobs", cronJob.Name, cronJob, result, FieldManager, false); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *Client) GetCronJob(name string) (*kbatchv1beta1.CronJob, error) {
+	cronJob, err := c.cronJobClient.Get(name, kmeta.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	cronJob.TypeMeta = _cronJobTypeMeta
+	return cronJob, nil
+}
+
+func (c *Client) DeleteCronJob(name string) (bool, error) {
+	err := c.cronJobClient.Delete(name, _deleteOpts)
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return true, nil
+}
+
+func (c *Client) ListCronJobs(opts *kmeta.ListOptions) ([]kbatchv1beta1.CronJob, error) {
+	if opts == nil {
+		opts = &kmeta.ListOptions{}
+	}
+	cronJobList, err := c.cronJobClient.List(*opts)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	for i := range cronJobList.Items {
+		cronJobList.Items[i].TypeMeta = _cronJobTypeMeta
+	}
+	return cronJobList.Items, nil
+}
+
+func (c *Client) ListCronJobsByLabels(labels map[string]string) ([]kbatchv1beta1.CronJob, error) {
+	opts := &kmeta.ListOptions{
+		LabelSelector: klabels.SelectorFromSet(labels).String(),
+	}
+	return c.ListCronJobs(opts)
+}
+
+func (c *Client) ListCronJobsByLabel(labelKey string, labelValue string) ([]kbatchv1beta1.CronJob, error) {
+	return c.ListCronJobsByLabels(map[string]string{labelKey: labelValue})
+}
+
+func (c *Client) ListCronJobsWithLabelKeys(labelKeys ...string) ([]kbatchv1beta1.CronJob, error) {
+	opts := &kmeta.ListOptions{
+		LabelSelector: LabelExistsSelector(labelKeys...),
+	}
+	return c.ListCronJobs(opts)
+}
+
+func (c *Client) WatchCronJobs(opts *kmeta.ListOptions) (kwatch.Interface, error) {
+	if opts == nil {
+		opts = &kmeta.ListOptions{}
+	}
+	watcher, err := c.cronJobClient.Watch(*opts)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return watcher, nil
+}
+
+func CronJobMap(cronJobs []kbatchv1beta1.CronJob) map[string]kbatchv1beta1.CronJob {
+	cronJobMap := map[string]kbatchv1beta1.CronJob{}
+	for _, cronJob := range cronJobs {
+		cronJobMap[cronJob.Name] = cronJob
+	}
+	return cronJobMap
+}
@@ -115,14 +115,34 @@ func (c *Client) UpdateDeployment(deployment *kapps.Deployment) (*kapps.Deployme
 }
 
 func (c *Client) ApplyDeployment(deployment *kapps.Deployment) (*kapps.Deployment, error) {
-	existing, err := c.GetDeployment(deployment.Name)
-	if err != nil {
+	deployment.TypeMeta = _deploymentTypeMeta
+	result := &kapps.Deployment{}
+	if err := c.apply(c.clientset.AppsV1().RESTClient(), "deployments", deployment.Name, deployment, result, FieldManager, false); err != nil {
 		return nil, err
 	}
-	if existing == nil {
-		return c.CreateDeployment(deployment)
+	return result, nil
+}
+
+// ScaleDeployment sets the deployment's replica count as a separate field manager, so that the replica count
+// is not reset when the rest of the deployment is applied (as long as the applied deployment doesn't set replicas).
+// The apply is forced, so ScaleFieldManager takes ownership of the replica count from any other manager
+func (c *Client) ScaleDeployment(name string, replicas int32) (*kapps.Deployment, error) {
+	scaleObj := map[string]interface{}{
+		"apiVersion": _deploymentTypeMeta.APIVersion,
+		"kind":       _deploymentTypeMeta.Kind,
+		"metadata": map[string]interface{}{
+			"name": name,
+		},
+		"spec": map[string]interface{}{
+			"replicas": replicas,
+		},
+	}
+
+	result := &kapps.Deployment{}
+	if err := c.apply(c.clientset.AppsV1().RESTClient(), "deployments", name, scaleObj, result, ScaleFieldManager, true); err != nil {
+		return nil, err
 	}
-	return c.UpdateDeployment(deployment)
+	return result, nil
 }
 
 func (c *Client) GetDeployment(name string) (*kapps.Deployment, error) {
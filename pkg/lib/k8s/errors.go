@@ -30,6 +30,9 @@ const (
 	ErrParseLabel         = "k8s.parse_label"
 	ErrParseAnnotation    = "k8s.parse_annotation"
 	ErrParseQuantity      = "k8s.parse_quantity"
+	ErrApplyConflict      = "k8s.apply_conflict"
+	ErrSeccompProfile     = "k8s.seccomp_profile"
+	ErrAppArmorProfile    = "k8s.apparmor_profile"
 )
 
 func ErrorLabelNotFound(labelName string) error {
@@ -66,3 +69,24 @@ func ErrorParseQuantity(qtyStr string) error {
 		Message: fmt.Sprintf("%s: invalid kubernetes quantity, some valid examples are 1, 200m, 500Mi, 2G (see here for more information: https://docs.cortex.dev/v/%s/deployments/compute)", qtyStr, consts.CortexVersionMinor),
 	})
 }
+
+func ErrorApplyConflict(resource string, name string, err error) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrApplyConflict,
+		Message: fmt.Sprintf("unable to update %s %s because some of its fields are managed by another user or controller (%s)", resource, s.UserStr(name), errors.Message(err)),
+	})
+}
+
+func ErrorSeccompProfile(profile string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrSeccompProfile,
+		Message: fmt.Sprintf("%s is not a valid seccomp profile; valid profiles are %s, %s, %s, or %s<profile-name>", s.UserStr(profile), SeccompProfileRuntimeDefault, SeccompProfileDockerDefault, SeccompProfileUnconfined, SeccompProfileLocalhostPrefix),
+	})
+}
+
+func ErrorAppArmorProfile(profile string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrAppArmorProfile,
+		Message: fmt.Sprintf("%s is not a valid apparmor profile; valid profiles are %s, %s, or %s<profile-name>", s.UserStr(profile), AppArmorProfileRuntimeDefault, AppArmorProfileUnconfined, AppArmorProfileLocalhostPrefix),
+	})
+}
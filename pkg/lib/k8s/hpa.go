@@ -23,10 +23,11 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	kmeta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	klabels "k8s.io/apimachinery/pkg/labels"
+	kwatch "k8s.io/apimachinery/pkg/watch"
 )
 
 var _hpaTypeMeta = kmeta.TypeMeta{
-	APIVersion: "autoscaling/v1",
+	APIVersion: "autoscaling/v2beta2",
 	Kind:       "HorizontalPodAutoscaler",
 }
 
@@ -91,14 +92,12 @@ func (c *Client) UpdateHPA(hpa *kautoscaling.HorizontalPodAutoscaler) (*kautosca
 }
 
 func (c *Client) ApplyHPA(hpa *kautoscaling.HorizontalPodAutoscaler) (*kautoscaling.HorizontalPodAutoscaler, error) {
-	existing, err := c.GetHPA(hpa.Name)
-	if err != nil {
+	hpa.TypeMeta = _hpaTypeMeta
+	result := &kautoscaling.HorizontalPodAutoscaler{}
+	if err := c.apply(c.clientset.AutoscalingV2beta2().RESTClient(), "horizontalpodautoscalers", hpa.Name, hpa, result, FieldManager, false); err != nil {
 		return nil, err
 	}
-	if existing == nil {
-		return c.CreateHPA(hpa)
-	}
-	return c.UpdateHPA(hpa)
+	return result, nil
 }
 
 func (c *Client) GetHPA(name string) (*kautoscaling.HorizontalPodAutoscaler, error) {
@@ -156,6 +155,17 @@ func (c *Client) ListHPAsWithLabelKeys(labelKeys ...string) ([]kautoscaling.Hori
 	return c.ListHPAs(opts)
 }
 
+func (c *Client) WatchHPAs(opts *kmeta.ListOptions) (kwatch.Interface, error) {
+	if opts == nil {
+		opts = &kmeta.ListOptions{}
+	}
+	watcher, err := c.hpaClient.Watch(*opts)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return watcher, nil
+}
+
 func HPAMap(hpas []kautoscaling.HorizontalPodAutoscaler) map[string]kautoscaling.HorizontalPodAutoscaler {
 	hpaMap := map[string]kautoscaling.HorizontalPodAutoscaler{}
 	for _, hpa := range hpas {
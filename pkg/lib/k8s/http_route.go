@@ -0,0 +1,153 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	kmeta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kschema "k8s.io/apimachinery/pkg/runtime/schema"
+	ktypes "k8s.io/apimachinery/pkg/types"
+)
+
+// the Gateway API has no typed client for this version of client-go, so its resources are managed as unstructured objects
+
+var _httpRouteGVR = kschema.GroupVersionResource{
+	Group:    "gateway.networking.k8s.io",
+	Version:  "v1",
+	Resource: "httproutes",
+}
+
+var _gatewayGVR = kschema.GroupVersionResource{
+	Group:    "gateway.networking.k8s.io",
+	Version:  "v1",
+	Resource: "gateways",
+}
+
+type HTTPRouteSpec struct {
+	Name            string
+	ParentName      string
+	ParentNamespace string
+	ServiceName     string
+	ServicePort     int32
+	Path            string
+	Labels          map[string]string
+	Annotations     map[string]string
+}
+
+// HTTPRoute routes requests for an exact path, received by the parent gateway, to a service in the route's namespace
+func HTTPRoute(spec *HTTPRouteSpec) *kunstructured.Unstructured {
+	httpRoute := &kunstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": _httpRouteGVR.GroupVersion().String(),
+			"kind":       "HTTPRoute",
+			"spec": map[string]interface{}{
+				"parentRefs": []interface{}{
+					map[string]interface{}{
+						"name":      spec.ParentName,
+						"namespace": spec.ParentNamespace,
+					},
+				},
+				"rules": []interface{}{
+					map[string]interface{}{
+						"matches": []interface{}{
+							map[string]interface{}{
+								"path": map[string]interface{}{
+									"type":  "Exact",
+									"value": spec.Path,
+								},
+							},
+						},
+						"backendRefs": []interface{}{
+							map[string]interface{}{
+								"name": spec.ServiceName,
+								"port": int64(spec.ServicePort),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	httpRoute.SetName(spec.Name)
+	httpRoute.SetLabels(spec.Labels)
+	httpRoute.SetAnnotations(spec.Annotations)
+	return httpRoute
+}
+
+func (c *Client) ApplyHTTPRoute(httpRoute *kunstructured.Unstructured) (*kunstructured.Unstructured, error) {
+	body, err := httpRoute.MarshalJSON()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result, err := c.dynamicClient.Resource(_httpRouteGVR).Namespace(c.Namespace).Patch(httpRoute.GetName(), ktypes.ApplyPatchType, body, kmeta.PatchOptions{FieldManager: FieldManager})
+	if kerrors.IsConflict(err) {
+		return nil, ErrorApplyConflict(_httpRouteGVR.Resource, httpRoute.GetName(), err)
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return result, nil
+}
+
+func (c *Client) GetHTTPRoute(name string) (*kunstructured.Unstructured, error) {
+	httpRoute, err := c.dynamicClient.Resource(_httpRouteGVR).Namespace(c.Namespace).Get(name, kmeta.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return httpRoute, nil
+}
+
+func (c *Client) DeleteHTTPRoute(name string) (bool, error) {
+	err := c.dynamicClient.Resource(_httpRouteGVR).Namespace(c.Namespace).Delete(name, _deleteOpts)
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return true, nil
+}
+
+// GetGatewayAddress returns the first address of a gateway (which may be in a different namespace than the client's),
+// or "" if the gateway doesn't have an address yet
+func (c *Client) GetGatewayAddress(namespace string, name string) (string, error) {
+	gateway, err := c.dynamicClient.Resource(_gatewayGVR).Namespace(namespace).Get(name, kmeta.GetOptions{})
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	addresses, _, err := kunstructured.NestedSlice(gateway.Object, "status", "addresses")
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	for _, address := range addresses {
+		addressMap, ok := address.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if value, ok := addressMap["value"].(string); ok && value != "" {
+			return value, nil
+		}
+	}
+	return "", nil
+}
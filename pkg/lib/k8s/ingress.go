@@ -97,14 +97,12 @@ func (c *Client) UpdateIngress(ingress *kextensions.Ingress) (*kextensions.Ingre
 }
 
 func (c *Client) ApplyIngress(ingress *kextensions.Ingress) (*kextensions.Ingress, error) {
-	existing, err := c.GetIngress(ingress.Name)
-	if err != nil {
+	ingress.TypeMeta = _ingressTypeMeta
+	result := &kextensions.Ingress{}
+	if err := c.apply(c.clientset.ExtensionsV1beta1().RESTClient(), "ingresses", ingress.Name, ingress, result, FieldManager, false); err != nil {
 		return nil, err
 	}
-	if existing == nil {
-		return c.CreateIngress(ingress)
-	}
-	return c.UpdateIngress(ingress)
+	return result, nil
 }
 
 func (c *Client) GetIngress(name string) (*kextensions.Ingress, error) {
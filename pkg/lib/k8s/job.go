@@ -88,14 +88,12 @@ func (c *Client) UpdateJob(job *kbatch.Job) (*kbatch.Job, error) {
 }
 
 func (c *Client) ApplyJob(job *kbatch.Job) (*kbatch.Job, error) {
-	existing, err := c.GetJob(job.Name)
-	if err != nil {
+	job.TypeMeta = _jobTypeMeta
+	result := &kbatch.Job{}
+	if err := c.apply(c.clientset.BatchV1().RESTClient(), "jobs", job.Name, job, result, FieldManager, false); err != nil {
 		return nil, err
 	}
-	if existing == nil {
-		return c.CreateJob(job)
-	}
-	return c.UpdateJob(job)
+	return result, nil
 }
 
 func (c *Client) GetJob(name string) (*kbatch.Job, error) {
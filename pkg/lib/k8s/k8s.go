@@ -17,7 +17,6 @@ limitations under the License.
 package k8s
 
 import (
-	"path"
 	"regexp"
 	"strings"
 
@@ -32,16 +31,17 @@ import (
 	kclientapps "k8s.io/client-go/kubernetes/typed/apps/v1"
 	kclientautoscaling "k8s.io/client-go/kubernetes/typed/autoscaling/v2beta2"
 	kclientbatch "k8s.io/client-go/kubernetes/typed/batch/v1"
+	kclientbatchv1beta1 "k8s.io/client-go/kubernetes/typed/batch/v1beta1"
 	kclientcore "k8s.io/client-go/kubernetes/typed/core/v1"
 	kclientextensions "k8s.io/client-go/kubernetes/typed/extensions/v1beta1"
+	kclientnetworking "k8s.io/client-go/kubernetes/typed/networking/v1"
+	kclientpolicy "k8s.io/client-go/kubernetes/typed/policy/v1beta1"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	kclientrest "k8s.io/client-go/rest"
 	kclientcmd "k8s.io/client-go/tools/clientcmd"
-	kclienthomedir "k8s.io/client-go/util/homedir"
 )
 
 var (
-	_home         = kclienthomedir.HomeDir()
 	_deletePolicy = kmeta.DeletePropagationBackground
 	_deleteOpts   = &kmeta.DeleteOptions{
 		PropagationPolicy: &_deletePolicy,
@@ -60,26 +60,55 @@ type Client struct {
 	jobClient            kclientbatch.JobInterface
 	ingressClient        kclientextensions.IngressInterface
 	hpaClient            kclientautoscaling.HorizontalPodAutoscalerInterface
+	pdbClient            kclientpolicy.PodDisruptionBudgetInterface
+	networkPolicyClient  kclientnetworking.NetworkPolicyInterface
+	cronJobClient        kclientbatchv1beta1.CronJobInterface
+	istioClientset       *istioclient.Clientset
 	virtualServiceClient istionetworkingclient.VirtualServiceInterface
 	Namespace            string
 }
 
+type Config struct {
+	InCluster         bool
+	KubeConfigPath    string   // only used if !InCluster; defaults to $KUBECONFIG, or ~/.kube/config
+	Context           string   // only used if !InCluster; defaults to the kubeconfig's current context
+	ImpersonateUser   string   // optional
+	ImpersonateGroups []string // optional
+}
+
 func New(namespace string, inCluster bool) (*Client, error) {
+	return NewFromConfig(namespace, &Config{InCluster: inCluster})
+}
+
+func NewFromConfig(namespace string, config *Config) (*Client, error) {
 	var err error
 	client := &Client{
 		Namespace: namespace,
 	}
-	if inCluster {
+	if config.InCluster {
 		client.RestConfig, err = kclientrest.InClusterConfig()
 	} else {
-		kubeConfig := path.Join(_home, ".kube", "config")
-		client.RestConfig, err = kclientcmd.BuildConfigFromFlags("", kubeConfig)
+		loadingRules := kclientcmd.NewDefaultClientConfigLoadingRules()
+		if config.KubeConfigPath != "" {
+			loadingRules.ExplicitPath = config.KubeConfigPath
+		}
+		overrides := &kclientcmd.ConfigOverrides{
+			CurrentContext: config.Context,
+		}
+		client.RestConfig, err = kclientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
 	}
 
 	if err != nil {
 		return nil, errors.Wrap(err, "kubeconfig")
 	}
 
+	if config.ImpersonateUser != "" || len(config.ImpersonateGroups) > 0 {
+		client.RestConfig.Impersonate = kclientrest.ImpersonationConfig{
+			UserName: config.ImpersonateUser,
+			Groups:   config.ImpersonateGroups,
+		}
+	}
+
 	client.clientset, err = kclientset.NewForConfig(client.RestConfig)
 	if err != nil {
 		return nil, errors.Wrap(err, "kubeconfig")
@@ -90,11 +119,11 @@ func New(namespace string, inCluster bool) (*Client, error) {
 		return nil, errors.Wrap(err, "kubeconfig")
 	}
 
-	istioClient, err := istioclient.NewForConfig(client.RestConfig)
+	client.istioClientset, err = istioclient.NewForConfig(client.RestConfig)
 	if err != nil {
 		return nil, errors.Wrap(err, "kubeconfig")
 	}
-	client.virtualServiceClient = istioClient.NetworkingV1alpha3().VirtualServices(namespace)
+	client.virtualServiceClient = client.istioClientset.NetworkingV1alpha3().VirtualServices(namespace)
 
 	client.podClient = client.clientset.CoreV1().Pods(namespace)
 	client.nodeClient = client.clientset.CoreV1().Nodes()
@@ -104,6 +133,9 @@ func New(namespace string, inCluster bool) (*Client, error) {
 	client.jobClient = client.clientset.BatchV1().Jobs(namespace)
 	client.ingressClient = client.clientset.ExtensionsV1beta1().Ingresses(namespace)
 	client.hpaClient = client.clientset.AutoscalingV2beta2().HorizontalPodAutoscalers(namespace)
+	client.pdbClient = client.clientset.PolicyV1beta1().PodDisruptionBudgets(namespace)
+	client.networkPolicyClient = client.clientset.NetworkingV1().NetworkPolicies(namespace)
+	client.cronJobClient = client.clientset.BatchV1beta1().CronJobs(namespace)
 	return client, nil
 }
 
@@ -0,0 +1,64 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"time"
+
+	kmeta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kleaderelection "k8s.io/client-go/tools/leaderelection"
+	kresourcelock "k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+const (
+	_leaseDuration = 15 * time.Second
+	_renewDeadline = 10 * time.Second
+	_retryPeriod   = 2 * time.Second
+)
+
+// RunLeaderElection campaigns for the lease with the given name (in the client's namespace) until ctx is done. onStartedLeading
+// is called when this process acquires the lease, and onStoppedLeading is called when it loses it (after which it campaigns again)
+func (c *Client) RunLeaderElection(ctx context.Context, leaseName string, identity string, onStartedLeading func(), onStoppedLeading func()) {
+	lock := &kresourcelock.LeaseLock{
+		LeaseMeta: kmeta.ObjectMeta{
+			Name:      leaseName,
+			Namespace: c.Namespace,
+		},
+		Client: c.clientset.CoordinationV1(),
+		LockConfig: kresourcelock.ResourceLockConfig{
+			Identity: identity,
+		},
+	}
+
+	for ctx.Err() == nil {
+		kleaderelection.RunOrDie(ctx, kleaderelection.LeaderElectionConfig{
+			Lock:            lock,
+			Name:            leaseName,
+			LeaseDuration:   _leaseDuration,
+			RenewDeadline:   _renewDeadline,
+			RetryPeriod:     _retryPeriod,
+			ReleaseOnCancel: true,
+			Callbacks: kleaderelection.LeaderCallbacks{
+				OnStartedLeading: func(context.Context) {
+					onStartedLeading()
+				},
+				OnStoppedLeading: onStoppedLeading,
+			},
+		})
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	knetworking "k8s.io/api/networking/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	kmeta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	kwatch "k8s.io/apimachinery/pkg/watch"
+)
+
+var _networkPolicyTypeMeta = kmeta.TypeMeta{
+	APIVersion: "networking.k8s.io/v1",
+	Kind:       "NetworkPolicy",
+}
+
+type NetworkPolicySpec struct {
+	Name        string
+	PodSelector map[string]string // an empty selector selects all pods in the namespace
+	Ingress     []knetworking.NetworkPolicyIngressRule
+	Egress      []knetworking.NetworkPolicyEgressRule
+	PolicyTypes []knetworking.PolicyType // if empty, k8s infers the policy types from Ingress and Egress
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+func NetworkPolicy(spec *NetworkPolicySpec) *knetworking.NetworkPolicy {
+	networkPolicy := &knetworking.NetworkPolicy{
+		TypeMeta: _networkPolicyTypeMeta,
+		ObjectMeta: kmeta.ObjectMeta{
+			Name:        spec.Name,
+			Labels:      spec.Labels,
+			Annotations: spec.Annotations,
+		},
+		Spec: knetworking.NetworkPolicySpec{
+			PodSelector: kmeta.LabelSelector{
+				MatchLabels: spec.PodSelector,
+			},
+			Ingress:     spec.Ingress,
+			Egress:      spec.Egress,
+			PolicyTypes: spec.PolicyTypes,
+		},
+	}
+	return networkPolicy
+}
+
+func (c *Client) CreateNetworkPolicy(networkPolicy *knetworking.NetworkPolicy) (*knetworking.NetworkPolicy, error) {
+	networkPolicy.TypeMeta = _networkPolicyTypeMeta
+	networkPolicy, err := c.networkPolicyClient.Create(networkPolicy)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return networkPolicy, nil
+}
+
+func (c *Client) UpdateNetworkPolicy(networkPolicy *knetworking.NetworkPolicy) (*knetworking.NetworkPolicy, error) {
+	networkPolicy.TypeMeta = _networkPolicyTypeMeta
+	networkPolicy, err := c.networkPolicyClient.Update(networkPolicy)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return networkPolicy, nil
+}
+
+func (c *Client) ApplyNetworkPolicy(networkPolicy *knetworking.NetworkPolicy) (*knetworking.NetworkPolicy, error) {
+	networkPolicy.TypeMeta = _networkPolicyTypeMeta
+	result := &knetworking.NetworkPolicy{}
+	if err := c.apply(c.clientset.NetworkingV1().RESTClient(), "networkpolicies", networkPolicy.Name, networkPolicy, result, FieldManager, false); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *Client) GetNetworkPolicy(name string) (*knetworking.NetworkPolicy, error) {
+	networkPolicy, err := c.networkPolicyClient.Get(name, kmeta.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	networkPolicy.TypeMeta = _networkPolicyTypeMeta
+	return networkPolicy, nil
+}
+
+func (c *Client) DeleteNetworkPolicy(name string) (bool, error) {
+	err := c.networkPolicyClient.Delete(name, _deleteOpts)
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return true, nil
+}
+
+func (c *Client) ListNetworkPolicies(opts *kmeta.ListOptions) ([]knetworking.NetworkPolicy, error) {
+	if opts == nil {
+		opts = &kmeta.ListOptions{}
+	}
+	networkPolicyList, err := c.networkPolicyClient.List(*opts)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	for i := range networkPolicyList.Items {
+		networkPolicyList.Items[i].TypeMeta = _networkPolicyTypeMeta
+	}
+	return networkPolicyList.Items, nil
+}
+
+func (c *Client) ListNetworkPoliciesByLabels(labels map[string]string) ([]knetworking.NetworkPolicy, error) {
+	opts := &kmeta.ListOptions{
+		LabelSelector: klabels.SelectorFromSet(labels).String(),
+	}
+	return c.ListNetworkPolicies(opts)
+}
+
+func (c *Client) ListNetworkPoliciesByLabel(labelKey string, labelValue string) ([]knetworking.NetworkPolicy, error) {
+	return c.ListNetworkPoliciesByLabels(map[string]string{labelKey: labelValue})
+}
+
+func (c *Client) ListNetworkPoliciesWithLabelKeys(labelKeys ...string) ([]knetworking.NetworkPolicy, error) {
+	opts := &kmeta.ListOptions{
+		LabelSelector: LabelExistsSelector(labelKeys...),
+	}
+	return c.ListNetworkPolicies(opts)
+}
+
+func (c *Client) WatchNetworkPolicies(opts *kmeta.ListOptions) (kwatch.Interface, error) {
+	if opts == nil {
+		opts = &kmeta.ListOptions{}
+	}
+	watcher, err := c.networkPolicyClient.Watch(*opts)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return watcher, nil
+}
+
+func NetworkPolicyMap(networkPolicies []knetworking.NetworkPolicy) map[string]knetworking.NetworkPolicy {
+	networkPolicyMap := map[string]knetworking.NetworkPolicy{}
+	for _, networkPolicy := range networkPolicies {
+		networkPolicyMap[networkPolicy.Name] = networkPolicy
+	}
+	return networkPolicyMap
+}
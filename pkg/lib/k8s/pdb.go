@@ -0,0 +1,173 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	kpolicy "k8s.io/api/policy/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	kmeta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	kwatch "k8s.io/apimachinery/pkg/watch"
+)
+
+var _pdbTypeMeta = kmeta.TypeMeta{
+	APIVersion: "policy/v1beta1",
+	Kind:       "PodDisruptionBudget",
+}
+
+type PDBSpec struct {
+	Name           string
+	MinAvailable   *string // Can be a percentage (e.g. 10%) or an absolute number (e.g. 2)
+	MaxUnavailable *string // Can be a percentage (e.g. 10%) or an absolute number (e.g. 2)
+	Selector       map[string]string
+	Labels         map[string]string
+	Annotations    map[string]string
+}
+
+func PDB(spec *PDBSpec) *kpolicy.PodDisruptionBudget {
+	var minAvailable *intstr.IntOrString
+	if spec.MinAvailable != nil {
+		intStr := intstr.Parse(*spec.MinAvailable)
+		minAvailable = &intStr
+	}
+
+	var maxUnavailable *intstr.IntOrString
+	if spec.MaxUnavailable != nil {
+		intStr := intstr.Parse(*spec.MaxUnavailable)
+		maxUnavailable = &intStr
+	}
+
+	pdb := &kpolicy.PodDisruptionBudget{
+		TypeMeta: _pdbTypeMeta,
+		ObjectMeta: kmeta.ObjectMeta{
+			Name:        spec.Name,
+			Labels:      spec.Labels,
+			Annotations: spec.Annotations,
+		},
+		Spec: kpolicy.PodDisruptionBudgetSpec{
+			MinAvailable:   minAvailable,
+			MaxUnavailable: maxUnavailable,
+			Selector: &kmeta.LabelSelector{
+				MatchLabels: spec.Selector,
+			},
+		},
+	}
+	return pdb
+}
+
+func (c *Client) CreatePDB(pdb *kpolicy.PodDisruptionBudget) (*kpolicy.PodDisruptionBudget, error) {
+	pdb.TypeMeta = _pdbTypeMeta
+	pdb, err := c.pdbClient.Create(pdb)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return pdb, nil
+}
+
+func (c *Client) UpdatePDB(pdb *kpolicy.PodDisruptionBudget) (*kpolicy.PodDisruptionBudget, error) {
+	pdb.TypeMeta = _pdbTypeMeta
+	pdb, err := c.pdbClient.Update(pdb)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return pdb, nil
+}
+
+func (c *Client) ApplyPDB(pdb *kpolicy.PodDisruptionBudget) (*kpolicy.PodDisruptionBudget, error) {
+	pdb.TypeMeta = _pdbTypeMeta
+	result := &kpolicy.PodDisruptionBudget{}
+	if err := c.apply(c.clientset.PolicyV1beta1().RESTClient(), "poddisruptionbudgets", pdb.Name, pdb, result, FieldManager, false); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *Client) GetPDB(name string) (*kpolicy.PodDisruptionBudget, error) {
+	pdb, err := c.pdbClient.Get(name, kmeta.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	pdb.TypeMeta = _pdbTypeMeta
+	return pdb, nil
+}
+
+func (c *Client) DeletePDB(name string) (bool, error) {
+	err := c.pdbClient.Delete(name, _deleteOpts)
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return true, nil
+}
+
+func (c *Client) ListPDBs(opts *kmeta.ListOptions) ([]kpolicy.PodDisruptionBudget, error) {
+	if opts == nil {
+		opts = &kmeta.ListOptions{}
+	}
+	pdbList, err := c.pdbClient.List(*opts)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	for i := range pdbList.Items {
+		pdbList.Items[i].TypeMeta = _pdbTypeMeta
+	}
+	return pdbList.Items, nil
+}
+
+func (c *Client) ListPDBsByLabels(labels map[string]string) ([]kpolicy.PodDisruptionBudget, error) {
+	opts := &kmeta.ListOptions{
+		LabelSelector: klabels.SelectorFromSet(labels).String(),
+	}
+	return c.ListPDBs(opts)
+}
+
+func (c *Client) ListPDBsByLabel(labelKey string, labelValue string) ([]kpolicy.PodDisruptionBudget, error) {
+	return c.ListPDBsByLabels(map[string]string{labelKey: labelValue})
+}
+
+func (c *Client) ListPDBsWithLabelKeys(labelKeys ...string) ([]kpolicy.PodDisruptionBudget, error) {
+	opts := &kmeta.ListOptions{
+		LabelSelector: LabelExistsSelector(labelKeys...),
+	}
+	return c.ListPDBs(opts)
+}
+
+func (c *Client) WatchPDBs(opts *kmeta.ListOptions) (kwatch.Interface, error) {
+	if opts == nil {
+		opts = &kmeta.ListOptions{}
+	}
+	watcher, err := c.pdbClient.Watch(*opts)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return watcher, nil
+}
+
+func PDBMap(pdbs []kpolicy.PodDisruptionBudget) map[string]kpolicy.PodDisruptionBudget {
+	pdbMap := map[string]kpolicy.PodDisruptionBudget{}
+	for _, pdb := range pdbs {
+		pdbMap[pdb.Name] = pdb
+	}
+	return pdbMap
+}
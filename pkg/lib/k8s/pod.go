@@ -103,14 +103,12 @@ func (c *Client) UpdatePod(pod *kcore.Pod) (*kcore.Pod, error) {
 }
 
 func (c *Client) ApplyPod(pod *kcore.Pod) (*kcore.Pod, error) {
-	existing, err := c.GetPod(pod.Name)
-	if err != nil {
+	pod.TypeMeta = _podTypeMeta
+	result := &kcore.Pod{}
+	if err := c.apply(c.clientset.CoreV1().RESTClient(), "pods", pod.Name, pod, result, FieldManager, false); err != nil {
 		return nil, err
 	}
-	if existing == nil {
-		return c.CreatePod(pod)
-	}
-	return c.UpdatePod(pod)
+	return result, nil
 }
 
 func IsPodReady(pod *kcore.Pod) bool {
@@ -402,3 +400,34 @@ func (c *Client) Exec(podName string, containerName string, command []string) (s
 	return buf.String(), nil
 
 }
+
+// GetPodLogs returns the last tailLines lines of the container's logs (or all lines if tailLines is nil)
+func (c *Client) GetPodLogs(podName string, containerName string, tailLines *int64) (string, error) {
+	options := &kcore.PodLogOptions{
+		Container: containerName,
+		TailLines: tailLines,
+	}
+
+	logs, err := c.podClient.GetLogs(podName, options).Do().Raw()
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return string(logs), nil
+}
+
+// GetPreviousPodLogs returns the last tailLines lines of the logs of the container's previous instance, i.e. before it was restarted (or all lines if tailLines is nil)
+func (c *Client) GetPreviousPodLogs(podName string, containerName string, tailLines *int64) (string, error) {
+	options := &kcore.PodLogOptions{
+		Container: containerName,
+		TailLines: tailLines,
+		Previous:  true,
+	}
+
+	logs, err := c.podClient.GetLogs(podName, options).Do().Raw()
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return string(logs), nil
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"strings"
+)
+
+// seccompProfile is not a field of the security context until kubernetes 1.19
+const SeccompPodAnnotationKey = "seccomp.security.alpha.kubernetes.io/pod"
+
+const (
+	SeccompProfileRuntimeDefault  = "runtime/default"
+	SeccompProfileDockerDefault   = "docker/default"
+	SeccompProfileUnconfined      = "unconfined"
+	SeccompProfileLocalhostPrefix = "localhost/"
+)
+
+// appArmorProfile is not a field of the security context until kubernetes 1.30
+const AppArmorContainerAnnotationKeyPrefix = "container.apparmor.security.beta.kubernetes.io/"
+
+const (
+	AppArmorProfileRuntimeDefault  = "runtime/default"
+	AppArmorProfileUnconfined      = "unconfined"
+	AppArmorProfileLocalhostPrefix = "localhost/"
+)
+
+// pod security standards (https://kubernetes.io/docs/concepts/security/pod-security-standards)
+const (
+	PodSecurityStandardPrivileged = "privileged"
+	PodSecurityStandardRestricted = "restricted"
+)
+
+var PodSecurityStandards = []string{PodSecurityStandardPrivileged, PodSecurityStandardRestricted}
+
+func AppArmorContainerAnnotationKey(containerName string) string {
+	return AppArmorContainerAnnotationKeyPrefix + containerName
+}
+
+func ValidateSeccompProfile(profile string) (string, error) {
+	switch profile {
+	case SeccompProfileRuntimeDefault, SeccompProfileDockerDefault, SeccompProfileUnconfined:
+		return profile, nil
+	}
+
+	if strings.HasPrefix(profile, SeccompProfileLocalhostPrefix) && len(profile) > len(SeccompProfileLocalhostPrefix) {
+		return profile, nil
+	}
+
+	return "", ErrorSeccompProfile(profile)
+}
+
+func ValidateAppArmorProfile(profile string) (string, error) {
+	switch profile {
+	case AppArmorProfileRuntimeDefault, AppArmorProfileUnconfined:
+		return profile, nil
+	}
+
+	if strings.HasPrefix(profile, AppArmorProfileLocalhostPrefix) && len(profile) > len(AppArmorProfileLocalhostPrefix) {
+		return profile, nil
+	}
+
+	return "", ErrorAppArmorProfile(profile)
+}
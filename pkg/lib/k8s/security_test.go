@@ -0,0 +1,49 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSeccompProfile(t *testing.T) {
+	for _, profile := range []string{"runtime/default", "docker/default", "unconfined", "localhost/my-profile.json"} {
+		validated, err := ValidateSeccompProfile(profile)
+		require.NoError(t, err)
+		require.Equal(t, profile, validated)
+	}
+
+	for _, profile := range []string{"", "default", "localhost/", "Unconfined", "runtime/default/"} {
+		_, err := ValidateSeccompProfile(profile)
+		require.Error(t, err)
+	}
+}
+
+func TestValidateAppArmorProfile(t *testing.T) {
+	for _, profile := range []string{"runtime/default", "unconfined", "localhost/my-profile"} {
+		validated, err := ValidateAppArmorProfile(profile)
+		require.NoError(t, err)
+		require.Equal(t, profile, validated)
+	}
+
+	for _, profile := range []string{"", "docker/default", "localhost/", "RUNTIME/DEFAULT"} {
+		_, err := ValidateAppArmorProfile(profile)
+		require.Error(t, err)
+	}
+}
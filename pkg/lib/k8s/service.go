@@ -86,14 +86,12 @@ func (c *Client) UpdateService(existing, updated *kcore.Service) (*kcore.Service
 }
 
 func (c *Client) ApplyService(service *kcore.Service) (*kcore.Service, error) {
-	existing, err := c.GetService(service.Name)
-	if err != nil {
+	service.TypeMeta = _serviceTypeMeta
+	result := &kcore.Service{}
+	if err := c.apply(c.clientset.CoreV1().RESTClient(), "services", service.Name, service, result, FieldManager, false); err != nil {
 		return nil, err
 	}
-	if existing == nil {
-		return c.CreateService(service)
-	}
-	return c.UpdateService(existing, service)
+	return result, nil
 }
 
 func (c *Client) GetService(name string) (*kcore.Service, error) {
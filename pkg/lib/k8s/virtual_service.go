@@ -17,6 +17,8 @@ limitations under the License.
 package k8s
 
 import (
+	"encoding/json"
+
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
 	"github.com/cortexlabs/cortex/pkg/lib/urls"
@@ -25,33 +27,37 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	kmeta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	klabels "k8s.io/apimachinery/pkg/labels"
+	ktypes "k8s.io/apimachinery/pkg/types"
 )
 
 var _virtualServiceTypeMeta = kmeta.TypeMeta{
-	APIVersion: "v1alpha3",
+	APIVersion: "networking.istio.io/v1alpha3",
 	Kind:       "VirtualService",
 }
 
 type VirtualServiceSpec struct {
-	Name         string
-	Gateways     []string
-	Destinations []Destination
-	Path         string
-	Rewrite      *string
-	Labels       map[string]string
-	Annotations  map[string]string
+	Name            string
+	Gateways        []string
+	Destinations    []Destination
+	Path            string
+	Rewrite         *string
+	ResponseHeaders map[string]string // headers to set on all responses
+	AbortStatusCode int32             // if non-zero, all requests are responded to with this status code instead of being routed to the destinations
+	Labels          map[string]string
+	Annotations     map[string]string
 }
 
 type Destination struct {
-	ServiceName string
-	Weight      int32
-	Port        uint32
+	ServiceName     string
+	Weight          int32
+	Port            uint32
+	ResponseHeaders map[string]string // headers to set on responses from this destination
 }
 
 func VirtualService(spec *VirtualServiceSpec) *istioclientnetworking.VirtualService {
 	destinations := []*istionetworking.HTTPRouteDestination{}
 	for _, destination := range spec.Destinations {
-		destinations = append(destinations, &istionetworking.HTTPRouteDestination{
+		routeDestination := &istionetworking.HTTPRouteDestination{
 			Destination: &istionetworking.Destination{
 				Host: destination.ServiceName,
 				Port: &istionetworking.PortSelector{
@@ -59,7 +65,15 @@ func VirtualService(spec *VirtualServiceSpec) *istioclientnetworking.VirtualServ
 				},
 			},
 			Weight: destination.Weight,
-		})
+		}
+		if len(destination.ResponseHeaders) > 0 {
+			routeDestination.Headers = &istionetworking.Headers{
+				Response: &istionetworking.Headers_HeaderOperations{
+					Set: destination.ResponseHeaders,
+				},
+			}
+		}
+		destinations = append(destinations, routeDestination)
 	}
 
 	virtualService := &istioclientnetworking.VirtualService{
@@ -95,6 +109,27 @@ func VirtualService(spec *VirtualServiceSpec) *istioclientnetworking.VirtualServ
 		}
 	}
 
+	if spec.AbortStatusCode != 0 {
+		virtualService.Spec.Http[0].Fault = &istionetworking.HTTPFaultInjection{
+			Abort: &istionetworking.HTTPFaultInjection_Abort{
+				ErrorType: &istionetworking.HTTPFaultInjection_Abort_HttpStatus{
+					HttpStatus: spec.AbortStatusCode,
+				},
+				Percentage: &istionetworking.Percent{
+					Value: 100,
+				},
+			},
+		}
+	}
+
+	if len(spec.ResponseHeaders) > 0 {
+		virtualService.Spec.Http[0].Headers = &istionetworking.Headers{
+			Response: &istionetworking.Headers_HeaderOperations{
+				Set: spec.ResponseHeaders,
+			},
+		}
+	}
+
 	return virtualService
 }
 
@@ -119,14 +154,40 @@ func (c *Client) UpdateVirtualService(existing, updated *istioclientnetworking.V
 }
 
 func (c *Client) ApplyVirtualService(virtualService *istioclientnetworking.VirtualService) (*istioclientnetworking.VirtualService, error) {
-	existing, err := c.GetVirtualService(virtualService.Name)
-	if err != nil {
+	virtualService.TypeMeta = _virtualServiceTypeMeta
+	result := &istioclientnetworking.VirtualService{}
+	if err := c.apply(c.istioClientset.NetworkingV1alpha3().RESTClient(), "virtualservices", virtualService.Name, virtualService, result, FieldManager, false); err != nil {
 		return nil, err
 	}
-	if existing == nil {
-		return c.CreateVirtualService(virtualService)
+	return result, nil
+}
+
+// PatchVirtualServiceAnnotations sets annotations on an existing virtual service as fieldManager, without touching any
+// other fields (annotations whose value is nil are removed). Unlike an apply, the patch fails if the virtual service
+// doesn't exist, so it can't recreate a virtual service which is being deleted
+func (c *Client) PatchVirtualServiceAnnotations(name string, annotations map[string]*string, fieldManager string) (*istioclientnetworking.VirtualService, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := &istioclientnetworking.VirtualService{}
+	err = c.istioClientset.NetworkingV1alpha3().RESTClient().Patch(ktypes.MergePatchType).
+		Namespace(c.Namespace).
+		Resource("virtualservices").
+		Name(name).
+		Param("fieldManager", fieldManager).
+		Body(body).
+		Do().
+		Into(result)
+	if err != nil {
+		return nil, errors.WithStack(err)
 	}
-	return c.UpdateVirtualService(existing, virtualService)
+	return result, nil
 }
 
 func (c *Client) GetVirtualService(name string) (*istioclientnetworking.VirtualService, error) {
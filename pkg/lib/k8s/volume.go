@@ -18,6 +18,7 @@ package k8s
 
 import (
 	kcore "k8s.io/api/core/v1"
+	kresource "k8s.io/apimachinery/pkg/api/resource"
 )
 
 func EmptyDirVolume(volumeName string) kcore.Volume {
@@ -29,9 +30,46 @@ func EmptyDirVolume(volumeName string) kcore.Volume {
 	}
 }
 
+// MemoryEmptyDirVolume is backed by tmpfs, and counts towards the memory usage of the containers which write to it
+func MemoryEmptyDirVolume(volumeName string, sizeLimit *kresource.Quantity) kcore.Volume {
+	return kcore.Volume{
+		Name: volumeName,
+		VolumeSource: kcore.VolumeSource{
+			EmptyDir: &kcore.EmptyDirVolumeSource{
+				Medium:    kcore.StorageMediumMemory,
+				SizeLimit: sizeLimit,
+			},
+		},
+	}
+}
+
+func HugePagesEmptyDirVolume(volumeName string) kcore.Volume {
+	return kcore.Volume{
+		Name: volumeName,
+		VolumeSource: kcore.VolumeSource{
+			EmptyDir: &kcore.EmptyDirVolumeSource{
+				Medium: kcore.StorageMediumHugePages,
+			},
+		},
+	}
+}
+
 func EmptyDirVolumeMount(volumeName string, mountPath string) kcore.VolumeMount {
 	return kcore.VolumeMount{
 		Name:      volumeName,
 		MountPath: mountPath,
 	}
 }
+
+func ConfigMapVolume(volumeName string, configMapName string) kcore.Volume {
+	return kcore.Volume{
+		Name: volumeName,
+		VolumeSource: kcore.VolumeSource{
+			ConfigMap: &kcore.ConfigMapVolumeSource{
+				LocalObjectReference: kcore.LocalObjectReference{
+					Name: configMapName,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parallel
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+)
+
+// Group runs tasks concurrently and waits for all of them to finish. Panics in tasks are recovered and returned as errors.
+// The zero value is ready to use, and runs all tasks at once
+type Group struct {
+	// Limit is the maximum number of tasks which can run at once (0 means no limit)
+	Limit int
+
+	// OnTaskDone is called (if set) after each task completes, e.g. to record telemetry
+	OnTaskDone func(taskName string, duration time.Duration, err error)
+
+	// OnWait is called (if set) the first time Wait returns, with the results of all of the group's tasks
+	OnWait func(results []TaskResult)
+
+	wg       sync.WaitGroup
+	initOnce sync.Once
+	waitOnce sync.Once
+	sem      chan struct{}
+	mux      sync.Mutex
+	errs     []error
+	results  []TaskResult
+}
+
+// TaskResult describes a completed task
+type TaskResult struct {
+	TaskName string
+	Duration time.Duration
+	Err      error
+}
+
+// NewGroup creates a Group which runs at most limit tasks at once (0 means no limit)
+func NewGroup(limit int) *Group {
+	return &Group{Limit: limit}
+}
+
+// Go runs fn in a new goroutine, blocking until a slot is available if the group's limit has been reached
+func (g *Group) Go(taskName string, fn func() error) {
+	g.initOnce.Do(func() {
+		if g.Limit > 0 {
+			g.sem = make(chan struct{}, g.Limit)
+		}
+	})
+
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+
+		start := time.Now()
+		err := runTask(taskName, fn)
+		duration := time.Since(start)
+
+		if g.OnTaskDone != nil {
+			g.OnTaskDone(taskName, duration, err)
+		}
+
+		g.mux.Lock()
+		g.results = append(g.results, TaskResult{TaskName: taskName, Duration: duration, Err: err})
+		if err != nil {
+			g.errs = append(g.errs, err)
+		}
+		g.mux.Unlock()
+	}()
+}
+
+// Wait blocks until all tasks have finished, and returns their errors (in order of completion)
+func (g *Group) Wait() []error {
+	g.wg.Wait()
+	g.mux.Lock()
+	errs, results := g.errs, g.results
+	g.mux.Unlock()
+
+	if g.OnWait != nil {
+		g.waitOnce.Do(func() {
+			g.OnWait(results)
+		})
+	}
+
+	return errs
+}
+
+// WaitFirstErr blocks until all tasks have finished, and returns the first error encountered (if any)
+func (g *Group) WaitFirstErr() error {
+	return errors.FirstError(g.Wait()...)
+}
+
+// RunWithLimit runs fns concurrently (at most limit at once), and returns the errors in the same order as fns
+func RunWithLimit(limit int, fns ...func() error) []error {
+	errs := make([]error, len(fns))
+	group := NewGroup(limit)
+
+	for i := range fns {
+		localIdx := i
+		if fns[localIdx] == nil {
+			continue
+		}
+		group.Go("", func() error {
+			errs[localIdx] = runTask("", fns[localIdx])
+			return nil
+		})
+	}
+
+	group.Wait()
+	return errs
+}
+
+// RunFirstErrWithLimit runs fns concurrently (at most limit at once), and returns the first error encountered (if any)
+func RunFirstErrWithLimit(limit int, fns ...func() error) error {
+	return errors.FirstError(RunWithLimit(limit, fns...)...)
+}
+
+func runTask(taskName string, fn func() error) (err error) {
+	defer func() {
+		if errInterface := recover(); errInterface != nil {
+			err = ErrorTaskPanic(taskName, errors.CastRecoverError(errInterface))
+			errors.PrintStacktrace(err)
+		}
+	}()
+	return fn()
+}
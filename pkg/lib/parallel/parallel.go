@@ -25,31 +25,7 @@ import (
 
 func Run(fn func() error, fns ...func() error) []error {
 	allFns := append(fns, fn)
-
-	errChannels := make([]chan error, len(allFns))
-	for i := range errChannels {
-		errChannels[i] = make(chan error)
-	}
-
-	for i := range allFns {
-		fn := allFns[i]
-		errChannel := errChannels[i]
-
-		if fn == nil {
-			errChannel <- nil
-			continue
-		}
-
-		go func() {
-			errChannel <- fn()
-		}()
-	}
-
-	errors := make([]error, len(allFns))
-	for i := range allFns {
-		errors[i] = <-errChannels[i]
-	}
-	return errors
+	return RunWithLimit(0, allFns...)
 }
 
 func RunFirstErr(fn func() error, fns ...func() error) error {
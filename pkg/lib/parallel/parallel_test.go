@@ -91,3 +91,117 @@ package parallel
 // 	}
 // 	require.Equal(t, expectedErrs, errs)
 // }
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupLimit(t *testing.T) {
+	var running int32
+	var maxRunning int32
+
+	group := NewGroup(3)
+	for i := 0; i < 12; i++ {
+		group.Go("task", func() error {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				prevMax := atomic.LoadInt32(&maxRunning)
+				if current <= prevMax || atomic.CompareAndSwapInt32(&maxRunning, prevMax, current) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return nil
+		})
+	}
+
+	require.Empty(t, group.Wait())
+	require.Equal(t, int32(3), maxRunning)
+}
+
+func TestGroupNoLimit(t *testing.T) {
+	var running int32
+	allStarted := make(chan struct{})
+
+	var group Group
+	for i := 0; i < 5; i++ {
+		group.Go("task", func() error {
+			if atomic.AddInt32(&running, 1) == 5 {
+				close(allStarted)
+			}
+			select {
+			case <-allStarted:
+				return nil
+			case <-time.After(5 * time.Second):
+				return errors.ErrorUnexpected("tasks did not run concurrently")
+			}
+		})
+	}
+
+	require.Empty(t, group.Wait())
+}
+
+func TestGroupPanic(t *testing.T) {
+	var results []TaskResult
+	group := NewGroup(2)
+	group.OnWait = func(r []TaskResult) {
+		results = r
+	}
+
+	group.Go("ok", func() error {
+		return nil
+	})
+	group.Go("panics", func() error {
+		panic("oops")
+	})
+
+	errs := group.Wait()
+	require.Len(t, errs, 1)
+	require.Equal(t, ErrTaskPanic, errors.GetKind(errs[0]))
+	require.Contains(t, errors.Message(errs[0]), "panics")
+
+	require.Len(t, results, 2)
+	for _, result := range results {
+		if result.TaskName == "panics" {
+			require.Error(t, result.Err)
+		} else {
+			require.NoError(t, result.Err)
+		}
+	}
+
+	// the slot held by the panicked task is released
+	group.Go("after panic", func() error {
+		return nil
+	})
+	group.Go("after panic", func() error {
+		return nil
+	})
+	require.Len(t, group.Wait(), 1)
+}
+
+func TestRunWithLimit(t *testing.T) {
+	errs := RunWithLimit(2,
+		func() error {
+			return nil
+		},
+		nil,
+		func() error {
+			panic("oops")
+		},
+		func() error {
+			return errors.ErrorUnexpected("failed")
+		},
+	)
+
+	require.Len(t, errs, 4)
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+	require.Equal(t, ErrTaskPanic, errors.GetKind(errs[2]))
+	require.Equal(t, errors.ErrUnexpected, errors.GetKind(errs[3]))
+}
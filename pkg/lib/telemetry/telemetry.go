@@ -27,6 +27,7 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/maps"
 	"github.com/cortexlabs/cortex/pkg/lib/parallel"
+	"github.com/cortexlabs/cortex/pkg/lib/transport"
 	"github.com/getsentry/sentry-go"
 	"gopkg.in/segmentio/analytics-go.v3"
 )
@@ -86,9 +87,10 @@ func Init(telemetryConfig Config) error {
 	}
 
 	err := sentry.Init(sentry.ClientOptions{
-		Dsn:         dsn,
-		Release:     consts.CortexVersion,
-		Environment: telemetryConfig.Environment,
+		Dsn:           dsn,
+		Release:       consts.CortexVersion,
+		Environment:   telemetryConfig.Environment,
+		HTTPTransport: transport.New(),
 	})
 	if err != nil {
 		_config = nil
@@ -109,6 +111,7 @@ func Init(telemetryConfig Config) error {
 	_segment, err = analytics.NewWithConfig(writeKey, analytics.Config{
 		BatchSize: 1,
 		Logger:    segmentLogger,
+		Transport: transport.New(),
 		DefaultContext: &analytics.Context{
 			App: analytics.AppInfo{
 				Version: consts.CortexVersion,
@@ -0,0 +1,113 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+)
+
+// CABundleEnvVar is the environment variable which may point to a PEM file of additional certificate authorities to trust
+const CABundleEnvVar = "CORTEX_CA_BUNDLE"
+
+// Destinations which are always reached directly when a proxy is configured: loopback, the instance metadata service, and in-cluster/VPC addresses
+var _defaultNoProxy = []string{
+	"localhost",
+	"127.0.0.1",
+	"169.254.169.254",
+	".svc",
+	".cluster.local",
+	".internal",
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+}
+
+// nil means that only the system's certificate authorities are trusted
+var _rootCAs *x509.CertPool
+
+// AddCertificates trusts the PEM-encoded certificate authorities in pemCerts (in addition to the system's) for all transports created afterwards
+func AddCertificates(pemCerts []byte) error {
+	pool := _rootCAs
+	if pool == nil {
+		pool, _ = x509.SystemCertPool()
+		if pool == nil {
+			pool = x509.NewCertPool()
+		}
+	}
+
+	if !pool.AppendCertsFromPEM(pemCerts) {
+		return ErrorInvalidCABundle()
+	}
+
+	_rootCAs = pool
+	return nil
+}
+
+// AddCertificatesFromEnv trusts the certificate authorities in the file at $CORTEX_CA_BUNDLE, if it's set
+func AddCertificatesFromEnv() error {
+	path := os.Getenv(CABundleEnvVar)
+	if path == "" {
+		return nil
+	}
+
+	// files.ReadFileBytes can't be used here, since the files package (indirectly) depends on telemetry, which depends on this package
+	pemCerts, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(errors.WithStack(err), CABundleEnvVar)
+	}
+
+	return errors.Wrap(AddCertificates(pemCerts), CABundleEnvVar, path)
+}
+
+func ValidateCABundle(pemCerts string) (string, error) {
+	if !x509.NewCertPool().AppendCertsFromPEM([]byte(pemCerts)) {
+		return "", ErrorInvalidCABundle()
+	}
+	return pemCerts, nil
+}
+
+// SetProxy routes outbound HTTPS requests through httpsProxy, except to the noProxy destinations and the in-cluster defaults; it must be called before any requests are made
+func SetProxy(httpsProxy string, noProxy []string) {
+	os.Setenv("HTTPS_PROXY", httpsProxy)
+	destinations := append([]string{}, noProxy...)
+	os.Setenv("NO_PROXY", strings.Join(append(destinations, _defaultNoProxy...), ","))
+}
+
+func TLSConfig() *tls.Config {
+	return &tls.Config{RootCAs: _rootCAs}
+}
+
+// New returns a transport which honors HTTPS_PROXY and NO_PROXY and trusts any added certificate authorities
+func New() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = TLSConfig()
+	return transport
+}
+
+// NewInsecure is like New, but doesn't verify the server's certificate (e.g. for the operator's self-signed endpoint)
+func NewInsecure() *http.Transport {
+	transport := New()
+	transport.TLSClientConfig.InsecureSkipVerify = true
+	return transport
+}
@@ -30,6 +30,7 @@ const (
 	ErrEndpoint            = "urls.endpoint"
 	ErrEndpointEmptyPath   = "urls.endpoint_empty_path"
 	ErrEndpointDoubleSlash = "urls.endpoint_double_slash"
+	ErrDomain              = "urls.domain"
 )
 
 func ErrorInvalidURL(provided string) error {
@@ -73,3 +74,10 @@ func ErrorEndpointDoubleSlash(provided string) error {
 		Message: fmt.Sprintf("%s cannot contain adjacent slashes", s.UserStr(provided)),
 	})
 }
+
+func ErrorDomain(provided string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrDomain,
+		Message: fmt.Sprintf("%s is not a valid domain (e.g. example.com, or *.example.com to include its subdomains)", s.UserStr(provided)),
+	})
+}
@@ -29,6 +29,7 @@ var (
 	_dns1123Regex   = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
 	_endpointRegex  = regexp.MustCompile(`^[a-zA-Z0-9_\-\./]*$`)
 	_urlQParamRegex = regexp.MustCompile(`(https?://.*)\?[^:\s]*`)
+	_domainRegex    = regexp.MustCompile(`^(\*\.)?([a-z0-9]([-a-z0-9]*[a-z0-9])?\.)*[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
 )
 
 func Parse(rawurl string) (*url.URL, error) {
@@ -62,6 +63,15 @@ func CheckDNS1123(str string) error {
 	return nil
 }
 
+// ValidateDomain accepts a domain name, optionally prefixed with "*." to include all of its subdomains
+func ValidateDomain(str string) (string, error) {
+	str = strings.ToLower(str)
+	if !_domainRegex.MatchString(str) {
+		return "", ErrorDomain(str)
+	}
+	return str, nil
+}
+
 func ValidateEndpoint(str string) (string, error) {
 	if !_endpointRegex.MatchString(str) {
 		return "", ErrorEndpoint(str)
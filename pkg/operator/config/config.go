@@ -28,6 +28,7 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/hash"
 	"github.com/cortexlabs/cortex/pkg/lib/k8s"
 	"github.com/cortexlabs/cortex/pkg/lib/telemetry"
+	"github.com/cortexlabs/cortex/pkg/lib/transport"
 	"github.com/cortexlabs/cortex/pkg/types/clusterconfig"
 )
 
@@ -59,6 +60,16 @@ func Init() error {
 		return errors.FirstError(errs...)
 	}
 
+	// must happen before any clients are created
+	if Cluster.Proxy != nil {
+		transport.SetProxy(Cluster.Proxy.HTTPSProxy, Cluster.Proxy.NoProxy)
+		if Cluster.Proxy.CABundle != nil {
+			if err := transport.AddCertificates([]byte(*Cluster.Proxy.CABundle)); err != nil {
+				return errors.Wrap(err, clusterconfig.ProxyKey, clusterconfig.CABundleKey)
+			}
+		}
+	}
+
 	AWS, err = aws.NewFromEnv(*Cluster.Region)
 	if err != nil {
 		return err
@@ -94,7 +105,8 @@ func Init() error {
 	}
 	Cluster.APIGateway = *apiGateway
 
-	if Cluster.APILoadBalancerScheme == clusterconfig.InternalLoadBalancerScheme {
+	// the VPC link integrates API Gateway with the istio gateway's network load balancer, which other api_ingress controllers don't use
+	if Cluster.APILoadBalancerScheme == clusterconfig.InternalLoadBalancerScheme && Cluster.APIIngress == clusterconfig.IstioAPIIngress {
 		vpcLink, err := AWS.GetVPCLinkByTag(clusterconfig.ClusterNameTag, Cluster.ClusterName)
 		if err != nil {
 			return err
@@ -114,15 +126,25 @@ func Init() error {
 
 	Cluster.InstanceMetadata = aws.InstanceMetadatas[*Cluster.Region][*Cluster.InstanceType]
 
-	if K8s, err = k8s.New("default", Cluster.OperatorInCluster); err != nil {
+	k8sConfig := &k8s.Config{
+		InCluster:       Cluster.OperatorInCluster,
+		KubeConfigPath:  os.Getenv("CORTEX_OPERATOR_KUBECONFIG"),
+		Context:         os.Getenv("CORTEX_OPERATOR_KUBE_CONTEXT"),
+		ImpersonateUser: os.Getenv("CORTEX_OPERATOR_IMPERSONATE_USER"),
+	}
+	if groups := os.Getenv("CORTEX_OPERATOR_IMPERSONATE_GROUPS"); groups != "" {
+		k8sConfig.ImpersonateGroups = strings.Split(groups, ",")
+	}
+
+	if K8s, err = k8s.NewFromConfig("default", k8sConfig); err != nil {
 		return err
 	}
 
-	if K8sIstio, err = k8s.New("istio-system", Cluster.OperatorInCluster); err != nil {
+	if K8sIstio, err = k8s.NewFromConfig("istio-system", k8sConfig); err != nil {
 		return err
 	}
 
-	if K8sAllNamspaces, err = k8s.New("", Cluster.OperatorInCluster); err != nil {
+	if K8sAllNamspaces, err = k8s.NewFromConfig("", k8sConfig); err != nil {
 		return err
 	}
 
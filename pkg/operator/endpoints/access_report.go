@@ -0,0 +1,69 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/lib/slices"
+	"github.com/cortexlabs/cortex/pkg/operator/operator"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+)
+
+// AccessReport exports the audit log between start and end (by default, the last 24 hours) as CSV or JSON
+func AccessReport(w http.ResponseWriter, r *http.Request) {
+	end, err := getOptionalTimeQParam("end", time.Now(), r)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	start, err := getOptionalTimeQParam("start", end.Add(-24*time.Hour), r)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	format := getOptionalQParam("format", r)
+	if format == "" {
+		format = schema.AccessReportFormatCSV
+	}
+	if !slices.HasString(schema.AccessReportFormats, format) {
+		respondError(w, r, ErrorInvalidQueryParamValue("format", format, schema.AccessReportFormats))
+		return
+	}
+
+	events, err := operator.ListAuditEvents(start, end)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	report, err := operator.AccessReport(events, format)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	if format == schema.AccessReportFormatCSV {
+		w.Header().Set("Content-Type", "text/csv")
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(report)
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/lib/hash"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
+	"github.com/gorilla/mux"
+)
+
+// responses to these routes are expensive to generate (they read from the cluster, S3, and CloudWatch), and are polled
+// (e.g. by `cortex get --watch`), so they are cached briefly and identified by an ETag
+var _cacheableRoutes = strset.New(
+	"/get",
+	"/get/{apiName}",
+	"/manifest/{apiName}",
+	"/previews",
+	"/loadtest/{apiName}/{jobID}",
+	"/replay/{apiName}/{replayID}",
+)
+
+// the cache is per operator replica, and only the replica which handles a request that changes the cluster's APIs clears its
+// cache, so other replicas may serve a response which is up to _responseCacheTTL older than the change
+const _responseCacheTTL = 5 * time.Second
+
+type cachedResponse struct {
+	header    http.Header
+	body      []byte
+	etag      string
+	createdAt time.Time
+}
+
+var (
+	_responseCache    = map[string]cachedResponse{} // path and query parameters -> response
+	_responseCacheMux sync.Mutex
+)
+
+// bufferedRecorder holds the response so that it can be cached before it is sent
+type bufferedRecorder struct {
+	header     http.Header
+	statusCode int
+	body       []byte
+}
+
+func (recorder *bufferedRecorder) Header() http.Header {
+	return recorder.header
+}
+
+func (recorder *bufferedRecorder) WriteHeader(statusCode int) {
+	recorder.statusCode = statusCode
+}
+
+func (recorder *bufferedRecorder) Write(bytes []byte) (int, error) {
+	if recorder.statusCode == 0 {
+		recorder.statusCode = http.StatusOK
+	}
+	recorder.body = append(recorder.body, bytes...)
+	return len(bytes), nil
+}
+
+// ResponseCacheMiddleware serves successful responses to cacheable routes from the cache for up to 5 seconds, and responds
+// with 304 Not Modified if the request's If-None-Match header matches the response's ETag; requests which change the
+// cluster's APIs (i.e. all requests other than GETs) clear the cache
+func ResponseCacheMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			defer clearResponseCache()
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !isCacheableRoute(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key := responseCacheKey(r)
+		response, ok := getCachedResponse(key)
+		if !ok {
+			recorder := &bufferedRecorder{header: http.Header{}}
+			next.ServeHTTP(recorder, r)
+
+			if recorder.statusCode != http.StatusOK {
+				copyHeader(w.Header(), recorder.header)
+				w.WriteHeader(recorder.statusCode)
+				w.Write(recorder.body)
+				return
+			}
+
+			response = cachedResponse{
+				header:    recorder.header,
+				body:      recorder.body,
+				etag:      `"` + hash.Bytes(recorder.body) + `"`,
+				createdAt: time.Now(),
+			}
+			setCachedResponse(key, response)
+		}
+
+		copyHeader(w.Header(), response.header)
+		w.Header().Set("ETag", response.etag)
+		if r.Header.Get("If-None-Match") == response.etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write(response.body)
+	})
+}
+
+func copyHeader(dst http.Header, src http.Header) {
+	for key, values := range src {
+		dst[key] = append([]string(nil), values...)
+	}
+}
+
+func isCacheableRoute(r *http.Request) bool {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return false
+	}
+	pathTemplate, err := route.GetPathTemplate()
+	if err != nil {
+		return false
+	}
+	return _cacheableRoutes.Has(pathTemplate)
+}
+
+// the client ID doesn't affect the response, so it's excluded from the key
+func responseCacheKey(r *http.Request) string {
+	query := r.URL.Query()
+	query.Del("clientID")
+	return r.URL.Path + "?" + query.Encode()
+}
+
+func getCachedResponse(key string) (cachedResponse, bool) {
+	_responseCacheMux.Lock()
+	defer _responseCacheMux.Unlock()
+
+	response, ok := _responseCache[key]
+	if !ok || time.Since(response.createdAt) > _responseCacheTTL {
+		return cachedResponse{}, false
+	}
+	return response, true
+}
+
+func setCachedResponse(key string, response cachedResponse) {
+	_responseCacheMux.Lock()
+	defer _responseCacheMux.Unlock()
+
+	// expired responses are removed when others are added, so that the cache doesn't grow with e.g. deleted APIs
+	for existingKey, existingResponse := range _responseCache {
+		if time.Since(existingResponse.createdAt) > _responseCacheTTL {
+			delete(_responseCache, existingKey)
+		}
+	}
+	_responseCache[key] = response
+}
+
+func clearResponseCache() {
+	_responseCacheMux.Lock()
+	defer _responseCacheMux.Unlock()
+	_responseCache = map[string]cachedResponse{}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCacheMiddleware(t *testing.T) {
+	numCalls := 0
+	router := mux.NewRouter()
+	router.Use(ResponseCacheMiddleware)
+	router.HandleFunc("/get/{apiName}", func(w http.ResponseWriter, r *http.Request) {
+		numCalls++
+		if mux.Vars(r)["apiName"] == "missing" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		respond(w, map[string]int{"calls": numCalls})
+	}).Methods("GET")
+	router.HandleFunc("/deploy", func(w http.ResponseWriter, r *http.Request) {
+		respond(w, nil)
+	}).Methods("POST")
+
+	get := func(path string, etag string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest("GET", path, nil)
+		if etag != "" {
+			request.Header.Set("If-None-Match", etag)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	response := get("/get/my-api?clientID=a", "")
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Equal(t, "{\"calls\":1}\n", response.Body.String())
+	etag := response.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	// served from the cache (the client ID is ignored), including the handler's headers
+	response = get("/get/my-api?clientID=b", "")
+	require.Equal(t, "{\"calls\":1}\n", response.Body.String())
+	require.Equal(t, "application/json", response.Header().Get("Content-Type"))
+	response = get("/get/my-api", etag)
+	require.Equal(t, http.StatusNotModified, response.Code)
+	require.Empty(t, response.Body.String())
+	require.Equal(t, 1, numCalls)
+
+	// errors aren't cached
+	get("/get/missing", "")
+	response = get("/get/missing", "")
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Equal(t, 3, numCalls)
+
+	// changes clear the cache
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/deploy", nil))
+	response = get("/get/my-api", etag)
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Equal(t, "{\"calls\":4}\n", response.Body.String())
+	require.NotEqual(t, etag, response.Header().Get("ETag"))
+}
@@ -26,6 +26,15 @@ import (
 
 func Deploy(w http.ResponseWriter, r *http.Request) {
 	force := getOptionalBoolQParam("force", false, r)
+	checkDeps := getOptionalBoolQParam("checkDependencies", false, r)
+
+	ttl, err := getOptionalDurationQParam("ttl", r)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	previewBranch := getOptionalQParam("preview", r)
 
 	configFileName, err := getRequiredQueryParam("configFileName", r)
 	if err != nil {
@@ -48,11 +57,19 @@ func Deploy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response, err := resources.Deploy(projectBytes, configFileName, configBytes, force)
+	response, err := resources.Deploy(projectBytes, configFileName, configBytes, force, ttl, previewBranch, checkDeps)
 	if err != nil {
 		respondError(w, r, err)
 		return
 	}
 
+	var apiNames []string
+	for _, result := range response.Results {
+		if result.API.API != nil {
+			apiNames = append(apiNames, result.API.Name)
+		}
+	}
+	auditEvent(r).Resources = apiNames
+
 	respond(w, response)
 }
@@ -24,17 +24,23 @@ import (
 )
 
 const (
-	ErrAPIVersionMismatch     = "endpoints.api_version_mismatch"
-	ErrHeaderMissing          = "endpoints.header_missing"
-	ErrHeaderMalformed        = "endpoints.header_malformed"
-	ErrAuthAPIError           = "endpoints.auth_api_error"
-	ErrAuthInvalid            = "endpoints.auth_invalid"
-	ErrAuthOtherAccount       = "endpoints.auth_other_account"
-	ErrFormFileMustBeProvided = "endpoints.form_file_must_be_provided"
-	ErrQueryParamRequired     = "endpoints.query_param_required"
-	ErrPathParamRequired      = "endpoints.path_param_required"
-	ErrAnyQueryParamRequired  = "endpoints.any_query_param_required"
-	ErrAnyPathParamRequired   = "endpoints.any_path_param_required"
+	ErrAPIVersionMismatch       = "endpoints.api_version_mismatch"
+	ErrHeaderMissing            = "endpoints.header_missing"
+	ErrHeaderMalformed          = "endpoints.header_malformed"
+	ErrAuthAPIError             = "endpoints.auth_api_error"
+	ErrAuthInvalid              = "endpoints.auth_invalid"
+	ErrAuthOtherAccount         = "endpoints.auth_other_account"
+	ErrFormFileMustBeProvided   = "endpoints.form_file_must_be_provided"
+	ErrQueryParamRequired       = "endpoints.query_param_required"
+	ErrPathParamRequired        = "endpoints.path_param_required"
+	ErrAnyQueryParamRequired    = "endpoints.any_query_param_required"
+	ErrAnyPathParamRequired     = "endpoints.any_path_param_required"
+	ErrInvalidProfile           = "endpoints.invalid_profile"
+	ErrQueryParamMustBeInt      = "endpoints.query_param_must_be_int"
+	ErrQueryParamMustBeDuration = "endpoints.query_param_must_be_duration"
+	ErrQueryParamMustBeTime     = "endpoints.query_param_must_be_time"
+	ErrInvalidQueryParamValue   = "endpoints.invalid_query_param_value"
+	ErrRequestBodyTooLarge      = "endpoints.request_body_too_large"
 )
 
 func ErrorAPIVersionMismatch(operatorVersion string, clientVersion string) error {
@@ -92,6 +98,34 @@ func ErrorQueryParamRequired(param string) error {
 	})
 }
 
+func ErrorQueryParamMustBeInt(param string, value string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrQueryParamMustBeInt,
+		Message: fmt.Sprintf("query param %s must be an integer (got %s)", param, s.UserStr(value)),
+	})
+}
+
+func ErrorQueryParamMustBeDuration(param string, value string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrQueryParamMustBeDuration,
+		Message: fmt.Sprintf("query param %s must be a duration, e.g. 30m or 24h (got %s)", param, s.UserStr(value)),
+	})
+}
+
+func ErrorQueryParamMustBeTime(param string, value string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrQueryParamMustBeTime,
+		Message: fmt.Sprintf("query param %s must be an RFC 3339 timestamp, e.g. 2020-07-01T00:00:00Z (got %s)", param, s.UserStr(value)),
+	})
+}
+
+func ErrorInvalidQueryParamValue(param string, value string, allowedValues []string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidQueryParamValue,
+		Message: fmt.Sprintf("query param %s must be %s (got %s)", param, s.UserStrsOr(allowedValues), s.UserStr(value)),
+	})
+}
+
 func ErrorPathParamRequired(param string) error {
 	return errors.WithStack(&errors.Error{
 		Kind:    ErrPathParamRequired,
@@ -114,3 +148,17 @@ func ErrorAnyPathParamRequired(param string, params ...string) error {
 		Message: fmt.Sprintf("path params required: %s", s.UserStrsOr(allParams)),
 	})
 }
+
+func ErrorInvalidProfile(profileName string, validProfileNames []string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidProfile,
+		Message: fmt.Sprintf("invalid profile %s; valid profiles are %s", s.UserStr(profileName), s.UserStrsOr(validProfileNames)),
+	})
+}
+
+func ErrorRequestBodyTooLarge(maxSizeMi int64) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrRequestBodyTooLarge,
+		Message: fmt.Sprintf("request body exceeds the operator's limit of %dMi (configurable via operator_server.max_request_body_size in your cluster configuration)", maxSizeMi),
+	})
+}
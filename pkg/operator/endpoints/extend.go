@@ -0,0 +1,57 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/operator/resources"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+	"github.com/gorilla/mux"
+)
+
+func Extend(w http.ResponseWriter, r *http.Request) {
+	apiName := mux.Vars(r)["apiName"]
+
+	// a nil duration removes the API's expiration
+	var duration *time.Duration
+	if !getOptionalBoolQParam("remove", false, r) {
+		durationStr, err := getRequiredQueryParam("duration", r)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+		parsedDuration, err := time.ParseDuration(durationStr)
+		if err != nil {
+			respondError(w, r, ErrorQueryParamMustBeDuration("duration", durationStr))
+			return
+		}
+		duration = &parsedDuration
+	}
+
+	msg, err := resources.ExtendAPI(apiName, duration)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	response := schema.ExtendResponse{
+		Message: msg,
+	}
+	respond(w, response)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	s "github.com/cortexlabs/cortex/pkg/lib/strings"
+	"github.com/cortexlabs/cortex/pkg/operator/resources"
+	"github.com/gorilla/mux"
+)
+
+func LoadTest(w http.ResponseWriter, r *http.Request) {
+	apiName := mux.Vars(r)["apiName"]
+
+	rpsStr, err := getRequiredQueryParam("rps", r)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	rps, ok := s.ParseInt(rpsStr)
+	if !ok {
+		respondError(w, r, ErrorQueryParamMustBeInt("rps", rpsStr))
+		return
+	}
+
+	durationStr, err := getRequiredQueryParam("duration", r)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	durationSecs, ok := s.ParseInt(durationStr)
+	if !ok {
+		respondError(w, r, ErrorQueryParamMustBeInt("duration", durationStr))
+		return
+	}
+
+	payload, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		respondError(w, r, errors.WithStack(err))
+		return
+	}
+
+	response, err := resources.LoadTestAPI(apiName, payload, rps, time.Duration(durationSecs)*time.Second)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	respond(w, response)
+}
+
+func GetLoadTest(w http.ResponseWriter, r *http.Request) {
+	apiName := mux.Vars(r)["apiName"]
+	jobID := mux.Vars(r)["jobID"]
+
+	response, err := resources.GetLoadTest(apiName, jobID)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	respond(w, response)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"net/http"
+
+	s "github.com/cortexlabs/cortex/pkg/lib/strings"
+	"github.com/cortexlabs/cortex/pkg/operator/resources"
+	"github.com/cortexlabs/cortex/pkg/operator/resources/syncapi"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+	"github.com/gorilla/mux"
+)
+
+func Maintenance(w http.ResponseWriter, r *http.Request) {
+	apiName := mux.Vars(r)["apiName"]
+	enabled := getOptionalBoolQParam("enabled", true, r)
+
+	var maintenance *schema.Maintenance
+	if enabled {
+		statusCode := syncapi.DefaultMaintenanceStatusCode
+		if statusCodeStr := getOptionalQParam("status_code", r); statusCodeStr != "" {
+			var ok bool
+			statusCode, ok = s.ParseInt(statusCodeStr)
+			if !ok {
+				respondError(w, r, ErrorQueryParamMustBeInt("status_code", statusCodeStr))
+				return
+			}
+		}
+
+		maintenance = &schema.Maintenance{
+			StatusCode: statusCode,
+			Message:    getOptionalQParam("message", r),
+		}
+	}
+
+	msg, err := resources.SetMaintenance(apiName, maintenance)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	response := schema.MaintenanceResponse{
+		Message: msg,
+	}
+	respond(w, response)
+}
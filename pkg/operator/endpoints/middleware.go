@@ -20,12 +20,17 @@ import (
 	"context"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/cortexlabs/cortex/pkg/consts"
 	"github.com/cortexlabs/cortex/pkg/lib/aws"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
 	"github.com/cortexlabs/cortex/pkg/lib/telemetry"
 	"github.com/cortexlabs/cortex/pkg/operator/config"
+	"github.com/cortexlabs/cortex/pkg/operator/operator"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+	"github.com/gorilla/mux"
 )
 
 var _cachedClientIDs = strset.New()
@@ -35,6 +40,8 @@ type ctxKey int
 const (
 	ctxKeyUnknown ctxKey = iota
 	ctxKeyClient
+	ctxKeyIdentity
+	ctxKeyAuditEvent
 )
 
 func PanicMiddleware(next http.Handler) http.Handler {
@@ -44,6 +51,22 @@ func PanicMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+func RequestBodyLimitMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		maxSizeMi := config.Cluster.OperatorServer.MaxRequestBodySize
+		maxBytes := maxSizeMi * 1024 * 1024
+
+		if r.ContentLength > maxBytes {
+			respondErrorCode(w, r, http.StatusRequestEntityTooLarge, ErrorRequestBodyTooLarge(maxSizeMi))
+			return
+		}
+
+		// bodies without a declared length (e.g. chunked) fail once the limit is read past
+		r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+		next.ServeHTTP(w, r)
+	})
+}
+
 func ClientIDMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if clientID := r.URL.Query().Get("clientID"); clientID != "" {
@@ -107,6 +130,10 @@ func AuthMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
+		if callerARN, err := awsClient.GetCachedCallerARN(); err == nil {
+			r = r.WithContext(context.WithValue(r.Context(), ctxKeyIdentity, callerARN))
+		}
+
 		next.ServeHTTP(w, r)
 	})
 }
@@ -131,3 +158,80 @@ func APIVersionCheckMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// statusRecorder records the status code of the response
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (recorder *statusRecorder) WriteHeader(statusCode int) {
+	recorder.statusCode = statusCode
+	recorder.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (recorder *statusRecorder) Write(bytes []byte) (int, error) {
+	if recorder.statusCode == 0 {
+		recorder.statusCode = http.StatusOK
+	}
+	return recorder.ResponseWriter.Write(bytes)
+}
+
+// AuditMiddleware records requests which change the cluster's APIs (i.e. all requests other than GETs) in the audit log
+func AuditMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		event := &schema.AuditEvent{
+			Time:       time.Now(),
+			Parameters: map[string]string{},
+		}
+		if route := mux.CurrentRoute(r); route != nil {
+			if pathTemplate, err := route.GetPathTemplate(); err == nil {
+				event.Action = strings.Split(strings.TrimPrefix(pathTemplate, "/"), "/")[0]
+			}
+		}
+		if apiName := mux.Vars(r)["apiName"]; apiName != "" {
+			event.Resources = []string{apiName}
+		}
+		if identity, ok := r.Context().Value(ctxKeyIdentity).(string); ok {
+			event.Identity = identity
+		}
+		if clientID, ok := r.Context().Value(ctxKeyClient).(string); ok {
+			event.ClientID = clientID
+		}
+		for key := range r.URL.Query() {
+			if key != "clientID" {
+				event.Parameters[key] = r.URL.Query().Get(key)
+			}
+		}
+
+		recorder := &statusRecorder{ResponseWriter: w}
+		r = r.WithContext(context.WithValue(r.Context(), ctxKeyAuditEvent, event))
+
+		defer func() {
+			event.StatusCode = recorder.statusCode
+			if event.StatusCode == 0 {
+				event.StatusCode = http.StatusInternalServerError // the handler panicked before responding
+			}
+			if err := operator.RecordAuditEvent(*event); err != nil {
+				telemetry.Error(err)
+				errors.PrintError(err)
+			}
+		}()
+
+		next.ServeHTTP(recorder, r)
+	})
+}
+
+// auditEvent returns the request's audit event, so that handlers can add details which aren't in the request's path
+// (it is recorded when the handler returns)
+func auditEvent(r *http.Request) *schema.AuditEvent {
+	if event, ok := r.Context().Value(ctxKeyAuditEvent).(*schema.AuditEvent); ok {
+		return event
+	}
+	return &schema.AuditEvent{} // the request isn't audited
+}
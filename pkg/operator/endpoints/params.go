@@ -18,6 +18,7 @@ package endpoints
 
 import (
 	"net/http"
+	"time"
 
 	s "github.com/cortexlabs/cortex/pkg/lib/strings"
 	"github.com/gorilla/mux"
@@ -51,3 +52,31 @@ func getOptionalBoolQParam(paramName string, defaultVal bool, r *http.Request) b
 	}
 	return defaultVal
 }
+
+// Returns nil if the param is not set
+func getOptionalDurationQParam(paramName string, r *http.Request) (*time.Duration, error) {
+	paramStr := getOptionalQParam(paramName, r)
+	if paramStr == "" {
+		return nil, nil
+	}
+
+	duration, err := time.ParseDuration(paramStr)
+	if err != nil {
+		return nil, ErrorQueryParamMustBeDuration(paramName, paramStr)
+	}
+	return &duration, nil
+}
+
+// Returns defaultVal if the param is not set
+func getOptionalTimeQParam(paramName string, defaultVal time.Time, r *http.Request) (time.Time, error) {
+	paramStr := getOptionalQParam(paramName, r)
+	if paramStr == "" {
+		return defaultVal, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, paramStr)
+	if err != nil {
+		return time.Time{}, ErrorQueryParamMustBeTime(paramName, paramStr)
+	}
+	return t, nil
+}
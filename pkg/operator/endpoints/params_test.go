@@ -0,0 +1,50 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOptionalDurationQParam(t *testing.T) {
+	duration, err := getOptionalDurationQParam("ttl", httptest.NewRequest("POST", "/deploy", nil))
+	require.NoError(t, err)
+	require.Nil(t, duration)
+
+	duration, err = getOptionalDurationQParam("ttl", httptest.NewRequest("POST", "/deploy?ttl=", nil))
+	require.NoError(t, err)
+	require.Nil(t, duration)
+
+	duration, err = getOptionalDurationQParam("ttl", httptest.NewRequest("POST", "/deploy?ttl=24h", nil))
+	require.NoError(t, err)
+	require.Equal(t, 24*time.Hour, *duration)
+
+	duration, err = getOptionalDurationQParam("ttl", httptest.NewRequest("POST", "/deploy?ttl=1h30m", nil))
+	require.NoError(t, err)
+	require.Equal(t, 90*time.Minute, *duration)
+
+	_, err = getOptionalDurationQParam("ttl", httptest.NewRequest("POST", "/deploy?ttl=1d", nil))
+	require.Equal(t, ErrQueryParamMustBeDuration, errors.GetKind(err))
+
+	_, err = getOptionalDurationQParam("ttl", httptest.NewRequest("POST", "/deploy?ttl=24", nil))
+	require.Equal(t, ErrQueryParamMustBeDuration, errors.GetKind(err))
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/cortexlabs/cortex/pkg/operator/resources"
+)
+
+func GetPreviews(w http.ResponseWriter, r *http.Request) {
+	response, err := resources.GetPreviews()
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	respond(w, response)
+}
+
+// the branch is passed as a query param since branch names may contain slashes
+func DeletePreview(w http.ResponseWriter, r *http.Request) {
+	auditEvent(r).Action = "delete preview"
+
+	branch, err := getRequiredQueryParam("branch", r)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	response, err := resources.DeletePreview(branch)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	respond(w, response)
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"net/http"
+	"net/http/pprof"
+	runtimepprof "runtime/pprof"
+
+	"github.com/gorilla/mux"
+)
+
+const _cpuProfileName = "cpu"
+
+// Profile serves pprof data for the operator process (e.g. cpu, heap, goroutine, block, mutex)
+func Profile(w http.ResponseWriter, r *http.Request) {
+	profileName := mux.Vars(r)["profileName"]
+
+	if profileName == _cpuProfileName {
+		pprof.Profile(w, r)
+		return
+	}
+
+	if runtimepprof.Lookup(profileName) == nil {
+		respondError(w, r, ErrorInvalidProfile(profileName, profileNames()))
+		return
+	}
+
+	pprof.Handler(profileName).ServeHTTP(w, r)
+}
+
+func profileNames() []string {
+	names := []string{_cpuProfileName}
+	for _, profile := range runtimepprof.Profiles() {
+		names = append(names, profile.Name())
+	}
+	return names
+}
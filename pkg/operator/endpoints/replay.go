@@ -0,0 +1,81 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"net/http"
+	"time"
+
+	s "github.com/cortexlabs/cortex/pkg/lib/strings"
+	"github.com/cortexlabs/cortex/pkg/operator/resources"
+	"github.com/gorilla/mux"
+)
+
+const _defaultReplayLimit = 100
+
+// Replay re-sends the requests which the API logged between start and end (by default, the last hour) to the target API
+func Replay(w http.ResponseWriter, r *http.Request) {
+	apiName := mux.Vars(r)["apiName"]
+
+	targetAPIName, err := getRequiredQueryParam("target", r)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	end, err := getOptionalTimeQParam("end", time.Now(), r)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	start, err := getOptionalTimeQParam("start", end.Add(-time.Hour), r)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	limit := _defaultReplayLimit
+	if limitStr := getOptionalQParam("limit", r); limitStr != "" {
+		var ok bool
+		limit, ok = s.ParseInt(limitStr)
+		if !ok {
+			respondError(w, r, ErrorQueryParamMustBeInt("limit", limitStr))
+			return
+		}
+	}
+
+	response, err := resources.ReplayAPI(apiName, targetAPIName, start, end, limit)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	respond(w, response)
+}
+
+func GetReplay(w http.ResponseWriter, r *http.Request) {
+	apiName := mux.Vars(r)["apiName"]
+	replayID := mux.Vars(r)["replayID"]
+
+	response, err := resources.GetReplay(apiName, replayID)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	respond(w, response)
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/operator/resources"
+)
+
+// Usage reports each project's usage between start and end (by default, the last 24 hours), aggregated by hour
+func Usage(w http.ResponseWriter, r *http.Request) {
+	end, err := getOptionalTimeQParam("end", time.Now(), r)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+	start, err := getOptionalTimeQParam("start", end.Add(-24*time.Hour), r)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	response, err := resources.GetUsage(start, end)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	respond(w, response)
+}
@@ -28,8 +28,8 @@ import (
 	"github.com/cortexlabs/cortex/pkg/operator/config"
 	"github.com/cortexlabs/cortex/pkg/operator/endpoints"
 	"github.com/cortexlabs/cortex/pkg/operator/operator"
+	"github.com/cortexlabs/cortex/pkg/operator/resources"
 	"github.com/cortexlabs/cortex/pkg/operator/resources/syncapi"
-	"github.com/cortexlabs/cortex/pkg/types/userconfig"
 	"github.com/gorilla/mux"
 )
 
@@ -47,43 +47,71 @@ func main() {
 		exit.Error(errors.Wrap(err, "init"))
 	}
 
-	deployments, err := config.K8s.ListDeploymentsWithLabelKeys("apiName")
-	if err != nil {
-		exit.Error(errors.Wrap(err, "init"))
-	}
-
-	for _, deployment := range deployments {
-		if userconfig.KindFromString(deployment.Labels["apiKind"]) == userconfig.SyncAPIKind {
-			if err := syncapi.UpdateAutoscalerCron(&deployment); err != nil {
-				exit.Error(errors.Wrap(err, "init"))
+	// the crons (including the APIs' autoscalers) only run on the operator replica which holds the leader lease
+	operator.RunLeaderElection(
+		func() {
+			if err := syncapi.SyncAutoscalerCrons(); err != nil {
+				operator.ErrorHandler("sync autoscaler crons")(err)
 			}
-		}
-	}
-
-	cron.Run(operator.DeleteEvictedPods, operator.ErrorHandler("delete evicted pods"), 12*time.Hour)
-	cron.Run(operator.InstanceTelemetry, operator.ErrorHandler("instance telemetry"), 1*time.Hour)
+		},
+		syncapi.StopAutoscalerCrons,
+	)
+
+	cron.Run(operator.LeaderOnly(syncapi.SyncAutoscalerCrons), operator.ErrorHandler("sync autoscaler crons"), 1*time.Minute)
+	cron.Run(operator.LeaderOnly(operator.DeleteEvictedPods), operator.ErrorHandler("delete evicted pods"), 12*time.Hour)
+	cron.Run(operator.LeaderOnly(operator.InstanceTelemetry), operator.ErrorHandler("instance telemetry"), 1*time.Hour)
+	cron.Run(operator.LeaderOnly(resources.DeleteExpiredAPIs), operator.ErrorHandler("delete expired apis"), 1*time.Minute)
+	cron.Run(operator.LeaderOnly(operator.ExportAccessReports), operator.ErrorHandler("export access reports"), 1*time.Hour)
+	cron.Run(operator.LeaderOnly(resources.MeterUsage), operator.ErrorHandler("meter usage"), resources.MeteringInterval)
+	cron.Run(operator.LeaderOnly(syncapi.DeleteExpiredOffloadedResponses), operator.ErrorHandler("delete expired offloaded responses"), 1*time.Hour)
 
 	router := mux.NewRouter()
 
 	routerWithoutAuth := router.NewRoute().Subrouter()
 	routerWithoutAuth.Use(endpoints.PanicMiddleware)
+	routerWithoutAuth.Use(endpoints.RequestBodyLimitMiddleware)
 	routerWithoutAuth.HandleFunc("/verifycortex", endpoints.VerifyCortex).Methods("GET")
 
 	routerWithAuth := router.NewRoute().Subrouter()
 
 	routerWithAuth.Use(endpoints.PanicMiddleware)
+	routerWithAuth.Use(endpoints.RequestBodyLimitMiddleware)
 	routerWithAuth.Use(endpoints.ClientIDMiddleware)
 	routerWithAuth.Use(endpoints.APIVersionCheckMiddleware)
 	routerWithAuth.Use(endpoints.AuthMiddleware)
+	routerWithAuth.Use(endpoints.AuditMiddleware)
+	routerWithAuth.Use(endpoints.ResponseCacheMiddleware)
 
 	routerWithAuth.HandleFunc("/info", endpoints.Info).Methods("GET")
 	routerWithAuth.HandleFunc("/deploy", endpoints.Deploy).Methods("POST")
 	routerWithAuth.HandleFunc("/refresh/{apiName}", endpoints.Refresh).Methods("POST")
+	routerWithAuth.HandleFunc("/maintenance/{apiName}", endpoints.Maintenance).Methods("POST")
+	routerWithAuth.HandleFunc("/extend/{apiName}", endpoints.Extend).Methods("POST")
+	routerWithAuth.HandleFunc("/loadtest/{apiName}", endpoints.LoadTest).Methods("POST")
+	routerWithAuth.HandleFunc("/loadtest/{apiName}/{jobID}", endpoints.GetLoadTest).Methods("GET")
+	routerWithAuth.HandleFunc("/replay/{apiName}", endpoints.Replay).Methods("POST")
+	routerWithAuth.HandleFunc("/replay/{apiName}/{replayID}", endpoints.GetReplay).Methods("GET")
 	routerWithAuth.HandleFunc("/delete/{apiName}", endpoints.Delete).Methods("DELETE")
+	routerWithAuth.HandleFunc("/previews", endpoints.GetPreviews).Methods("GET")
+	routerWithAuth.HandleFunc("/previews", endpoints.DeletePreview).Methods("DELETE")
 	routerWithAuth.HandleFunc("/get", endpoints.GetAPIs).Methods("GET")
 	routerWithAuth.HandleFunc("/get/{apiName}", endpoints.GetAPI).Methods("GET")
+	routerWithAuth.HandleFunc("/manifest/{apiName}", endpoints.GetManifest).Methods("GET")
 	routerWithAuth.HandleFunc("/logs/{apiName}", endpoints.ReadLogs)
+	routerWithAuth.HandleFunc("/profile/{profileName}", endpoints.Profile).Methods("GET")
+	routerWithAuth.HandleFunc("/access-report", endpoints.AccessReport).Methods("GET")
+	routerWithAuth.HandleFunc("/usage", endpoints.Usage).Methods("GET")
 
 	log.Print("Running on port " + _operatorPortStr)
-	log.Fatal(http.ListenAndServe(":"+_operatorPortStr, router))
+	serverConfig := config.Cluster.OperatorServer
+	server := &http.Server{
+		Addr:              ":" + _operatorPortStr,
+		Handler:           router,
+		ReadHeaderTimeout: time.Duration(serverConfig.ReadHeaderTimeout) * time.Second,
+		ReadTimeout:       time.Duration(serverConfig.ReadTimeout) * time.Second,
+		WriteTimeout:      time.Duration(serverConfig.WriteTimeout) * time.Second,
+		IdleTimeout:       time.Duration(serverConfig.IdleTimeout) * time.Second,
+		MaxHeaderBytes:    int(serverConfig.MaxHeaderSize) * 1024,
+	}
+	log.Fatal(server.ListenAndServe())
 }
@@ -0,0 +1,194 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/random"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+)
+
+const (
+	_auditLogPrefix           = "audit"
+	_accessReportsPrefix      = "cortex-access-reports"
+	_maxParallelAuditLogReads = 20
+)
+
+var _accessReportCSVHeader = []string{"time", "identity", "client_id", "action", "resources", "parameters", "status_code"}
+
+// auditEventKey groups the audit log by day, and the file names start with the event's timestamp so that events can be
+// filtered by time without being downloaded
+func auditEventKey(event schema.AuditEvent) string {
+	return filepath.Join(
+		_auditLogPrefix,
+		event.Time.UTC().Format("2006-01-02"),
+		fmt.Sprintf("%d-%s.json", event.Time.UnixNano(), random.LowercaseString(8)),
+	)
+}
+
+func auditEventTimeFromKey(key string) (time.Time, bool) {
+	fileName := filepath.Base(key)
+	if !strings.HasSuffix(fileName, ".json") {
+		return time.Time{}, false
+	}
+	unixNano, err := strconv.ParseInt(strings.Split(fileName, "-")[0], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, unixNano), true
+}
+
+// auditLogPrefixes returns the prefixes of each day between start and end (inclusive)
+func auditLogPrefixes(start time.Time, end time.Time) []string {
+	var prefixes []string
+	for day := start.UTC().Truncate(24 * time.Hour); !day.After(end); day = day.Add(24 * time.Hour) {
+		prefixes = append(prefixes, filepath.Join(_auditLogPrefix, day.Format("2006-01-02"))+"/")
+	}
+	return prefixes
+}
+
+func RecordAuditEvent(event schema.AuditEvent) error {
+	return config.AWS.UploadJSONToS3(event, config.Cluster.Bucket, auditEventKey(event))
+}
+
+// ListAuditEvents returns the events between start and end (inclusive), sorted by time
+func ListAuditEvents(start time.Time, end time.Time) ([]schema.AuditEvent, error) {
+	var keys []string
+	for _, prefix := range auditLogPrefixes(start, end) {
+		err := config.AWS.S3Iterator(config.Cluster.Bucket, prefix, false, nil, func(object *s3.Object) (bool, error) {
+			if eventTime, ok := auditEventTimeFromKey(*object.Key); ok && !eventTime.Before(start) && !eventTime.After(end) {
+				keys = append(keys, *object.Key)
+			}
+			return true, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	events := make([]schema.AuditEvent, len(keys))
+	group := ParallelGroup("read audit log", _maxParallelAuditLogReads)
+	for i := range keys {
+		i := i
+		group.Go(keys[i], func() error {
+			return config.AWS.ReadJSONFromS3(&events[i], config.Cluster.Bucket, keys[i])
+		})
+	}
+	if err := group.WaitFirstErr(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+
+	return events, nil
+}
+
+// AccessReport renders the events as JSON, or as CSV with one row per event
+func AccessReport(events []schema.AuditEvent, format string) ([]byte, error) {
+	if format == schema.AccessReportFormatJSON {
+		if events == nil {
+			events = []schema.AuditEvent{}
+		}
+		reportBytes, err := json.MarshalIndent(events, "", "  ")
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		return reportBytes, nil
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write(_accessReportCSVHeader)
+
+	for _, event := range events {
+		parameters := make([]string, 0, len(event.Parameters))
+		for key, value := range event.Parameters {
+			parameters = append(parameters, key+"="+value)
+		}
+		sort.Strings(parameters)
+
+		writer.Write([]string{
+			event.Time.UTC().Format(time.RFC3339),
+			event.Identity,
+			event.ClientID,
+			event.Action,
+			strings.Join(event.Resources, ";"),
+			strings.Join(parameters, ";"),
+			strconv.Itoa(event.StatusCode),
+		})
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return buf.Bytes(), nil
+}
+
+func accessReportKey(clusterName string, day time.Time, format string) string {
+	return filepath.Join(_accessReportsPrefix, clusterName, day.Format("2006-01-02")+"."+format)
+}
+
+// ExportAccessReports uploads the previous day's access report to the cluster's access_report_bucket, unless it has
+// already been exported (the CSV report is uploaded last, so its presence indicates that the export completed)
+func ExportAccessReports() error {
+	if config.Cluster.AccessReportBucket == nil {
+		return nil
+	}
+	bucket := *config.Cluster.AccessReportBucket
+
+	day := time.Now().UTC().Truncate(24 * time.Hour).Add(-24 * time.Hour)
+
+	isExported, err := config.AWS.IsS3File(bucket, accessReportKey(config.Cluster.ClusterName, day, schema.AccessReportFormatCSV))
+	if err != nil {
+		return err
+	}
+	if isExported {
+		return nil
+	}
+
+	events, err := ListAuditEvents(day, day.Add(24*time.Hour-time.Nanosecond))
+	if err != nil {
+		return err
+	}
+
+	for _, format := range []string{schema.AccessReportFormatJSON, schema.AccessReportFormatCSV} {
+		report, err := AccessReport(events, format)
+		if err != nil {
+			return err
+		}
+		if err := config.AWS.UploadBytesToS3(report, bucket, accessReportKey(config.Cluster.ClusterName, day, format)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
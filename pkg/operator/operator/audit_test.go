@@ -0,0 +1,81 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditEventKey(t *testing.T) {
+	eventTime := time.Date(2020, 7, 1, 23, 59, 59, 123, time.FixedZone("PDT", -7*60*60))
+	key := auditEventKey(schema.AuditEvent{Time: eventTime})
+
+	require.True(t, strings.HasPrefix(key, "audit/2020-07-02/"), key)
+	parsedTime, ok := auditEventTimeFromKey(key)
+	require.True(t, ok)
+	require.True(t, parsedTime.Equal(eventTime))
+
+	_, ok = auditEventTimeFromKey("audit/2020-07-02/")
+	require.False(t, ok)
+}
+
+func TestAuditLogPrefixes(t *testing.T) {
+	start := time.Date(2020, 6, 30, 12, 0, 0, 0, time.UTC)
+	end := time.Date(2020, 7, 2, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, []string{"audit/2020-06-30/", "audit/2020-07-01/", "audit/2020-07-02/"}, auditLogPrefixes(start, end))
+
+	require.Equal(t, []string{"audit/2020-06-30/"}, auditLogPrefixes(start, start))
+	require.Empty(t, auditLogPrefixes(end, start))
+}
+
+func TestAccessReport(t *testing.T) {
+	events := []schema.AuditEvent{
+		{
+			Time:       time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC),
+			Identity:   "arn:aws:iam::123456789012:user/alice",
+			ClientID:   "client",
+			Action:     "deploy",
+			Resources:  []string{"iris", "text-generator"},
+			Parameters: map[string]string{"force": "true", "configFileName": "cortex.yaml"},
+			StatusCode: 200,
+		},
+		{
+			Time:       time.Date(2020, 7, 1, 13, 0, 0, 0, time.UTC),
+			Identity:   "arn:aws:iam::123456789012:user/bob",
+			Action:     "delete",
+			Resources:  []string{"iris"},
+			StatusCode: 400,
+		},
+	}
+
+	report, err := AccessReport(events, schema.AccessReportFormatCSV)
+	require.NoError(t, err)
+	require.Equal(t, strings.Join([]string{
+		"time,identity,client_id,action,resources,parameters,status_code",
+		"2020-07-01T12:00:00Z,arn:aws:iam::123456789012:user/alice,client,deploy,iris;text-generator,configFileName=cortex.yaml;force=true,200",
+		"2020-07-01T13:00:00Z,arn:aws:iam::123456789012:user/bob,,delete,iris,,400",
+	}, "\n")+"\n", string(report))
+
+	report, err = AccessReport(nil, schema.AccessReportFormatJSON)
+	require.NoError(t, err)
+	require.Equal(t, "[]", string(report))
+}
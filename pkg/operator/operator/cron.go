@@ -28,6 +28,9 @@ import (
 	kmeta "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// Limits the number of concurrent pod deletions (to avoid overloading the k8s API server)
+const _maxParallelPodDeletions = 10
+
 func DeleteEvictedPods() error {
 	failedPods, err := config.K8s.ListPods(&kmeta.ListOptions{
 		FieldSelector: "status.phase=Failed",
@@ -36,20 +39,18 @@ func DeleteEvictedPods() error {
 		return err
 	}
 
-	var errs []error
+	group := BackgroundParallelGroup("delete evicted pods", _maxParallelPodDeletions)
 	for _, pod := range failedPods {
 		if pod.Status.Reason == k8s.ReasonEvicted {
-			_, err := config.K8s.DeletePod(pod.Name)
-			if err != nil {
-				errs = append(errs, err)
-			}
+			podName := pod.Name
+			group.Go(podName, func() error {
+				_, err := config.K8s.DeletePod(podName)
+				return err
+			})
 		}
 	}
 
-	if errors.HasError(errs) {
-		return errors.FirstError(errs...)
-	}
-	return nil
+	return group.WaitFirstErr()
 }
 
 type instanceInfo struct {
@@ -0,0 +1,108 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+	s "github.com/cortexlabs/cortex/pkg/lib/strings"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+	"github.com/cortexlabs/cortex/pkg/types/spec"
+	kcore "k8s.io/api/core/v1"
+)
+
+const (
+	_egressProxyContainerName     = "egress-proxy"
+	_egressProxyInitContainerName = "egress-init"
+	_egressProxyPortStr           = "8890"
+	_egressProxyUID               = int64(1337) // outbound connections are only allowed from this user
+)
+
+// EgressAllowlist returns the domains which the API's pods can connect to: the API's allowlist, plus the AWS endpoints
+// which cortex itself uses from within the pod (the cluster's bucket and CloudWatch)
+func EgressAllowlist(api *spec.API) []string {
+	return append([]string{
+		fmt.Sprintf("%s.s3.amazonaws.com", config.Cluster.Bucket),
+		fmt.Sprintf("%s.s3.%s.amazonaws.com", config.Cluster.Bucket, *config.Cluster.Region),
+		fmt.Sprintf("monitoring.%s.amazonaws.com", *config.Cluster.Region),
+	}, api.Networking.EgressAllowlist...)
+}
+
+// EgressInitContainer must run after all other init containers, since it blocks their outbound connections
+func EgressInitContainer() kcore.Container {
+	return kcore.Container{
+		Name:            _egressProxyInitContainerName,
+		Image:           config.Cluster.ImageEgressProxy,
+		ImagePullPolicy: kcore.PullAlways,
+		Args:            []string{"init"},
+		Env: []kcore.EnvVar{
+			{
+				Name:  "CORTEX_EGRESS_PROXY_UID",
+				Value: s.Int64(_egressProxyUID),
+			},
+		},
+		SecurityContext: &kcore.SecurityContext{
+			RunAsUser: pointer.Int64(0),
+			Capabilities: &kcore.Capabilities{
+				Add: []kcore.Capability{"NET_ADMIN"},
+			},
+		},
+	}
+}
+
+func EgressProxyContainer(api *spec.API) kcore.Container {
+	return kcore.Container{
+		Name:            _egressProxyContainerName,
+		Image:           config.Cluster.ImageEgressProxy,
+		ImagePullPolicy: kcore.PullAlways,
+		Args:            []string{"proxy"},
+		Env: []kcore.EnvVar{
+			{
+				Name:  "CORTEX_EGRESS_ALLOWLIST",
+				Value: strings.Join(EgressAllowlist(api), ","),
+			},
+			{
+				Name:  "CORTEX_EGRESS_PROXY_PORT",
+				Value: _egressProxyPortStr,
+			},
+		},
+		SecurityContext: &kcore.SecurityContext{
+			RunAsUser: pointer.Int64(_egressProxyUID),
+		},
+	}
+}
+
+// the proxy environment variables are respected by pip, conda, boto3, requests, and the aws go sdk
+func egressProxyEnvVars(api *spec.API) []kcore.EnvVar {
+	if api.Networking.EgressAllowlist == nil {
+		return nil
+	}
+
+	proxyURL := "http://localhost:" + _egressProxyPortStr
+	noProxy := "localhost,127.0.0.1"
+
+	return []kcore.EnvVar{
+		{Name: "HTTP_PROXY", Value: proxyURL},
+		{Name: "HTTPS_PROXY", Value: proxyURL},
+		{Name: "NO_PROXY", Value: noProxy},
+		{Name: "http_proxy", Value: proxyURL},
+		{Name: "https_proxy", Value: proxyURL},
+		{Name: "no_proxy", Value: noProxy},
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"github.com/cortexlabs/cortex/pkg/lib/k8s"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+	"github.com/cortexlabs/cortex/pkg/types/spec"
+	"github.com/cortexlabs/cortex/pkg/types/userconfig"
+	kcore "k8s.io/api/core/v1"
+	kresource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// testPodTemplate resembles the pod templates of the API's workloads: the downloader, api, serving, and request monitor
+// containers share the empty dir mount, and the egress proxy and neuron containers are privileged sidecars
+func testPodTemplate() *kcore.PodTemplateSpec {
+	sharedMounts := make([]kcore.VolumeMount, 1, 2)
+	sharedMounts[0] = k8s.EmptyDirVolumeMount(_emptyDirVolumeName, _emptyDirMountPath)
+
+	return &kcore.PodTemplateSpec{
+		Spec: kcore.PodSpec{
+			InitContainers: []kcore.Container{
+				{Name: _downloaderInitContainerName, VolumeMounts: sharedMounts},
+				{Name: _egressProxyInitContainerName},
+			},
+			Containers: []kcore.Container{
+				{
+					Name:            _apiContainerName,
+					VolumeMounts:    sharedMounts,
+					SecurityContext: &kcore.SecurityContext{Privileged: pointer.Bool(true)},
+					Resources: kcore.ResourceRequirements{
+						Requests: kcore.ResourceList{
+							kcore.ResourceCPU:    kresource.MustParse("1"),
+							kcore.ResourceMemory: kresource.MustParse("1Gi"),
+							"nvidia.com/gpu":     kresource.MustParse("1"),
+						},
+						Limits: kcore.ResourceList{
+							"nvidia.com/gpu": kresource.MustParse("1"),
+						},
+					},
+				},
+				{Name: _tfServingContainerName, VolumeMounts: sharedMounts},
+				{
+					Name:         RequestMonitorContainerName,
+					VolumeMounts: sharedMounts,
+					Resources: kcore.ResourceRequirements{
+						Requests: kcore.ResourceList{
+							kcore.ResourceCPU:    _requestMonitorCPURequest,
+							kcore.ResourceMemory: _requestMonitorMemRequest,
+						},
+					},
+				},
+				{Name: _neuronRTDContainerName},
+			},
+			Volumes: []kcore.Volume{k8s.EmptyDirVolume(_emptyDirVolumeName)},
+		},
+	}
+}
+
+func testAPI(predictorType userconfig.PredictorType, compute *userconfig.Compute, security *userconfig.Security) *spec.API {
+	return &spec.API{API: &userconfig.API{
+		Predictor: &userconfig.Predictor{Type: predictorType},
+		Compute:   compute,
+		Security:  security,
+	}}
+}
+
+// testContainer returns the pod template's (init) container with the given name
+func testContainer(podTemplate *kcore.PodTemplateSpec, name string) *kcore.Container {
+	for _, containers := range [][]kcore.Container{podTemplate.Spec.InitContainers, podTemplate.Spec.Containers} {
+		for i := range containers {
+			if containers[i].Name == name {
+				return &containers[i]
+			}
+		}
+	}
+	return nil
+}
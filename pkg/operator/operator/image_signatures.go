@@ -0,0 +1,95 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"github.com/cortexlabs/cortex/pkg/consts"
+	"github.com/cortexlabs/cortex/pkg/lib/cosign"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/regex"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+	"github.com/cortexlabs/cortex/pkg/types/clusterconfig"
+	"github.com/cortexlabs/cortex/pkg/types/status"
+	"github.com/cortexlabs/cortex/pkg/types/userconfig"
+)
+
+// VerifyImageSignatures checks that the API's images are signed by one of the keys in the cluster's image_signature_policy,
+// and pins the images to the digests which were verified (so that their tags can't be moved to unsigned images afterwards)
+func VerifyImageSignatures(apiConfig *userconfig.API) ([]status.ImageVerification, error) {
+	if config.Cluster.ImageSignaturePolicy == nil || apiConfig.Predictor == nil {
+		return nil, nil
+	}
+
+	verifier, err := cosign.NewVerifier(config.Cluster.ImageSignaturePolicy.PublicKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	images := map[string]*string{
+		userconfig.ImageKey: &apiConfig.Predictor.Image,
+	}
+	if apiConfig.Predictor.Type == userconfig.TensorFlowPredictorType {
+		images[userconfig.TensorFlowServingImageKey] = &apiConfig.Predictor.TensorFlowServingImage
+	}
+
+	var verifications []status.ImageVerification
+	for _, key := range []string{userconfig.ImageKey, userconfig.TensorFlowServingImageKey} {
+		image, ok := images[key]
+		if !ok || *image == "" {
+			continue
+		}
+
+		// cortex's own images are not signed with the cluster's keys
+		sourceImage := clusterconfig.UnmirroredImage(*image, config.Cluster.ImageRegistry)
+		if consts.DefaultImagePathsSet.Has(sourceImage) || userconfig.IsRuntimeImage(sourceImage) {
+			continue
+		}
+
+		signature, err := verifier.Verify(*image, registryCredentials(*image))
+		if err != nil {
+			return nil, errors.Wrap(err, apiConfig.Identify(), userconfig.PredictorKey, key)
+		}
+
+		verifications = append(verifications, status.ImageVerification{
+			Image:  *image,
+			Digest: signature.Digest,
+			KeyID:  signature.KeyID,
+		})
+		*image = cosign.PinDigest(*image, signature.Digest)
+	}
+
+	return verifications, nil
+}
+
+// registryCredentials returns the credentials for pulling from the cluster's ECR registry (other registries are accessed anonymously)
+func registryCredentials(image string) *cosign.Credentials {
+	if !regex.IsValidECRURL(image) {
+		return nil
+	}
+
+	ecrAuthConfig, err := config.AWS.GetECRAuthConfig()
+	if err != nil {
+		// fall back to anonymous access; if the registry requires credentials, the verification will fail with a clear error
+		errors.PrintError(err)
+		return nil
+	}
+
+	return &cosign.Credentials{
+		Username: ecrAuthConfig.Username,
+		Password: ecrAuthConfig.AccessToken,
+	}
+}
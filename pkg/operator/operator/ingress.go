@@ -0,0 +1,147 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cortexlabs/cortex/pkg/lib/k8s"
+	"github.com/cortexlabs/cortex/pkg/lib/urls"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+	"github.com/cortexlabs/cortex/pkg/types/clusterconfig"
+	kextensions "k8s.io/api/extensions/v1beta1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// the istio gateway which routes requests to APIs (via their virtual services), regardless of the cluster's api_ingress
+const _apisGatewayServiceName = "ingressgateway-apis"
+
+// ApplyAPIIngress exposes an API's endpoint through the cluster's api_ingress (an ingress, or an HTTPRoute attached to the
+// gateway_api_parent). Requests are forwarded to the istio gateway, so that the API's virtual service still handles
+// routing (e.g. traffic splitting and maintenance mode). The istio api_ingress exposes the gateway through its own load
+// balancer, so nothing is created for it.
+func ApplyAPIIngress(apiName string, endpoint string) error {
+	switch config.Cluster.APIIngress {
+	case clusterconfig.IstioAPIIngress:
+		return nil
+	case clusterconfig.GatewayAPIAPIIngress:
+		_, err := config.K8sIstio.ApplyHTTPRoute(apiHTTPRouteSpec(apiName, endpoint))
+		return err
+	default:
+		_, err := config.K8sIstio.ApplyIngress(apiIngressSpec(apiName, endpoint))
+		return err
+	}
+}
+
+func DeleteAPIIngress(apiName string) error {
+	switch config.Cluster.APIIngress {
+	case clusterconfig.IstioAPIIngress:
+		return nil
+	case clusterconfig.GatewayAPIAPIIngress:
+		_, err := config.K8sIstio.DeleteHTTPRoute(K8sName(apiName))
+		return err
+	default:
+		_, err := config.K8sIstio.DeleteIngress(K8sName(apiName))
+		return err
+	}
+}
+
+func apiHTTPRouteSpec(apiName string, endpoint string) *kunstructured.Unstructured {
+	return k8s.HTTPRoute(&k8s.HTTPRouteSpec{
+		Name:            K8sName(apiName),
+		ParentName:      config.Cluster.GatewayAPIParent.Name,
+		ParentNamespace: config.Cluster.GatewayAPIParent.Namespace,
+		ServiceName:     _apisGatewayServiceName,
+		ServicePort:     80,
+		Path:            urls.CanonicalizeEndpoint(endpoint),
+		Labels: map[string]string{
+			"apiName": apiName,
+		},
+	})
+}
+
+func apiIngressSpec(apiName string, endpoint string) *kextensions.Ingress {
+	annotations := map[string]string{}
+
+	if config.Cluster.APIIngress == clusterconfig.ALBAPIIngress {
+		// all of the cluster's ingresses share a single ALB
+		annotations["alb.ingress.kubernetes.io/group.name"] = config.Cluster.ClusterName
+		annotations["alb.ingress.kubernetes.io/scheme"] = config.Cluster.APILoadBalancerScheme.String()
+		annotations["alb.ingress.kubernetes.io/target-type"] = "instance"
+		annotations["alb.ingress.kubernetes.io/tags"] = albTags(config.Cluster.Tags)
+		// the health check requests "/" from the istio gateway, which responds with 404 since no API is deployed there
+		annotations["alb.ingress.kubernetes.io/success-codes"] = "200-404"
+		if config.Cluster.SSLCertificateARN != nil {
+			annotations["alb.ingress.kubernetes.io/certificate-arn"] = *config.Cluster.SSLCertificateARN
+			annotations["alb.ingress.kubernetes.io/listen-ports"] = `[{"HTTP": 80}, {"HTTPS": 443}]`
+		}
+	}
+
+	if config.Cluster.APIIngress == clusterconfig.NGINXAPIIngress {
+		// match the network load balancer used by the istio api_ingress, which doesn't limit request sizes
+		annotations["nginx.ingress.kubernetes.io/proxy-body-size"] = "0"
+	}
+
+	return k8s.Ingress(&k8s.IngressSpec{
+		Name:         K8sName(apiName),
+		IngressClass: config.Cluster.APIIngress.String(),
+		ServiceName:  _apisGatewayServiceName,
+		ServicePort:  80,
+		Path:         urls.CanonicalizeEndpoint(endpoint),
+		Annotations:  annotations,
+		Labels: map[string]string{
+			"apiName": apiName,
+		},
+	})
+}
+
+func albTags(tags map[string]string) string {
+	tagStrs := make([]string, 0, len(tags))
+	for key, value := range tags {
+		tagStrs = append(tagStrs, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(tagStrs)
+	return strings.Join(tagStrs, ",")
+}
+
+// apiIngressLoadBalancerURL returns the URL of the load balancer which the api_ingress controller provisioned for the
+// cluster's ingresses (they all share one load balancer), or of the gateway which the APIs' routes are attached to
+func apiIngressLoadBalancerURL() (string, error) {
+	if config.Cluster.APIIngress == clusterconfig.GatewayAPIAPIIngress {
+		address, err := config.K8sIstio.GetGatewayAddress(config.Cluster.GatewayAPIParent.Namespace, config.Cluster.GatewayAPIParent.Name)
+		if err != nil {
+			return "", err
+		}
+		if address == "" {
+			return "", ErrorLoadBalancerInitializing()
+		}
+		return "http://" + address, nil
+	}
+
+	ingresses, err := config.K8sIstio.ListIngressesWithLabelKeys("apiName")
+	if err != nil {
+		return "", err
+	}
+	for _, ingress := range ingresses {
+		if len(ingress.Status.LoadBalancer.Ingress) > 0 && ingress.Status.LoadBalancer.Ingress[0].Hostname != "" {
+			return "http://" + ingress.Status.LoadBalancer.Ingress[0].Hostname, nil
+		}
+	}
+	return "", ErrorLoadBalancerInitializing()
+}
@@ -21,8 +21,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"net/http"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/cortexlabs/cortex/pkg/consts"
 	"github.com/cortexlabs/cortex/pkg/lib/aws"
@@ -32,6 +34,7 @@ import (
 	s "github.com/cortexlabs/cortex/pkg/lib/strings"
 	"github.com/cortexlabs/cortex/pkg/operator/config"
 	"github.com/cortexlabs/cortex/pkg/types"
+	"github.com/cortexlabs/cortex/pkg/types/clusterconfig"
 	"github.com/cortexlabs/cortex/pkg/types/spec"
 	"github.com/cortexlabs/cortex/pkg/types/userconfig"
 	istioclientnetworking "istio.io/client-go/pkg/apis/networking/v1alpha3"
@@ -43,6 +46,8 @@ import (
 const (
 	DefaultPortInt32 = int32(8888)
 	DefaultPortStr   = "8888"
+
+	RequestMonitorContainerName = "request-monitor"
 )
 
 const (
@@ -62,7 +67,7 @@ const (
 	_apiLivenessFile                               = "/mnt/workspace/api_liveness.txt"
 	_neuronRTDSocket                               = "/sock/neuron.sock"
 	_apiLivenessStalePeriod                        = 7 // seconds (there is a 2-second buffer to be safe)
-	_requestMonitorReadinessFile                   = "/request_monitor_ready.txt"
+	_requestMonitorReadinessFile                   = "/mnt/request_monitor_ready.txt"
 )
 
 var (
@@ -384,6 +389,10 @@ func getEnvVars(api *spec.API, container string) []kcore.EnvVar {
 				Name:  "CORTEX_SERVING_PORT",
 				Value: DefaultPortStr,
 			},
+			kcore.EnvVar{
+				Name:  "CORTEX_METRICS_DIMENSIONS",
+				Value: metricsDimensionsJSON(),
+			},
 			kcore.EnvVar{
 				Name:  "CORTEX_API_SPEC",
 				Value: aws.S3Path(config.Cluster.Bucket, api.Key),
@@ -407,6 +416,22 @@ func getEnvVars(api *spec.API, container string) []kcore.EnvVar {
 			Value: cortexPythonPath,
 		})
 
+		envVars = append(envVars, egressProxyEnvVars(api)...)
+
+		if api.Networking.MaxPayloadSize != nil {
+			envVars = append(envVars, kcore.EnvVar{
+				Name:  "CORTEX_MAX_PAYLOAD_SIZE",
+				Value: s.Int64(api.Networking.MaxPayloadSize.Value()),
+			})
+		}
+
+		if api.Networking.MaxResponseSize != nil {
+			envVars = append(envVars, kcore.EnvVar{
+				Name:  "CORTEX_MAX_RESPONSE_SIZE",
+				Value: s.Int64(api.Networking.MaxResponseSize.Value()),
+			})
+		}
+
 		if api.Predictor.Type == userconfig.ONNXPredictorType {
 			envVars = append(envVars,
 				kcore.EnvVar{
@@ -677,12 +702,21 @@ func neuronRuntimeDaemonContainer(api *spec.API, volumeMounts []kcore.VolumeMoun
 	}
 }
 
+// predictorContainerName returns the name of the container which runs the API's model
+func predictorContainerName(api *spec.API) string {
+	if api.Predictor.Type == userconfig.TensorFlowPredictorType {
+		return _tfServingContainerName
+	}
+	return _apiContainerName
+}
+
 func RequestMonitorContainer(api *spec.API) kcore.Container {
 	return kcore.Container{
-		Name:            "request-monitor",
+		Name:            RequestMonitorContainerName,
 		Image:           config.Cluster.ImageRequestMonitor,
 		ImagePullPolicy: kcore.PullAlways,
-		Args:            []string{api.Name, config.Cluster.ClusterName},
+		Args:            append([]string{api.Name, config.Cluster.MetricsNamespace}, metricsDimensionArgs()...),
+		Env:             egressProxyEnvVars(api),
 		EnvFrom:         BaseEnvVars,
 		VolumeMounts:    DefaultVolumeMounts,
 		ReadinessProbe:  FileExistsProbe(_requestMonitorReadinessFile),
@@ -805,13 +839,26 @@ var Tolerations = []kcore.Toleration{
 	},
 }
 
+// DeprecationResponseHeaders returns the headers which are set on responses from a deprecated API
+// (https://tools.ietf.org/html/rfc8594 and https://tools.ietf.org/html/draft-dalal-deprecation-header)
+func DeprecationResponseHeaders(sunsetDate time.Time) map[string]string {
+	return map[string]string{
+		"Deprecation": "true",
+		"Sunset":      sunsetDate.Format(http.TimeFormat),
+	}
+}
+
 func K8sName(apiName string) string {
 	return "api-" + apiName
 }
 
 // APILoadBalancerURL returns http endpoint of cluster ingress elb
 func APILoadBalancerURL() (string, error) {
-	service, err := config.K8sIstio.GetService("ingressgateway-apis")
+	if config.Cluster.APIIngress != clusterconfig.IstioAPIIngress {
+		return apiIngressLoadBalancerURL()
+	}
+
+	service, err := config.K8sIstio.GetService(_apisGatewayServiceName)
 	if err != nil {
 		return "", err
 	}
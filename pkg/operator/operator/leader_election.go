@@ -0,0 +1,68 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"context"
+	"log"
+	"os"
+	"sync/atomic"
+
+	"github.com/cortexlabs/cortex/pkg/lib/random"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+)
+
+const _leaderLeaseName = "operator-leader"
+
+var _isLeader int32 // 1 if this replica holds the leader lease
+
+// IsLeader returns whether this operator replica is responsible for running the operator's crons
+func IsLeader() bool {
+	return atomic.LoadInt32(&_isLeader) == 1
+}
+
+// LeaderOnly wraps a cron so that it's skipped on replicas which don't hold the leader lease, so that running multiple
+// operator replicas doesn't cause the same work to be done more than once
+func LeaderOnly(f func() error) func() error {
+	return func() error {
+		if !IsLeader() {
+			return nil
+		}
+		return f()
+	}
+}
+
+// RunLeaderElection campaigns for the leader lease in the background
+func RunLeaderElection(onStartedLeading func(), onStoppedLeading func()) {
+	identity, err := os.Hostname() // the pod name
+	if err != nil {
+		identity = "operator-" + random.LowercaseString(8)
+	}
+
+	go config.K8s.RunLeaderElection(context.Background(), _leaderLeaseName, identity,
+		func() {
+			log.Printf("%s acquired the leader lease", identity)
+			atomic.StoreInt32(&_isLeader, 1)
+			onStartedLeading()
+		},
+		func() {
+			log.Printf("%s lost the leader lease", identity)
+			atomic.StoreInt32(&_isLeader, 0)
+			onStoppedLeading()
+		},
+	)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"github.com/cortexlabs/cortex/pkg/lib/k8s"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
+	"github.com/cortexlabs/cortex/pkg/types/spec"
+	kcore "k8s.io/api/core/v1"
+	kresource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	_shmVolumeName       = "shm"
+	_shmMountPath        = "/dev/shm"
+	_hugePagesVolumeName = "hugepages"
+	_hugePagesMountPath  = "/dev/hugepages"
+	_hugePagesResource   = kcore.ResourceName("hugepages-2Mi")
+)
+
+// these containers run user code or model servers, which may use shared memory (e.g. PyTorch's DataLoader workers)
+var _shmContainers = strset.New(_apiContainerName, _tfServingContainerName)
+
+// ApplySharedMemory mounts a memory-backed volume of the API's shm size at /dev/shm (the container runtime's default is 64Mi)
+func ApplySharedMemory(api *spec.API, podTemplate *kcore.PodTemplateSpec) {
+	if api.Compute == nil || api.Compute.Shm == nil {
+		return
+	}
+
+	podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, k8s.MemoryEmptyDirVolume(_shmVolumeName, k8s.QuantityPtr(api.Compute.Shm.Quantity.DeepCopy())))
+
+	for i := range podTemplate.Spec.Containers {
+		container := &podTemplate.Spec.Containers[i]
+		if _shmContainers.Has(container.Name) {
+			appendVolumeMount(container, k8s.EmptyDirVolumeMount(_shmVolumeName, _shmMountPath))
+		}
+	}
+}
+
+// ApplyHugePages requests the API's huge pages for the container which runs the API's model, and mounts them at /dev/hugepages
+func ApplyHugePages(api *spec.API, podTemplate *kcore.PodTemplateSpec) {
+	if api.Compute == nil || api.Compute.HugePages == nil {
+		return
+	}
+
+	podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, k8s.HugePagesEmptyDirVolume(_hugePagesVolumeName))
+
+	for i := range podTemplate.Spec.Containers {
+		container := &podTemplate.Spec.Containers[i]
+		if container.Name != predictorContainerName(api) {
+			continue
+		}
+
+		// kubernetes requires huge pages requests to be equal to their limits
+		if container.Resources.Requests == nil {
+			container.Resources.Requests = kcore.ResourceList{}
+		}
+		if container.Resources.Limits == nil {
+			container.Resources.Limits = kcore.ResourceList{}
+		}
+		container.Resources.Requests[_hugePagesResource] = *kresource.NewQuantity(api.Compute.HugePages.Value(), kresource.BinarySI)
+		container.Resources.Limits[_hugePagesResource] = *kresource.NewQuantity(api.Compute.HugePages.Value(), kresource.BinarySI)
+
+		appendVolumeMount(container, k8s.EmptyDirVolumeMount(_hugePagesVolumeName, _hugePagesMountPath))
+	}
+}
+
+func appendVolumeMount(container *kcore.Container, volumeMount kcore.VolumeMount) {
+	// copy the mounts since they may be shared with other containers
+	volumeMounts := make([]kcore.VolumeMount, 0, len(container.VolumeMounts)+1)
+	volumeMounts = append(volumeMounts, container.VolumeMounts...)
+	container.VolumeMounts = append(volumeMounts, volumeMount)
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"testing"
+
+	"github.com/cortexlabs/cortex/pkg/lib/k8s"
+	"github.com/cortexlabs/cortex/pkg/types/userconfig"
+	"github.com/stretchr/testify/require"
+	kcore "k8s.io/api/core/v1"
+	kresource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestApplySharedMemory(t *testing.T) {
+	podTemplate := testPodTemplate()
+	ApplySharedMemory(testAPI(userconfig.TensorFlowPredictorType, &userconfig.Compute{}, nil), podTemplate)
+	require.Equal(t, testPodTemplate(), podTemplate)
+
+	shm := k8s.WrapQuantity(kresource.MustParse("1Gi"))
+	ApplySharedMemory(testAPI(userconfig.TensorFlowPredictorType, &userconfig.Compute{Shm: shm}, nil), podTemplate)
+
+	require.Len(t, podTemplate.Spec.Volumes, 2)
+	require.Equal(t, kcore.StorageMediumMemory, podTemplate.Spec.Volumes[1].EmptyDir.Medium)
+	require.Equal(t, 0, podTemplate.Spec.Volumes[1].EmptyDir.SizeLimit.Cmp(shm.Quantity))
+
+	require.Equal(t, _shmMountPath, testContainer(podTemplate, _apiContainerName).VolumeMounts[1].MountPath)
+	require.Equal(t, _shmMountPath, testContainer(podTemplate, _tfServingContainerName).VolumeMounts[1].MountPath)
+	require.Len(t, testContainer(podTemplate, RequestMonitorContainerName).VolumeMounts, 1)
+}
+
+func TestApplyHugePages(t *testing.T) {
+	podTemplate := testPodTemplate()
+	hugePages := k8s.WrapQuantity(kresource.MustParse("512Mi"))
+	ApplyHugePages(testAPI(userconfig.TensorFlowPredictorType, &userconfig.Compute{HugePages: hugePages}, nil), podTemplate)
+
+	require.Len(t, podTemplate.Spec.Volumes, 2)
+	require.Equal(t, kcore.StorageMediumHugePages, podTemplate.Spec.Volumes[1].EmptyDir.Medium)
+
+	servingContainer := testContainer(podTemplate, _tfServingContainerName)
+	requested := servingContainer.Resources.Requests[_hugePagesResource]
+	limit := servingContainer.Resources.Limits[_hugePagesResource]
+	require.Equal(t, int64(512*1024*1024), requested.Value())
+	require.Equal(t, int64(512*1024*1024), limit.Value())
+	require.Equal(t, _hugePagesMountPath, servingContainer.VolumeMounts[1].MountPath)
+
+	apiContainer := testContainer(podTemplate, _apiContainerName)
+	require.NotContains(t, apiContainer.Resources.Requests, _hugePagesResource)
+	require.Len(t, apiContainer.VolumeMounts, 1)
+}
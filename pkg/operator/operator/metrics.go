@@ -0,0 +1,63 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+)
+
+// MetricsDimensions returns the custom metrics dimensions from the cluster config (sorted by name),
+// which are added to every metric published by an API
+func MetricsDimensions() []*cloudwatch.Dimension {
+	dimensions := make([]*cloudwatch.Dimension, 0, len(config.Cluster.MetricsDimensions))
+	for _, name := range metricsDimensionNames() {
+		dimensions = append(dimensions, &cloudwatch.Dimension{
+			Name:  aws.String(name),
+			Value: aws.String(config.Cluster.MetricsDimensions[name]),
+		})
+	}
+	return dimensions
+}
+
+// returns the custom metrics dimensions in the format expected by the request monitor (name=value)
+func metricsDimensionArgs() []string {
+	args := make([]string, 0, len(config.Cluster.MetricsDimensions))
+	for _, name := range metricsDimensionNames() {
+		args = append(args, name+"="+config.Cluster.MetricsDimensions[name])
+	}
+	return args
+}
+
+// returns the custom metrics dimensions as a json object (name -> value), which is read by the api container
+func metricsDimensionsJSON() string {
+	dimensionsBytes, _ := json.Marshal(config.Cluster.MetricsDimensions)
+	return string(dimensionsBytes)
+}
+
+func metricsDimensionNames() []string {
+	names := make([]string, 0, len(config.Cluster.MetricsDimensions))
+	for name := range config.Cluster.MetricsDimensions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
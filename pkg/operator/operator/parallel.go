@@ -0,0 +1,75 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/parallel"
+	"github.com/cortexlabs/cortex/pkg/lib/telemetry"
+)
+
+// Limits the number of concurrent requests made to AWS when fanning out across all APIs (to avoid throttling)
+const MaxParallelAWSRequests = 20
+
+// ParallelGroup returns a parallel.Group which runs at most limit tasks at once. Panicked tasks are reported to telemetry as errors
+func ParallelGroup(groupName string, limit int) *parallel.Group {
+	group := parallel.NewGroup(limit)
+	group.OnTaskDone = func(taskName string, duration time.Duration, err error) {
+		if errors.GetKind(err) == parallel.ErrTaskPanic {
+			telemetry.Error(errors.Wrap(err, groupName))
+		}
+	}
+	return group
+}
+
+// BackgroundParallelGroup is a ParallelGroup which also reports aggregate statistics about its tasks in an event once the
+// group is waited on. It should only be used by crons (not while serving requests), and task names are not reported since
+// they may identify users' resources
+func BackgroundParallelGroup(groupName string, limit int) *parallel.Group {
+	group := ParallelGroup(groupName, limit)
+	group.OnWait = func(results []parallel.TaskResult) {
+		if len(results) == 0 {
+			return
+		}
+		telemetry.Event("operator.parallel_group", parallelGroupProperties(groupName, limit, results))
+	}
+	return group
+}
+
+func parallelGroupProperties(groupName string, limit int, results []parallel.TaskResult) map[string]interface{} {
+	var maxDuration time.Duration
+	numErrors := 0
+
+	for _, result := range results {
+		if result.Duration > maxDuration {
+			maxDuration = result.Duration
+		}
+		if result.Err != nil {
+			numErrors++
+		}
+	}
+
+	return map[string]interface{}{
+		"group":           groupName,
+		"limit":           limit,
+		"num_tasks":       len(results),
+		"num_errors":      numErrors,
+		"max_duration_ms": maxDuration.Milliseconds(),
+	}
+}
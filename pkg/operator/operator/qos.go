@@ -0,0 +1,107 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"github.com/cortexlabs/cortex/pkg/types/spec"
+	"github.com/cortexlabs/cortex/pkg/types/userconfig"
+	kcore "k8s.io/api/core/v1"
+	kresource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// every container in a guaranteed pod must set cpu and memory; these are used for containers which don't request any (e.g. init containers)
+var (
+	_guaranteedMinCPU = kresource.MustParse("50m")
+	_guaranteedMinMem = kresource.MustParse("64Mi")
+)
+
+// ApplyQoS sets the cpu and memory requests and limits of the pod template's containers to match the API's qos class
+func ApplyQoS(api *spec.API, podTemplate *kcore.PodTemplateSpec) {
+	if api.Compute == nil {
+		return
+	}
+
+	for i := range podTemplate.Spec.InitContainers {
+		applyContainerQoS(api.Compute, &podTemplate.Spec.InitContainers[i])
+	}
+	for i := range podTemplate.Spec.Containers {
+		applyContainerQoS(api.Compute, &podTemplate.Spec.Containers[i])
+	}
+
+	if api.Compute.ExclusiveCPUs {
+		applyExclusiveCPUs(api, podTemplate)
+	}
+}
+
+// the kubelet's static cpu manager policy gives exclusive cores to containers in guaranteed pods which request whole cpus,
+// so the container which runs the predictor is given all of the API's cpu (the other containers run on the shared cores)
+func applyExclusiveCPUs(api *spec.API, podTemplate *kcore.PodTemplateSpec) {
+	for i := range podTemplate.Spec.Containers {
+		container := &podTemplate.Spec.Containers[i]
+		if container.Name != predictorContainerName(api) {
+			continue
+		}
+		container.Resources.Requests[kcore.ResourceCPU] = api.Compute.CPU.Quantity.DeepCopy()
+		container.Resources.Limits[kcore.ResourceCPU] = api.Compute.CPU.Quantity.DeepCopy()
+	}
+}
+
+func applyContainerQoS(compute *userconfig.Compute, container *kcore.Container) {
+	resources := &container.Resources
+
+	switch compute.QoS {
+	case userconfig.GuaranteedQoSClass:
+		if resources.Requests == nil {
+			resources.Requests = kcore.ResourceList{}
+		}
+		if resources.Limits == nil {
+			resources.Limits = kcore.ResourceList{}
+		}
+		for resourceName, minimum := range map[kcore.ResourceName]kresource.Quantity{
+			kcore.ResourceCPU:    _guaranteedMinCPU,
+			kcore.ResourceMemory: _guaranteedMinMem,
+		} {
+			quantity := minimum.DeepCopy()
+			if request, ok := resources.Requests[resourceName]; ok && request.Cmp(quantity) > 0 {
+				quantity = request.DeepCopy()
+			}
+			resources.Requests[resourceName] = quantity
+			resources.Limits[resourceName] = quantity.DeepCopy()
+		}
+
+	case userconfig.BestEffortQoSClass:
+		for _, resourceName := range []kcore.ResourceName{kcore.ResourceCPU, kcore.ResourceMemory} {
+			delete(resources.Requests, resourceName)
+			delete(resources.Limits, resourceName)
+		}
+
+	case userconfig.BurstableQoSClass:
+		if compute.LimitRatio == nil {
+			return
+		}
+		for _, resourceName := range []kcore.ResourceName{kcore.ResourceCPU, kcore.ResourceMemory} {
+			request, ok := resources.Requests[resourceName]
+			if !ok {
+				continue
+			}
+			if resources.Limits == nil {
+				resources.Limits = kcore.ResourceList{}
+			}
+			resources.Limits[resourceName] = *kresource.NewMilliQuantity(int64(float64(request.MilliValue())**compute.LimitRatio), request.Format)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"testing"
+
+	"github.com/cortexlabs/cortex/pkg/lib/k8s"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+	"github.com/cortexlabs/cortex/pkg/types/spec"
+	"github.com/cortexlabs/cortex/pkg/types/userconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func qosTestAPI(qos userconfig.QoSClass, limitRatio *float64) *spec.API {
+	return testAPI(userconfig.PythonPredictorType, &userconfig.Compute{QoS: qos, LimitRatio: limitRatio}, nil)
+}
+
+func TestApplyQoSBurstable(t *testing.T) {
+	podTemplate := testPodTemplate()
+	ApplyQoS(qosTestAPI(userconfig.BurstableQoSClass, nil), podTemplate)
+	require.Equal(t, testPodTemplate(), podTemplate)
+}
+
+func TestApplyQoSLimitRatio(t *testing.T) {
+	podTemplate := testPodTemplate()
+	ApplyQoS(qosTestAPI(userconfig.BurstableQoSClass, pointer.Float64(1.5)), podTemplate)
+
+	limits := testContainer(podTemplate, _apiContainerName).Resources.Limits
+	require.Equal(t, int64(1500), limits.Cpu().MilliValue())
+	require.Equal(t, int64(1536*1024*1024), limits.Memory().Value())
+	gpuLimit := limits["nvidia.com/gpu"]
+	require.Equal(t, int64(1), gpuLimit.Value())
+	require.Nil(t, testContainer(podTemplate, _downloaderInitContainerName).Resources.Limits)
+}
+
+func TestApplyQoSGuaranteed(t *testing.T) {
+	podTemplate := testPodTemplate()
+	ApplyQoS(qosTestAPI(userconfig.GuaranteedQoSClass, nil), podTemplate)
+
+	for _, name := range []string{_downloaderInitContainerName, _apiContainerName} {
+		resources := testContainer(podTemplate, name).Resources
+		require.Equal(t, 0, resources.Requests.Cpu().Cmp(*resources.Limits.Cpu()))
+		require.Equal(t, 0, resources.Requests.Memory().Cmp(*resources.Limits.Memory()))
+	}
+
+	require.Equal(t, 0, testContainer(podTemplate, _downloaderInitContainerName).Resources.Limits.Cpu().Cmp(_guaranteedMinCPU))
+	require.Equal(t, int64(1000), testContainer(podTemplate, _apiContainerName).Resources.Limits.Cpu().MilliValue())
+}
+
+func TestApplyQoSBestEffort(t *testing.T) {
+	podTemplate := testPodTemplate()
+	ApplyQoS(qosTestAPI(userconfig.BestEffortQoSClass, nil), podTemplate)
+
+	resources := testContainer(podTemplate, _apiContainerName).Resources
+	require.Len(t, resources.Requests, 1)
+	require.Len(t, resources.Limits, 1)
+	gpuRequest := resources.Requests["nvidia.com/gpu"]
+	require.Equal(t, int64(1), gpuRequest.Value())
+}
+
+func TestApplyQoSExclusiveCPUs(t *testing.T) {
+	podTemplate := testPodTemplate()
+
+	api := qosTestAPI(userconfig.GuaranteedQoSClass, nil)
+	api.Compute.CPU = k8s.NewMilliQuantity(2000)
+	api.Compute.ExclusiveCPUs = true
+	ApplyQoS(api, podTemplate)
+
+	apiResources := testContainer(podTemplate, _apiContainerName).Resources
+	require.Equal(t, int64(2000), apiResources.Requests.Cpu().MilliValue())
+	require.Equal(t, int64(2000), apiResources.Limits.Cpu().MilliValue())
+
+	requestMonitorResources := testContainer(podTemplate, RequestMonitorContainerName).Resources
+	require.Equal(t, 0, requestMonitorResources.Limits.Cpu().Cmp(_guaranteedMinCPU))
+}
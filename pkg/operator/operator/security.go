@@ -0,0 +1,111 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"github.com/cortexlabs/cortex/pkg/lib/k8s"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
+	"github.com/cortexlabs/cortex/pkg/types/spec"
+	kcore "k8s.io/api/core/v1"
+)
+
+const (
+	_nonRootUID    = int64(1000)
+	_tmpVolumeName = "tmp"
+	_tmpMountPath  = "/tmp"
+)
+
+// these containers require elevated privileges to function, and don't run user code
+var _privilegedContainers = strset.New(_egressProxyInitContainerName, _egressProxyContainerName, _neuronRTDContainerName)
+
+// ApplySecurity applies the API's security settings (after the cluster's api_security_policy has been applied) to the pod template
+func ApplySecurity(api *spec.API, podTemplate *kcore.PodTemplateSpec) {
+	if api.Security == nil {
+		return
+	}
+
+	if podTemplate.Annotations == nil {
+		podTemplate.Annotations = map[string]string{}
+	}
+
+	if api.Security.SeccompProfile != nil {
+		podTemplate.Annotations[k8s.SeccompPodAnnotationKey] = *api.Security.SeccompProfile
+	}
+
+	if api.Security.AppArmorProfile != nil {
+		for _, containers := range [][]kcore.Container{podTemplate.Spec.InitContainers, podTemplate.Spec.Containers} {
+			for _, container := range containers {
+				if !_privilegedContainers.Has(container.Name) {
+					podTemplate.Annotations[k8s.AppArmorContainerAnnotationKey(container.Name)] = *api.Security.AppArmorProfile
+				}
+			}
+		}
+	}
+
+	runAsNonRoot := api.Security.RunAsNonRoot != nil && *api.Security.RunAsNonRoot
+	readOnlyRootFilesystem := api.Security.ReadOnlyRootFilesystem != nil && *api.Security.ReadOnlyRootFilesystem
+	restricted := api.Security.PodSecurityStandard != nil && *api.Security.PodSecurityStandard == k8s.PodSecurityStandardRestricted
+
+	if !runAsNonRoot && !readOnlyRootFilesystem && !restricted {
+		return
+	}
+
+	if readOnlyRootFilesystem {
+		podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, k8s.EmptyDirVolume(_tmpVolumeName))
+	}
+
+	for i := range podTemplate.Spec.InitContainers {
+		applyContainerSecurity(&podTemplate.Spec.InitContainers[i], runAsNonRoot, readOnlyRootFilesystem, restricted)
+	}
+	for i := range podTemplate.Spec.Containers {
+		applyContainerSecurity(&podTemplate.Spec.Containers[i], runAsNonRoot, readOnlyRootFilesystem, restricted)
+	}
+}
+
+func applyContainerSecurity(container *kcore.Container, runAsNonRoot bool, readOnlyRootFilesystem bool, restricted bool) {
+	if _privilegedContainers.Has(container.Name) {
+		return
+	}
+
+	if container.SecurityContext == nil {
+		container.SecurityContext = &kcore.SecurityContext{}
+	}
+
+	if runAsNonRoot {
+		// the api containers are privileged by default only so that they can tune the network stack via sysctl
+		container.SecurityContext.Privileged = pointer.Bool(false)
+		container.SecurityContext.AllowPrivilegeEscalation = pointer.Bool(false)
+		container.SecurityContext.RunAsNonRoot = pointer.Bool(true)
+		container.SecurityContext.RunAsUser = pointer.Int64(_nonRootUID)
+	}
+
+	// the restricted pod security standard also requires all capabilities to be dropped (runAsNonRoot and the seccomp
+	// profile are set during validation)
+	if restricted {
+		container.SecurityContext.Privileged = pointer.Bool(false)
+		container.SecurityContext.AllowPrivilegeEscalation = pointer.Bool(false)
+		container.SecurityContext.Capabilities = &kcore.Capabilities{
+			Drop: []kcore.Capability{"ALL"},
+		}
+	}
+
+	if readOnlyRootFilesystem {
+		container.SecurityContext.ReadOnlyRootFilesystem = pointer.Bool(true)
+		appendVolumeMount(container, k8s.EmptyDirVolumeMount(_tmpVolumeName, _tmpMountPath))
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"testing"
+
+	"github.com/cortexlabs/cortex/pkg/lib/k8s"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+	"github.com/cortexlabs/cortex/pkg/types/userconfig"
+	"github.com/stretchr/testify/require"
+	kcore "k8s.io/api/core/v1"
+)
+
+func TestApplySecurityNil(t *testing.T) {
+	podTemplate := testPodTemplate()
+	ApplySecurity(testAPI(userconfig.PythonPredictorType, nil, nil), podTemplate)
+	require.Equal(t, testPodTemplate(), podTemplate)
+}
+
+func TestApplySecurityNonRoot(t *testing.T) {
+	podTemplate := testPodTemplate()
+	ApplySecurity(testAPI(userconfig.PythonPredictorType, nil, &userconfig.Security{RunAsNonRoot: pointer.Bool(true)}), podTemplate)
+
+	for _, name := range []string{_downloaderInitContainerName, _apiContainerName} {
+		container := testContainer(podTemplate, name)
+		require.Equal(t, pointer.Bool(true), container.SecurityContext.RunAsNonRoot)
+		require.Equal(t, pointer.Int64(_nonRootUID), container.SecurityContext.RunAsUser)
+		require.Equal(t, pointer.Bool(false), container.SecurityContext.Privileged)
+		require.Equal(t, pointer.Bool(false), container.SecurityContext.AllowPrivilegeEscalation)
+		require.Nil(t, container.SecurityContext.ReadOnlyRootFilesystem)
+		require.Len(t, container.VolumeMounts, 1)
+	}
+
+	require.Nil(t, testContainer(podTemplate, _egressProxyInitContainerName).SecurityContext)
+	require.Nil(t, testContainer(podTemplate, _neuronRTDContainerName).SecurityContext)
+	require.Len(t, podTemplate.Spec.Volumes, 1)
+}
+
+func TestApplySecurityReadOnlyRootFilesystem(t *testing.T) {
+	podTemplate := testPodTemplate()
+	ApplySecurity(testAPI(userconfig.PythonPredictorType, nil, &userconfig.Security{ReadOnlyRootFilesystem: pointer.Bool(true)}), podTemplate)
+
+	require.Len(t, podTemplate.Spec.Volumes, 2)
+	require.Equal(t, _tmpVolumeName, podTemplate.Spec.Volumes[1].Name)
+
+	for _, name := range []string{_downloaderInitContainerName, _apiContainerName} {
+		container := testContainer(podTemplate, name)
+		require.Equal(t, pointer.Bool(true), container.SecurityContext.ReadOnlyRootFilesystem)
+		require.Nil(t, container.SecurityContext.RunAsNonRoot)
+		require.Equal(t, []kcore.VolumeMount{
+			k8s.EmptyDirVolumeMount(_emptyDirVolumeName, _emptyDirMountPath),
+			k8s.EmptyDirVolumeMount(_tmpVolumeName, _tmpMountPath),
+		}, container.VolumeMounts)
+	}
+
+	// the api container remains privileged since it still runs as root
+	require.Equal(t, pointer.Bool(true), testContainer(podTemplate, _apiContainerName).SecurityContext.Privileged)
+	require.Nil(t, testContainer(podTemplate, _egressProxyInitContainerName).SecurityContext)
+	require.Empty(t, testContainer(podTemplate, _neuronRTDContainerName).VolumeMounts)
+}
+
+func TestApplySecurityProfiles(t *testing.T) {
+	podTemplate := testPodTemplate()
+	ApplySecurity(testAPI(userconfig.PythonPredictorType, nil, &userconfig.Security{
+		SeccompProfile:  pointer.String(k8s.SeccompProfileRuntimeDefault),
+		AppArmorProfile: pointer.String("localhost/cortex"),
+	}), podTemplate)
+
+	require.Equal(t, map[string]string{
+		k8s.SeccompPodAnnotationKey:                                      k8s.SeccompProfileRuntimeDefault,
+		k8s.AppArmorContainerAnnotationKey(_downloaderInitContainerName): "localhost/cortex",
+		k8s.AppArmorContainerAnnotationKey(_apiContainerName):            "localhost/cortex",
+		k8s.AppArmorContainerAnnotationKey(_tfServingContainerName):      "localhost/cortex",
+		k8s.AppArmorContainerAnnotationKey(RequestMonitorContainerName):  "localhost/cortex",
+	}, podTemplate.Annotations)
+
+	require.Nil(t, testContainer(podTemplate, _apiContainerName).SecurityContext.RunAsNonRoot)
+	require.Len(t, podTemplate.Spec.Volumes, 1)
+}
+
+func TestApplySecurityRestricted(t *testing.T) {
+	podTemplate := testPodTemplate()
+	ApplySecurity(testAPI(userconfig.PythonPredictorType, nil, &userconfig.Security{
+		RunAsNonRoot:        pointer.Bool(true),
+		SeccompProfile:      pointer.String(k8s.SeccompProfileRuntimeDefault),
+		PodSecurityStandard: pointer.String(k8s.PodSecurityStandardRestricted),
+	}), podTemplate)
+
+	for _, name := range []string{_downloaderInitContainerName, _apiContainerName} {
+		container := testContainer(podTemplate, name)
+		require.Equal(t, pointer.Bool(true), container.SecurityContext.RunAsNonRoot)
+		require.Equal(t, pointer.Bool(false), container.SecurityContext.Privileged)
+		require.Equal(t, pointer.Bool(false), container.SecurityContext.AllowPrivilegeEscalation)
+		require.Equal(t, []kcore.Capability{"ALL"}, container.SecurityContext.Capabilities.Drop)
+		require.Empty(t, container.SecurityContext.Capabilities.Add)
+	}
+
+	require.Equal(t, k8s.SeccompProfileRuntimeDefault, podTemplate.Annotations[k8s.SeccompPodAnnotationKey])
+}
+
+func TestApplySecurityPrivileged(t *testing.T) {
+	podTemplate := testPodTemplate()
+	ApplySecurity(testAPI(userconfig.PythonPredictorType, nil, &userconfig.Security{
+		PodSecurityStandard: pointer.String(k8s.PodSecurityStandardPrivileged),
+	}), podTemplate)
+
+	apiContainer := testContainer(podTemplate, _apiContainerName)
+	require.Equal(t, pointer.Bool(true), apiContainer.SecurityContext.Privileged)
+	require.Nil(t, apiContainer.SecurityContext.Capabilities)
+	require.Nil(t, testContainer(podTemplate, _downloaderInitContainerName).SecurityContext)
+}
@@ -17,7 +17,6 @@ limitations under the License.
 package operator
 
 import (
-	"github.com/cortexlabs/cortex/pkg/lib/parallel"
 	"github.com/cortexlabs/cortex/pkg/operator/config"
 	"github.com/cortexlabs/cortex/pkg/types/spec"
 )
@@ -35,25 +34,22 @@ func DownloadAPISpec(apiName string, apiID string) (*spec.API, error) {
 
 func DownloadAPISpecs(apiNames []string, apiIDs []string) ([]spec.API, error) {
 	apis := make([]spec.API, len(apiNames))
-	fns := make([]func() error, len(apiNames))
+	group := ParallelGroup("download api specs", MaxParallelAWSRequests)
 
 	for i := range apiNames {
 		localIdx := i
-		fns[i] = func() error {
+		group.Go(apiNames[localIdx], func() error {
 			api, err := DownloadAPISpec(apiNames[localIdx], apiIDs[localIdx])
 			if err != nil {
 				return err
 			}
 			apis[localIdx] = *api
 			return nil
-		}
+		})
 	}
 
-	if len(fns) > 0 {
-		err := parallel.RunFirstErr(fns[0], fns[1:]...)
-		if err != nil {
-			return nil, err
-		}
+	if err := group.WaitFirstErr(); err != nil {
+		return nil, err
 	}
 
 	return apis, nil
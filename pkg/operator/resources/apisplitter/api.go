@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"reflect"
+	"time"
 
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/k8s"
@@ -37,12 +38,17 @@ func UpdateAPI(apiConfig *userconfig.API, projectID string, force bool) (*spec.A
 		return nil, "", err
 	}
 
+	sunsetDates, err := getSunsetDates()
+	if err != nil {
+		return nil, "", err
+	}
+
 	api := spec.GetAPISpec(apiConfig, projectID, "")
 	if prevVirtualService == nil {
 		if err := config.AWS.UploadMsgpackToS3(api, config.Cluster.Bucket, api.Key); err != nil {
 			return nil, "", errors.Wrap(err, "upload api spec")
 		}
-		if err := applyK8sVirtualService(api, prevVirtualService); err != nil {
+		if err := applyK8sVirtualService(api, sunsetDates); err != nil {
 			go deleteK8sResources(api.Name)
 			return nil, "", err
 		}
@@ -54,11 +60,11 @@ func UpdateAPI(apiConfig *userconfig.API, projectID string, force bool) (*spec.A
 		return api, fmt.Sprintf("created %s", api.Name), nil
 	}
 
-	if !areVirtualServiceEqual(prevVirtualService, virtualServiceSpec(api)) {
+	if !areVirtualServiceEqual(prevVirtualService, virtualServiceSpec(api, sunsetDates)) {
 		if err := config.AWS.UploadMsgpackToS3(api, config.Cluster.Bucket, api.Key); err != nil {
 			return nil, "", errors.Wrap(err, "upload api spec")
 		}
-		if err := applyK8sVirtualService(api, prevVirtualService); err != nil {
+		if err := applyK8sVirtualService(api, sunsetDates); err != nil {
 			return nil, "", err
 		}
 		if err := operator.UpdateAPIGatewayK8s(prevVirtualService, api); err != nil {
@@ -115,19 +121,16 @@ func getK8sResources(apiConfig *userconfig.API) (*istioclientnetworking.VirtualS
 	return virtualService, err
 }
 
-func applyK8sVirtualService(apiSplitter *spec.API, prevVirtualService *istioclientnetworking.VirtualService) error {
-	newVirtualService := virtualServiceSpec(apiSplitter)
-
-	if prevVirtualService == nil {
-		_, err := config.K8s.CreateVirtualService(newVirtualService)
+func applyK8sVirtualService(apiSplitter *spec.API, sunsetDates map[string]time.Time) error {
+	if _, err := config.K8s.ApplyVirtualService(virtualServiceSpec(apiSplitter, sunsetDates)); err != nil {
 		return err
 	}
 
-	_, err := config.K8s.UpdateVirtualService(prevVirtualService, newVirtualService)
-	return err
+	return operator.ApplyAPIIngress(apiSplitter.Name, *apiSplitter.Networking.Endpoint)
 }
 
-func getAPISplitterDestinations(apiSplitter *spec.API) []k8s.Destination {
+// responses from deprecated APIs include the deprecation headers, whether they are routed through an API splitter or not
+func getAPISplitterDestinations(apiSplitter *spec.API, sunsetDates map[string]time.Time) []k8s.Destination {
 	destinations := make([]k8s.Destination, len(apiSplitter.APIs))
 	for i, api := range apiSplitter.APIs {
 		destinations[i] = k8s.Destination{
@@ -135,13 +138,98 @@ func getAPISplitterDestinations(apiSplitter *spec.API) []k8s.Destination {
 			Weight:      int32(api.Weight),
 			Port:        uint32(_defaultPortInt32),
 		}
+		if sunsetDate, ok := sunsetDates[api.Name]; ok {
+			destinations[i].ResponseHeaders = operator.DeprecationResponseHeaders(sunsetDate)
+		}
 	}
 	return destinations
 }
 
+// getSunsetDates returns the sunset dates of the deployed SyncAPIs which are deprecated (api name -> sunset date)
+func getSunsetDates() (map[string]time.Time, error) {
+	virtualServices, err := config.K8s.ListVirtualServicesByLabel("apiKind", userconfig.SyncAPIKind.String())
+	if err != nil {
+		return nil, err
+	}
+
+	sunsetDates := map[string]time.Time{}
+	for i := range virtualServices {
+		sunsetDate, err := userconfig.SunsetDateFromAnnotations(&virtualServices[i])
+		if err != nil {
+			return nil, err
+		}
+		if sunsetDate != nil {
+			sunsetDates[virtualServices[i].Labels["apiName"]] = *sunsetDate
+		}
+	}
+
+	return sunsetDates, nil
+}
+
+// UpdateDeprecationHeaders re-applies the virtual services of API splitters whose deprecation headers are out of date
+// (i.e. an API which they route traffic to was deprecated or undeprecated after the API splitter was deployed)
+func UpdateDeprecationHeaders() error {
+	sunsetDates, err := getSunsetDates()
+	if err != nil {
+		return err
+	}
+
+	virtualServices, err := config.K8s.ListVirtualServicesByLabel("apiKind", userconfig.APISplitterKind.String())
+	if err != nil {
+		return err
+	}
+
+	for i := range virtualServices {
+		virtualService := &virtualServices[i]
+		apiSplitter, err := operator.DownloadAPISpec(virtualService.Labels["apiName"], virtualService.Labels["apiID"])
+		if err != nil {
+			return err
+		}
+
+		if reflect.DeepEqual(virtualService.Spec.Http, virtualServiceSpec(apiSplitter, sunsetDates).Spec.Http) {
+			continue
+		}
+		if err := applyK8sVirtualService(apiSplitter, sunsetDates); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeprecationWarnings returns warnings for the deprecated APIs which the API splitter routes traffic to and whose
+// sunset dates are approaching
+func DeprecationWarnings(apiSplitter *spec.API) ([]string, error) {
+	sunsetDates, err := getSunsetDates()
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	for _, api := range apiSplitter.APIs {
+		sunsetDate, ok := sunsetDates[api.Name]
+		if !ok {
+			continue
+		}
+		deprecation := &userconfig.Deprecation{SunsetDate: sunsetDate}
+		if deprecation.IsSunsetApproaching() {
+			warnings = append(warnings, userconfig.APISplitterDeprecationWarning(apiSplitter.Name, api.Name, deprecation))
+		}
+	}
+
+	return warnings, nil
+}
+
 func deleteK8sResources(apiName string) error {
-	_, err := config.K8s.DeleteVirtualService(operator.K8sName(apiName))
-	return err
+	return parallel.RunFirstErr(
+		func() error {
+			_, err := config.K8s.DeleteVirtualService(operator.K8sName(apiName))
+			return err
+		},
+		func() error {
+			return operator.DeleteAPIIngress(apiName)
+		},
+	)
 }
 
 func deleteS3Resources(apiName string) error {
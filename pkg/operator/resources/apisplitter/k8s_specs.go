@@ -17,6 +17,8 @@ limitations under the License.
 package apisplitter
 
 import (
+	"time"
+
 	"github.com/cortexlabs/cortex/pkg/lib/k8s"
 	"github.com/cortexlabs/cortex/pkg/lib/pointer"
 	"github.com/cortexlabs/cortex/pkg/operator/operator"
@@ -29,11 +31,12 @@ const (
 	_defaultPortInt32, _defaultPortStr = int32(8888), "8888"
 )
 
-func virtualServiceSpec(apiSplitter *spec.API) *istioclientnetworking.VirtualService {
+// sunsetDates maps the names of deprecated APIs to their sunset dates
+func virtualServiceSpec(apiSplitter *spec.API, sunsetDates map[string]time.Time) *istioclientnetworking.VirtualService {
 	return k8s.VirtualService(&k8s.VirtualServiceSpec{
 		Name:         operator.K8sName(apiSplitter.Name),
 		Gateways:     []string{"apis-gateway"},
-		Destinations: getAPISplitterDestinations(apiSplitter),
+		Destinations: getAPISplitterDestinations(apiSplitter, sunsetDates),
 		Path:         *apiSplitter.Networking.Endpoint,
 		Rewrite:      pointer.String("predict"),
 		Annotations: map[string]string{
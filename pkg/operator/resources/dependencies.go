@@ -0,0 +1,200 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/k8s"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+	"github.com/cortexlabs/cortex/pkg/lib/telemetry"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+	"github.com/cortexlabs/cortex/pkg/operator/operator"
+	"github.com/cortexlabs/cortex/pkg/types/userconfig"
+	kbatch "k8s.io/api/batch/v1"
+	kcore "k8s.io/api/core/v1"
+	kresource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	_dependencyCheckTimeout      = 5 * time.Minute
+	_dependencyCheckPollInterval = 2 * time.Second
+	_dependencyCheckLogLines     = 40
+	_dependencyCheckVolumeName   = "dependencies"
+	_dependencyCheckMountPath    = "/mnt/dependencies"
+)
+
+// the dependency files which are installed by the predictor images when replicas start (see serve/run.sh)
+var _dependencyFileNames = []string{"conda-packages.txt", "requirements.txt"}
+
+// installs the dependencies in the same order as serve/run.sh
+var _dependencyCheckScript = fmt.Sprintf(`set -e
+if [ -f "%[1]s/conda-packages.txt" ]; then
+    conda install -y --file %[1]s/conda-packages.txt
+fi
+if [ -f "%[1]s/requirements.txt" ]; then
+    pip --no-cache-dir install -r %[1]s/requirements.txt
+fi`, _dependencyCheckMountPath)
+
+// checkDependencies installs the project's requirements.txt and conda-packages.txt in a short-lived job for each
+// predictor image used by the SyncAPIs, so that dependency conflicts (or packages which are not available for the
+// image's platform) fail the deployment instead of causing replicas to crash loop
+func checkDependencies(apiConfigs []userconfig.API, projectFileMap map[string][]byte) error {
+	dependencyFiles := map[string]string{}
+	for _, fileName := range _dependencyFileNames {
+		if fileBytes, ok := projectFileMap[fileName]; ok {
+			dependencyFiles[fileName] = string(fileBytes)
+		}
+	}
+	if len(dependencyFiles) == 0 {
+		return nil
+	}
+
+	apiNamesByImage := map[string][]string{}
+	for _, apiConfig := range apiConfigs {
+		if apiConfig.Kind != userconfig.SyncAPIKind {
+			continue
+		}
+		apiNamesByImage[apiConfig.Predictor.Image] = append(apiNamesByImage[apiConfig.Predictor.Image], apiConfig.Name)
+	}
+
+	images := make([]string, 0, len(apiNamesByImage))
+	for image := range apiNamesByImage {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+
+	group := operator.ParallelGroup("dependency check", 0)
+	for _, image := range images {
+		image := image
+		apiNames := apiNamesByImage[image]
+		group.Go(image, func() error {
+			return checkDependenciesForImage(image, apiNames, dependencyFiles)
+		})
+	}
+	return group.WaitFirstErr()
+}
+
+func checkDependenciesForImage(image string, apiNames []string, dependencyFiles map[string]string) error {
+	name := "dependency-check-" + k8s.RandomName()[:10]
+	labels := map[string]string{
+		"dependencyCheck": "true",
+		"apiName":         apiNames[0],
+	}
+
+	if _, err := config.K8s.CreateConfigMap(k8s.ConfigMap(&k8s.ConfigMapSpec{
+		Name:   name,
+		Data:   dependencyFiles,
+		Labels: labels,
+	})); err != nil {
+		return err
+	}
+	defer func() {
+		if _, err := config.K8s.DeleteConfigMap(name); err != nil {
+			telemetry.Error(err)
+		}
+	}()
+
+	if _, err := config.K8s.CreateJob(dependencyCheckJobSpec(name, image, labels)); err != nil {
+		return err
+	}
+	defer func() {
+		if _, err := config.K8s.DeleteJob(name); err != nil {
+			telemetry.Error(err)
+		}
+	}()
+
+	deadline := time.Now().Add(_dependencyCheckTimeout)
+	for time.Now().Before(deadline) {
+		job, err := config.K8s.GetJob(name)
+		if err != nil {
+			return err
+		}
+		if job == nil {
+			return errors.ErrorUnexpected("unable to find dependency check job", name)
+		}
+
+		if job.Status.Succeeded > 0 {
+			return nil
+		}
+		if job.Status.Failed > 0 {
+			return ErrorDependencyResolutionFailed(apiNames, image, dependencyCheckLogs(name))
+		}
+
+		time.Sleep(_dependencyCheckPollInterval)
+	}
+
+	return ErrorDependencyCheckTimeout(apiNames, _dependencyCheckTimeout)
+}
+
+func dependencyCheckJobSpec(name string, image string, labels map[string]string) *kbatch.Job {
+	return k8s.Job(&k8s.JobSpec{
+		Name:   name,
+		Labels: labels,
+		PodSpec: k8s.PodSpec{
+			Labels: labels,
+			K8sPodSpec: kcore.PodSpec{
+				RestartPolicy: "Never",
+				Containers: []kcore.Container{
+					{
+						Name:            "dependency-check",
+						Image:           image,
+						ImagePullPolicy: kcore.PullAlways,
+						Command:         []string{"/bin/bash", "-c", _dependencyCheckScript},
+						VolumeMounts: []kcore.VolumeMount{
+							{
+								Name:      _dependencyCheckVolumeName,
+								MountPath: _dependencyCheckMountPath,
+							},
+						},
+						Resources: kcore.ResourceRequirements{
+							Requests: kcore.ResourceList{
+								kcore.ResourceCPU:    kresource.MustParse("500m"),
+								kcore.ResourceMemory: kresource.MustParse("1Gi"),
+							},
+						},
+					},
+				},
+				Volumes: []kcore.Volume{
+					k8s.ConfigMapVolume(_dependencyCheckVolumeName, name),
+				},
+				NodeSelector: map[string]string{
+					"workload": "true",
+				},
+				Tolerations:        operator.Tolerations,
+				ServiceAccountName: "default",
+			},
+		},
+	})
+}
+
+// Returns the tail of the dependency check's logs (or a placeholder if they can't be retrieved)
+func dependencyCheckLogs(jobName string) string {
+	pods, err := config.K8s.ListPodsByLabel("job-name", jobName)
+	if err != nil || len(pods) == 0 {
+		return "(unable to retrieve the logs of the dependency check)"
+	}
+
+	logs, err := config.K8s.GetPodLogs(pods[0].Name, "dependency-check", pointer.Int64(_dependencyCheckLogLines))
+	if err != nil {
+		return "(unable to retrieve the logs of the dependency check)"
+	}
+	return logs
+}
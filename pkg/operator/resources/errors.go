@@ -18,19 +18,40 @@ package resources
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/cortexlabs/cortex/pkg/consts"
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/strings"
+	"github.com/cortexlabs/cortex/pkg/types/clusterconfig"
 	"github.com/cortexlabs/cortex/pkg/types/userconfig"
 )
 
 const (
-	ErrOperationNotSupportedForKind  = "resources.operation_not_supported_for_kind"
-	ErrAPINotDeployed                = "resources.api_not_deployed"
-	ErrCannotChangeTypeOfDeployedAPI = "resources.cannot_change_kind_of_deployed_api"
-	ErrNoAvailableNodeComputeLimit   = "resources.no_available_node_compute_limit"
-	ErrAPIUsedByAPISplitter          = "resources.syncapi_used_by_apisplitter"
-	ErrNotDeployedAPIsAPISplitter    = "resources.trafficsplit_apis_not_deployed"
+	ErrOperationNotSupportedForKind       = "resources.operation_not_supported_for_kind"
+	ErrAPINotDeployed                     = "resources.api_not_deployed"
+	ErrCannotChangeTypeOfDeployedAPI      = "resources.cannot_change_kind_of_deployed_api"
+	ErrNoAvailableNodeComputeLimit        = "resources.no_available_node_compute_limit"
+	ErrAPIUsedByAPISplitter               = "resources.syncapi_used_by_apisplitter"
+	ErrNotDeployedAPIsAPISplitter         = "resources.trafficsplit_apis_not_deployed"
+	ErrAPISplitterReferencesDeprecatedAPI = "resources.apisplitter_references_deprecated_api"
+	ErrInvalidTTL                         = "resources.invalid_ttl"
+	ErrInvalidPreviewBranch               = "resources.invalid_preview_branch"
+	ErrPreviewNotDeployed                 = "resources.preview_not_deployed"
+	ErrPreviewAPINameConflict             = "resources.preview_api_name_conflict"
+	ErrInvalidPreviewAPIName              = "resources.invalid_preview_api_name"
+	ErrDependencyResolutionFailed         = "resources.dependency_resolution_failed"
+	ErrDependencyCheckTimeout             = "resources.dependency_check_timeout"
+	ErrSecurityPolicyViolation            = "resources.security_policy_violation"
+	ErrDependenciesRequireRoot            = "resources.dependencies_require_root"
+	ErrPodSecurityExemptionRequired       = "resources.pod_security_exemption_required"
+	ErrRestrictedPodSecurityViolation     = "resources.restricted_pod_security_violation"
+	ErrRestrictedPodSecurityIncompatible  = "resources.restricted_pod_security_incompatible"
+	ErrInvalidUsageWindow                 = "resources.invalid_usage_window"
+	ErrAPIGatewayRequiresIstioIngress     = "resources.api_gateway_requires_istio_ingress"
+	ErrUsageWindowTooLong                 = "resources.usage_window_too_long"
+	ErrExclusiveCPUsRequireStaticPolicy   = "resources.exclusive_cpus_require_static_policy"
+	ErrInstanceHugePagesRequired          = "resources.instance_hugepages_required"
 )
 
 func ErrorOperationNotSupportedForKind(kind userconfig.Kind) error {
@@ -78,3 +99,129 @@ func ErrorNotDeployedAPIsAPISplitter(notDeployedAPIs []string) error {
 		Message: fmt.Sprintf("unable to find specified %s: %s", strings.PluralS("api", len(notDeployedAPIs)), strings.StrsAnd(notDeployedAPIs)),
 	})
 }
+
+func ErrorAPISplitterReferencesDeprecatedAPI(apiName string, sunsetDate time.Time) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrAPISplitterReferencesDeprecatedAPI,
+		Message: fmt.Sprintf("%s cannot be added to an api splitter because it is deprecated (sunset date: %s)", apiName, sunsetDate.Format(userconfig.SunsetDateFormat)),
+	})
+}
+
+func ErrorInvalidTTL(ttl time.Duration) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidTTL,
+		Message: fmt.Sprintf("invalid ttl (%s); ttl must be greater than zero", ttl),
+	})
+}
+
+func ErrorInvalidPreviewBranch(branch string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidPreviewBranch,
+		Message: fmt.Sprintf("invalid branch name %s; branch names must contain at least one alphanumeric character", strings.UserStr(branch)),
+	})
+}
+
+func ErrorPreviewNotDeployed(branch string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrPreviewNotDeployed,
+		Message: fmt.Sprintf("there are no preview apis deployed for branch %s", strings.UserStr(branch)),
+	})
+}
+
+func ErrorPreviewAPINameConflict(apiName string, branch string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrPreviewAPINameConflict,
+		Message: fmt.Sprintf("unable to deploy the preview of branch %s because an api named %s is already deployed and is not a preview of this branch", strings.UserStr(branch), apiName),
+	})
+}
+
+func ErrorInvalidPreviewAPIName(previewAPIName string, apiName string, branch string, err error) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidPreviewAPIName,
+		Message: fmt.Sprintf("unable to deploy the preview of %s for branch %s because its preview name (%s) is invalid: %s; please use a shorter api or branch name", apiName, strings.UserStr(branch), previewAPIName, errors.Message(err)),
+	})
+}
+
+func ErrorDependencyResolutionFailed(apiNames []string, image string, logs string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrDependencyResolutionFailed,
+		Message: fmt.Sprintf("unable to install the project's dependencies in %s (used by %s); please update requirements.txt and/or conda-packages.txt and try again\n\n%s", image, strings.StrsAnd(apiNames), logs),
+	})
+}
+
+func ErrorDependencyCheckTimeout(apiNames []string, timeout time.Duration) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrDependencyCheckTimeout,
+		Message: fmt.Sprintf("the dependency check for %s did not complete within %s; you can deploy without the dependency check by omitting the `--check-dependencies` flag", strings.StrsAnd(apiNames), timeout),
+	})
+}
+
+func ErrorSecurityPolicyViolation(key string, value string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrSecurityPolicyViolation,
+		Message: fmt.Sprintf("%s cannot be set to %s because it conflicts with the cluster's api_security_policy (run `cortex cluster info` to view the policy)", key, value),
+	})
+}
+
+func ErrorDependenciesRequireRoot(fileName string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrDependenciesRequireRoot,
+		Message: fmt.Sprintf("%s cannot be used when running as a non-root user or with a read-only root filesystem, since dependencies are installed when the api starts; please build a custom predictor image with your dependencies pre-installed instead (see https://docs.cortex.dev/v/%s/deployments/system-packages)", fileName, consts.CortexVersionMinor),
+	})
+}
+
+func ErrorPodSecurityExemptionRequired(apiName string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrPodSecurityExemptionRequired,
+		Message: fmt.Sprintf("%s cannot opt out of the cluster's restricted pod security standard because it is not listed in the cluster's %s.%s (which can only be updated by a cluster administrator via `cortex cluster configure`)", apiName, clusterconfig.APISecurityPolicyKey, clusterconfig.PodSecurityExemptAPIsKey),
+	})
+}
+
+func ErrorRestrictedPodSecurityStandardViolation(key string, value string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrRestrictedPodSecurityViolation,
+		Message: fmt.Sprintf("%s cannot be set to %s because the api uses the restricted pod security standard", key, value),
+	})
+}
+
+func ErrorIncompatibleWithRestrictedPodSecurityStandard(key string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrRestrictedPodSecurityIncompatible,
+		Message: fmt.Sprintf("%s cannot be used with the restricted pod security standard, since it requires containers which run with elevated privileges", key),
+	})
+}
+
+func ErrorInvalidUsageWindow(start time.Time, end time.Time) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidUsageWindow,
+		Message: fmt.Sprintf("no usage has been finalized between %s and %s (usage is aggregated by hour, and is available once the hour has ended)", start.Format(time.RFC3339), end.Format(time.RFC3339)),
+	})
+}
+
+func ErrorUsageWindowTooLong(maxWindow time.Duration) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrUsageWindowTooLong,
+		Message: fmt.Sprintf("usage can be reported for at most %d days at a time", int(maxWindow.Hours()/24)),
+	})
+}
+
+func ErrorAPIGatewayRequiresIstioIngress() error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrAPIGatewayRequiresIstioIngress,
+		Message: fmt.Sprintf("api gateway is only supported when the cluster's %s is %s; please set %s: %s in the networking configuration, and use the api load balancer's endpoint instead", clusterconfig.APIIngressKey, clusterconfig.IstioAPIIngress, userconfig.APIGatewayKey, userconfig.NoneAPIGatewayType),
+	})
+}
+
+func ErrorExclusiveCPUsRequireStaticCPUManagerPolicy() error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrExclusiveCPUsRequireStaticPolicy,
+		Message: fmt.Sprintf("%s requires the cluster's instances to run the kubelet with the static cpu manager policy; to use it, create a cluster with `%s: %s` in your cluster configuration file (optionally with `%s: %s` to align the cores with a single NUMA node), since this can't be changed on an existing cluster", userconfig.ExclusiveCPUsKey, clusterconfig.CPUManagerPolicyKey, clusterconfig.StaticCPUManagerPolicy, clusterconfig.TopologyManagerPolicyKey, clusterconfig.SingleNUMANodeTopologyManagerPolicy),
+	})
+}
+
+func ErrorInstanceHugePagesRequired() error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInstanceHugePagesRequired,
+		Message: fmt.Sprintf("%s can only be requested if huge pages are preallocated on the cluster's instances; to use them, create a cluster with %s set in your cluster configuration file (e.g. `%s: 1024` to preallocate 2Gi), since this can't be changed on an existing cluster", userconfig.HugePagesKey, clusterconfig.InstanceHugePagesKey, clusterconfig.InstanceHugePagesKey),
+	})
+}
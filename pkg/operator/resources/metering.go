@@ -0,0 +1,306 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/cortexlabs/cortex/pkg/lib/parallel"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
+	"github.com/cortexlabs/cortex/pkg/lib/slices"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+	"github.com/cortexlabs/cortex/pkg/operator/operator"
+	"github.com/cortexlabs/cortex/pkg/operator/resources/syncapi"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+	"github.com/cortexlabs/cortex/pkg/types/userconfig"
+	kapps "k8s.io/api/apps/v1"
+	kcore "k8s.io/api/core/v1"
+)
+
+const (
+	_meteringPrefix = "metering"
+
+	// CloudWatch metrics can arrive a few minutes late, so an hour's request counts are only collected after this delay
+	_meteringFinalizationDelay = 5 * time.Minute
+
+	_maxUsageWindow = 366 * 24 * time.Hour
+
+	_gpuResourceName kcore.ResourceName = "nvidia.com/gpu"
+)
+
+// MeteringInterval is the interval at which the usage of each API's pods is sampled
+const MeteringInterval = 1 * time.Minute
+
+// usageRecord is the usage of each API during an hour. Records are updated by the metering cron during the hour, and are
+// immutable once they have been finalized.
+type usageRecord struct {
+	Start time.Time            `json:"start"`
+	Final bool                 `json:"final"` // set once the hour's request counts and storage have been added
+	APIs  map[string]*apiUsage `json:"apis"`  // api name -> usage
+}
+
+type apiUsage struct {
+	Project        string   `json:"project"`
+	APIIDs         []string `json:"api_ids"` // the API's IDs during the hour, which its request metrics are recorded by
+	ReplicaSeconds float64  `json:"replica_seconds"`
+	CPUSeconds     float64  `json:"cpu_seconds"` // requested CPU cores × seconds
+	GPUSeconds     float64  `json:"gpu_seconds"`
+	RequestCount   int64    `json:"request_count"`
+	StorageBytes   int64    `json:"storage_bytes"` // bytes stored in the cluster's bucket for the API when the hour was finalized
+}
+
+var (
+	_meteringMux       sync.Mutex
+	_currentUsage      *usageRecord
+	_lastFinalizedHour time.Time
+)
+
+func usageRecordKey(hour time.Time) string {
+	return filepath.Join(_meteringPrefix, hour.Format("2006-01-02"), hour.Format("15")+".json")
+}
+
+// returns nil if no APIs were running during the hour
+func readUsageRecord(hour time.Time) (*usageRecord, error) {
+	key := usageRecordKey(hour)
+	exists, err := config.AWS.IsS3File(config.Cluster.Bucket, key)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	var record usageRecord
+	if err := config.AWS.ReadJSONFromS3(&record, config.Cluster.Bucket, key); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// MeterUsage samples the resources which each API's pods are holding, adds them to the current hour's usage record, and
+// finalizes the previous hour's record
+func MeterUsage() error {
+	_meteringMux.Lock()
+	defer _meteringMux.Unlock()
+
+	hour := time.Now().UTC().Truncate(time.Hour)
+
+	finalizableHour := time.Now().UTC().Add(-_meteringFinalizationDelay).Truncate(time.Hour).Add(-time.Hour)
+	if _lastFinalizedHour.Before(finalizableHour) {
+		record, err := readUsageRecord(finalizableHour)
+		if err != nil {
+			return err
+		}
+		if record != nil && !record.Final {
+			if err := finalizeUsageRecord(record); err != nil {
+				return err
+			}
+		}
+		_lastFinalizedHour = finalizableHour
+	}
+
+	if _currentUsage == nil || !_currentUsage.Start.Equal(hour) {
+		// continue the hour's record if the operator was restarted during the hour
+		record, err := readUsageRecord(hour)
+		if err != nil {
+			return err
+		}
+		if record == nil {
+			record = &usageRecord{Start: hour, APIs: map[string]*apiUsage{}}
+		}
+		_currentUsage = record
+	}
+
+	var deployments []kapps.Deployment
+	var pods []kcore.Pod
+	err := parallel.RunFirstErr(
+		func() error {
+			var err error
+			deployments, err = config.K8s.ListDeploymentsWithLabelKeys("apiName")
+			return err
+		},
+		func() error {
+			var err error
+			pods, err = config.K8s.ListPodsWithLabelKeys("apiName")
+			return err
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	addPodUsage(_currentUsage, deployments, pods, MeteringInterval.Seconds())
+
+	if len(_currentUsage.APIs) == 0 {
+		return nil
+	}
+	return config.AWS.UploadJSONToS3(_currentUsage, config.Cluster.Bucket, usageRecordKey(hour))
+}
+
+// addPodUsage adds the resources requested by each API's running pods over the sampling period to the record
+func addPodUsage(record *usageRecord, deployments []kapps.Deployment, pods []kcore.Pod, seconds float64) {
+	for _, deployment := range deployments {
+		apiName := deployment.Labels["apiName"]
+
+		usage, ok := record.APIs[apiName]
+		if !ok {
+			usage = &apiUsage{}
+			record.APIs[apiName] = usage
+		}
+
+		usage.Project = apiName
+		if project, ok := deployment.Annotations[userconfig.ProjectAnnotationKey]; ok {
+			usage.Project = project
+		}
+		if apiID := deployment.Labels["apiID"]; apiID != "" && !slices.HasString(usage.APIIDs, apiID) {
+			usage.APIIDs = append(usage.APIIDs, apiID)
+		}
+	}
+
+	for _, pod := range pods {
+		usage, ok := record.APIs[pod.Labels["apiName"]]
+		if !ok || pod.Status.Phase != kcore.PodRunning {
+			continue
+		}
+
+		if apiID := pod.Labels["apiID"]; apiID != "" && !slices.HasString(usage.APIIDs, apiID) {
+			usage.APIIDs = append(usage.APIIDs, apiID) // a stale pod from before the API was updated
+		}
+
+		usage.ReplicaSeconds += seconds
+		for _, container := range pod.Spec.Containers {
+			if cpu, ok := container.Resources.Requests[kcore.ResourceCPU]; ok {
+				usage.CPUSeconds += float64(cpu.MilliValue()) / 1000 * seconds
+			}
+			if gpu, ok := container.Resources.Requests[_gpuResourceName]; ok {
+				usage.GPUSeconds += float64(gpu.Value()) * seconds
+			}
+		}
+	}
+}
+
+// finalizeUsageRecord adds the request counts and storage of each API to the record
+func finalizeUsageRecord(record *usageRecord) error {
+	for apiName, usage := range record.APIs {
+		var err error
+		usage.RequestCount, err = syncapi.GetRequestCount(apiName, usage.APIIDs, record.Start, record.Start.Add(time.Hour))
+		if err != nil {
+			return err
+		}
+
+		usage.StorageBytes = 0
+		err = config.AWS.S3Iterator(config.Cluster.Bucket, filepath.Join("apis", apiName)+"/", false, nil, func(object *s3.Object) (bool, error) {
+			usage.StorageBytes += *object.Size
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	record.Final = true
+	return config.AWS.UploadJSONToS3(record, config.Cluster.Bucket, usageRecordKey(record.Start))
+}
+
+// GetUsage aggregates the usage of each project between start and end, which are rounded out to the hour. The current
+// hour (and the previous hour, until its request counts have been collected) is excluded, so that the usage which is
+// reported for a time window never changes.
+func GetUsage(start time.Time, end time.Time) (*schema.UsageResponse, error) {
+	start = start.UTC().Truncate(time.Hour)
+	end = end.UTC().Add(time.Hour - time.Nanosecond).Truncate(time.Hour)
+	if latestEnd := time.Now().UTC().Add(-_meteringFinalizationDelay).Truncate(time.Hour); end.After(latestEnd) {
+		end = latestEnd
+	}
+	if !start.Before(end) {
+		return nil, ErrorInvalidUsageWindow(start, end)
+	}
+	if end.Sub(start) > _maxUsageWindow {
+		return nil, ErrorUsageWindowTooLong(_maxUsageWindow)
+	}
+
+	var hours []time.Time
+	for hour := start; hour.Before(end); hour = hour.Add(time.Hour) {
+		hours = append(hours, hour)
+	}
+
+	records := make([]*usageRecord, len(hours))
+	group := operator.ParallelGroup("read usage records", operator.MaxParallelAWSRequests)
+	for i := range hours {
+		i := i
+		group.Go(hours[i].Format(time.RFC3339), func() error {
+			record, err := readUsageRecord(hours[i])
+			if err != nil || record == nil {
+				return err
+			}
+			// the most recent hour may not have been finalized by the cron yet, and hours during which the operator was unavailable were skipped
+			if !record.Final {
+				if err := finalizeUsageRecord(record); err != nil {
+					return err
+				}
+			}
+			records[i] = record
+			return nil
+		})
+	}
+	if err := group.WaitFirstErr(); err != nil {
+		return nil, err
+	}
+
+	return &schema.UsageResponse{
+		Start:    start,
+		End:      end,
+		Projects: aggregateUsage(records),
+	}, nil
+}
+
+func aggregateUsage(records []*usageRecord) []schema.ProjectUsage {
+	projectUsages := map[string]*schema.ProjectUsage{}
+	projectAPINames := map[string]strset.Set{}
+
+	for _, record := range records {
+		if record == nil {
+			continue
+		}
+		for apiName, usage := range record.APIs {
+			projectUsage, ok := projectUsages[usage.Project]
+			if !ok {
+				projectUsage = &schema.ProjectUsage{Project: usage.Project}
+				projectUsages[usage.Project] = projectUsage
+				projectAPINames[usage.Project] = strset.New()
+			}
+			projectAPINames[usage.Project].Add(apiName)
+
+			projectUsage.RequestCount += usage.RequestCount
+			projectUsage.ReplicaHours += usage.ReplicaSeconds / 3600
+			projectUsage.CPUHours += usage.CPUSeconds / 3600
+			projectUsage.GPUHours += usage.GPUSeconds / 3600
+			projectUsage.StorageGBHours += float64(usage.StorageBytes) / 1e9 // the storage at the end of the hour is billed for the whole hour
+		}
+	}
+
+	aggregated := make([]schema.ProjectUsage, 0, len(projectUsages))
+	for project, projectUsage := range projectUsages {
+		projectUsage.APINames = projectAPINames[project].SliceSorted()
+		aggregated = append(aggregated, *projectUsage)
+	}
+	sort.Slice(aggregated, func(i, j int) bool {
+		return aggregated[i].Project < aggregated[j].Project
+	})
+
+	return aggregated
+}
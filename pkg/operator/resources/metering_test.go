@@ -0,0 +1,116 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/types/userconfig"
+	"github.com/stretchr/testify/require"
+	kapps "k8s.io/api/apps/v1"
+	kcore "k8s.io/api/core/v1"
+	kresource "k8s.io/apimachinery/pkg/api/resource"
+	kmeta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUsageRecordKey(t *testing.T) {
+	hour := time.Date(2020, 7, 2, 9, 0, 0, 0, time.UTC)
+	require.Equal(t, "metering/2020-07-02/09.json", usageRecordKey(hour))
+}
+
+func TestAddPodUsage(t *testing.T) {
+	deployments := []kapps.Deployment{
+		{ObjectMeta: kmeta.ObjectMeta{
+			Labels:      map[string]string{"apiName": "classifier", "apiID": "id2"},
+			Annotations: map[string]string{userconfig.ProjectAnnotationKey: "search"},
+		}},
+		{ObjectMeta: kmeta.ObjectMeta{
+			Labels: map[string]string{"apiName": "generator", "apiID": "id3"},
+		}},
+	}
+
+	pod := func(apiName string, apiID string, phase kcore.PodPhase, cpu string, gpu string) kcore.Pod {
+		requests := kcore.ResourceList{kcore.ResourceCPU: kresource.MustParse(cpu)}
+		if gpu != "" {
+			requests[_gpuResourceName] = kresource.MustParse(gpu)
+		}
+		return kcore.Pod{
+			ObjectMeta: kmeta.ObjectMeta{Labels: map[string]string{"apiName": apiName, "apiID": apiID}},
+			Spec:       kcore.PodSpec{Containers: []kcore.Container{{Resources: kcore.ResourceRequirements{Requests: requests}}}},
+			Status:     kcore.PodStatus{Phase: phase},
+		}
+	}
+	pods := []kcore.Pod{
+		pod("classifier", "id1", kcore.PodRunning, "500m", ""),
+		pod("classifier", "id2", kcore.PodRunning, "500m", ""),
+		pod("generator", "id3", kcore.PodRunning, "1", "1"),
+		pod("generator", "id3", kcore.PodPending, "1", "1"),
+		pod("deleted", "id4", kcore.PodRunning, "1", ""),
+	}
+
+	record := &usageRecord{APIs: map[string]*apiUsage{}}
+	addPodUsage(record, deployments, pods, 60)
+	addPodUsage(record, deployments, pods, 60)
+
+	require.Len(t, record.APIs, 2)
+
+	require.Equal(t, "search", record.APIs["classifier"].Project)
+	require.Equal(t, []string{"id2", "id1"}, record.APIs["classifier"].APIIDs)
+	require.Equal(t, 240.0, record.APIs["classifier"].ReplicaSeconds)
+	require.Equal(t, 120.0, record.APIs["classifier"].CPUSeconds)
+	require.Equal(t, 0.0, record.APIs["classifier"].GPUSeconds)
+
+	require.Equal(t, "generator", record.APIs["generator"].Project)
+	require.Equal(t, []string{"id3"}, record.APIs["generator"].APIIDs)
+	require.Equal(t, 120.0, record.APIs["generator"].ReplicaSeconds)
+	require.Equal(t, 120.0, record.APIs["generator"].CPUSeconds)
+	require.Equal(t, 120.0, record.APIs["generator"].GPUSeconds)
+}
+
+func TestAggregateUsage(t *testing.T) {
+	records := []*usageRecord{
+		{APIs: map[string]*apiUsage{
+			"classifier":    {Project: "search", ReplicaSeconds: 3600, CPUSeconds: 1800, RequestCount: 10, StorageBytes: 2e9},
+			"generator":     {Project: "generator", ReplicaSeconds: 7200, CPUSeconds: 7200, GPUSeconds: 7200, RequestCount: 5},
+			"classifier-v2": {Project: "search", ReplicaSeconds: 1800, CPUSeconds: 900, RequestCount: 1},
+		}},
+		nil, // no apis were running during the hour
+		{APIs: map[string]*apiUsage{
+			"classifier": {Project: "search", ReplicaSeconds: 3600, CPUSeconds: 1800, RequestCount: 20, StorageBytes: 2e9},
+		}},
+	}
+
+	usage := aggregateUsage(records)
+	require.Len(t, usage, 2)
+
+	require.Equal(t, "generator", usage[0].Project)
+	require.Equal(t, []string{"generator"}, usage[0].APINames)
+	require.Equal(t, int64(5), usage[0].RequestCount)
+	require.Equal(t, 2.0, usage[0].ReplicaHours)
+	require.Equal(t, 2.0, usage[0].GPUHours)
+
+	require.Equal(t, "search", usage[1].Project)
+	require.Equal(t, []string{"classifier", "classifier-v2"}, usage[1].APINames)
+	require.Equal(t, int64(31), usage[1].RequestCount)
+	require.Equal(t, 2.5, usage[1].ReplicaHours)
+	require.Equal(t, 1.25, usage[1].CPUHours)
+	require.Equal(t, 0.0, usage[1].GPUHours)
+	require.Equal(t, 4.0, usage[1].StorageGBHours)
+
+	require.Empty(t, aggregateUsage(nil))
+}
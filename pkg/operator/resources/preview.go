@@ -0,0 +1,212 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	cr "github.com/cortexlabs/cortex/pkg/lib/configreader"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
+	s "github.com/cortexlabs/cortex/pkg/lib/strings"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+	"github.com/cortexlabs/cortex/pkg/operator/operator"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+	"github.com/cortexlabs/cortex/pkg/types/spec"
+	"github.com/cortexlabs/cortex/pkg/types/userconfig"
+	istioclientnetworking "istio.io/client-go/pkg/apis/networking/v1alpha3"
+)
+
+const (
+	// the preview annotation is owned by its own field manager so that it isn't removed when the API is updated
+	_previewFieldManager = "cortex-preview"
+
+	_maxPreviewSuffixLength = 20
+)
+
+// Preview APIs which are deployed without a ttl are deleted after this duration
+var DefaultPreviewTTL = 7 * 24 * time.Hour
+
+var _previewSuffixInvalidCharsRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+// PreviewSuffix converts a git branch name into a suffix which can be appended to API names and endpoints
+func PreviewSuffix(branch string) (string, error) {
+	suffix := _previewSuffixInvalidCharsRegex.ReplaceAllString(strings.ToLower(branch), "-")
+	if len(suffix) > _maxPreviewSuffixLength {
+		suffix = suffix[:_maxPreviewSuffixLength]
+	}
+	suffix = strings.Trim(suffix, "-")
+
+	if suffix == "" {
+		return "", ErrorInvalidPreviewBranch(branch)
+	}
+	return suffix, nil
+}
+
+// previewAPIConfigs renames the APIs (and their endpoints) so that they can be deployed alongside the original APIs.
+// The preview names are re-validated since the suffix can make them exceed the maximum API name length.
+// APISplitters which reference APIs in the same deployment are updated to reference the preview APIs
+func previewAPIConfigs(apiConfigs []userconfig.API, branch string) ([]userconfig.API, error) {
+	suffix, err := PreviewSuffix(branch)
+	if err != nil {
+		return nil, err
+	}
+
+	apiNames := strset.New()
+	for _, apiConfig := range apiConfigs {
+		apiNames.Add(apiConfig.Name)
+	}
+
+	previewConfigs := make([]userconfig.API, len(apiConfigs))
+	for i, apiConfig := range apiConfigs {
+		previewName := apiConfig.Name + "-" + suffix
+		if err := cr.ValidateStringVal(previewName, spec.APINameValidation); err != nil {
+			return nil, ErrorInvalidPreviewAPIName(previewName, apiConfig.Name, branch, err)
+		}
+		apiConfig.Name = previewName
+
+		if apiConfig.Networking != nil && apiConfig.Networking.Endpoint != nil {
+			networking := *apiConfig.Networking
+			networking.Endpoint = pointer.String(strings.TrimSuffix(*networking.Endpoint, "/") + "-" + suffix)
+			apiConfig.Networking = &networking
+		}
+
+		if len(apiConfig.APIs) > 0 {
+			trafficSplits := make([]*userconfig.TrafficSplit, len(apiConfig.APIs))
+			for j, trafficSplit := range apiConfig.APIs {
+				trafficSplitCopy := *trafficSplit
+				if apiNames.Has(trafficSplit.Name) {
+					trafficSplitCopy.Name = trafficSplit.Name + "-" + suffix
+				}
+				trafficSplits[j] = &trafficSplitCopy
+			}
+			apiConfig.APIs = trafficSplits
+		}
+
+		previewConfigs[i] = apiConfig
+	}
+
+	return previewConfigs, nil
+}
+
+// checkPreviewAPIName returns an error if an API with the preview API's name is deployed and is not a preview of branch
+func checkPreviewAPIName(apiName string, branch string) error {
+	virtualService, err := config.K8s.GetVirtualService(operator.K8sName(apiName))
+	if err != nil {
+		return err
+	}
+	if virtualService == nil {
+		return nil
+	}
+
+	if virtualService.Annotations[userconfig.PreviewBranchAnnotationKey] != branch {
+		return ErrorPreviewAPINameConflict(apiName, branch)
+	}
+	return nil
+}
+
+func setPreviewBranch(apiName string, branch string) error {
+	annotations := map[string]*string{
+		userconfig.PreviewBranchAnnotationKey: &branch,
+	}
+	_, err := config.K8s.PatchVirtualServiceAnnotations(operator.K8sName(apiName), annotations, _previewFieldManager)
+	return err
+}
+
+func GetPreviews() (*schema.GetPreviewsResponse, error) {
+	virtualServices, err := config.K8s.ListVirtualServicesWithLabelKeys("apiName")
+	if err != nil {
+		return nil, err
+	}
+
+	previewsByBranch := map[string]*schema.Preview{}
+	for i := range virtualServices {
+		branch, ok := virtualServices[i].Annotations[userconfig.PreviewBranchAnnotationKey]
+		if !ok {
+			continue
+		}
+
+		preview, ok := previewsByBranch[branch]
+		if !ok {
+			preview = &schema.Preview{Branch: branch}
+			previewsByBranch[branch] = preview
+		}
+		preview.APINames = append(preview.APINames, virtualServices[i].Labels["apiName"])
+
+		expiration, err := expirationFromVirtualService(&virtualServices[i])
+		if err != nil {
+			return nil, err
+		}
+		if expiration != nil && (preview.Expiration == nil || expiration.Before(*preview.Expiration)) {
+			preview.Expiration = expiration
+		}
+	}
+
+	previews := make([]schema.Preview, 0, len(previewsByBranch))
+	for _, preview := range previewsByBranch {
+		sort.Strings(preview.APINames)
+		previews = append(previews, *preview)
+	}
+	sort.Slice(previews, func(i, j int) bool {
+		return previews[i].Branch < previews[j].Branch
+	})
+
+	return &schema.GetPreviewsResponse{
+		Previews: previews,
+	}, nil
+}
+
+// DeletePreview deletes all of the APIs which were deployed as a preview of the branch
+func DeletePreview(branch string) (*schema.DeleteResponse, error) {
+	suffix, err := PreviewSuffix(branch)
+	if err != nil {
+		return nil, err
+	}
+
+	virtualServices, err := config.K8s.ListVirtualServicesWithLabelKeys("apiName")
+	if err != nil {
+		return nil, err
+	}
+
+	var previewVirtualServices []istioclientnetworking.VirtualService
+	for _, virtualService := range virtualServices {
+		previewBranch, ok := virtualService.Annotations[userconfig.PreviewBranchAnnotationKey]
+		if !ok {
+			continue
+		}
+		if previewSuffix, _ := PreviewSuffix(previewBranch); previewSuffix == suffix {
+			previewVirtualServices = append(previewVirtualServices, virtualService)
+		}
+	}
+	if len(previewVirtualServices) == 0 {
+		return nil, ErrorPreviewNotDeployed(branch)
+	}
+
+	deletedAPINames, errs := deleteAPIs("delete preview", previewVirtualServices)
+	if len(errs) > 0 {
+		return nil, errors.FirstError(errs...)
+	}
+
+	return &schema.DeleteResponse{
+		Message: fmt.Sprintf("deleting %s", s.StrsAnd(deletedAPINames)),
+	}, nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+	"github.com/cortexlabs/cortex/pkg/types/userconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviewSuffix(t *testing.T) {
+	for branch, expected := range map[string]string{
+		"master":                                "master",
+		"Feature/New-Model":                     "feature-new-model",
+		"fix_tokenizer":                         "fix-tokenizer",
+		"user/fix//double..dots":                "user-fix-double-dots",
+		"--fix--":                               "fix",
+		"release-1.2.3":                         "release-1-2-3",
+		"a-very-long-branch-name-for-a-feature": "a-very-long-branch-n",
+		"nineteen-characters-x":                 "nineteen-characters",
+		"ümlaut-branch":                         "mlaut-branch",
+	} {
+		suffix, err := PreviewSuffix(branch)
+		require.NoError(t, err, branch)
+		require.Equal(t, expected, suffix, branch)
+		require.True(t, len(suffix) <= _maxPreviewSuffixLength, branch)
+	}
+
+	for _, branch := range []string{"", "-", "///", "__"} {
+		_, err := PreviewSuffix(branch)
+		require.Equal(t, ErrInvalidPreviewBranch, errors.GetKind(err), branch)
+	}
+}
+
+func TestPreviewAPIConfigs(t *testing.T) {
+	apiConfigs := []userconfig.API{
+		{
+			Resource:   userconfig.Resource{Name: "text-generator", Kind: userconfig.SyncAPIKind},
+			Networking: &userconfig.Networking{Endpoint: pointer.String("/text-generator/")},
+		},
+		{
+			Resource:   userconfig.Resource{Name: "splitter", Kind: userconfig.APISplitterKind},
+			Networking: &userconfig.Networking{Endpoint: pointer.String("/splitter")},
+			APIs: []*userconfig.TrafficSplit{
+				{Name: "text-generator", Weight: 80},
+				{Name: "other-api", Weight: 20},
+			},
+		},
+	}
+
+	previewConfigs, err := previewAPIConfigs(apiConfigs, "Feature/New-Model")
+	require.NoError(t, err)
+	require.Len(t, previewConfigs, 2)
+
+	require.Equal(t, "text-generator-feature-new-model", previewConfigs[0].Name)
+	require.Equal(t, "/text-generator-feature-new-model", *previewConfigs[0].Networking.Endpoint)
+
+	require.Equal(t, "splitter-feature-new-model", previewConfigs[1].Name)
+	require.Equal(t, "/splitter-feature-new-model", *previewConfigs[1].Networking.Endpoint)
+	require.Equal(t, "text-generator-feature-new-model", previewConfigs[1].APIs[0].Name)
+	require.Equal(t, "other-api", previewConfigs[1].APIs[1].Name)
+
+	// the original configs are not modified
+	require.Equal(t, "text-generator", apiConfigs[0].Name)
+	require.Equal(t, "/text-generator/", *apiConfigs[0].Networking.Endpoint)
+	require.Equal(t, "text-generator", apiConfigs[1].APIs[0].Name)
+
+	longNameConfigs := []userconfig.API{
+		{Resource: userconfig.Resource{Name: strings.Repeat("a", 30), Kind: userconfig.SyncAPIKind}},
+	}
+	_, err = previewAPIConfigs(longNameConfigs, "feature-branch")
+	require.Equal(t, ErrInvalidPreviewAPIName, errors.GetKind(err))
+
+	_, err = previewAPIConfigs(apiConfigs, "///")
+	require.Equal(t, ErrInvalidPreviewBranch, errors.GetKind(err))
+}
@@ -18,6 +18,9 @@ package resources
 
 import (
 	"fmt"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/cortexlabs/cortex/pkg/consts"
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
@@ -35,6 +38,7 @@ import (
 	"github.com/cortexlabs/cortex/pkg/types/status"
 	"github.com/cortexlabs/cortex/pkg/types/userconfig"
 	"github.com/gorilla/websocket"
+	istioclientnetworking "istio.io/client-go/pkg/apis/networking/v1alpha3"
 )
 
 func GetDeployedResourceByName(resourceName string) (*userconfig.Resource, error) {
@@ -61,7 +65,20 @@ func IsResourceUpdating(resource userconfig.Resource) (bool, error) {
 	return false, ErrorOperationNotSupportedForKind(resource.Kind)
 }
 
-func Deploy(projectBytes []byte, configFileName string, configBytes []byte, force bool) (*schema.DeployResponse, error) {
+// If ttl is not nil, each of the deployed APIs will be deleted once ttl has elapsed. If previewBranch is not empty, the
+// APIs are deployed under branch-suffixed names and endpoints (and expire after DefaultPreviewTTL if ttl is nil). If
+// checkDeps is true, the project's dependencies are installed in a sandbox before any of the APIs are updated
+// Limits the number of APIs which are deployed or deleted at once
+const _maxParallelAPIOperations = 10
+
+func Deploy(projectBytes []byte, configFileName string, configBytes []byte, force bool, ttl *time.Duration, previewBranch string, checkDeps bool) (*schema.DeployResponse, error) {
+	if ttl != nil && *ttl <= 0 {
+		return nil, ErrorInvalidTTL(*ttl)
+	}
+	if previewBranch != "" && ttl == nil {
+		ttl = &DefaultPreviewTTL
+	}
+
 	projectID := hash.Bytes(projectBytes)
 	projectKey := spec.ProjectKey(projectID)
 	projectFileMap, err := zip.UnzipMemToMem(projectBytes)
@@ -79,12 +96,25 @@ func Deploy(projectBytes []byte, configFileName string, configBytes []byte, forc
 		return nil, err
 	}
 
+	if previewBranch != "" {
+		apiConfigs, err = previewAPIConfigs(apiConfigs, previewBranch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = ValidateClusterAPIs(apiConfigs, projectFiles)
 	if err != nil {
 		err = errors.Append(err, fmt.Sprintf("\n\napi configuration schema can be found here: https://docs.cortex.dev/v/%s/deployments/api-configuration", consts.CortexVersionMinor))
 		return nil, err
 	}
 
+	if checkDeps {
+		if err := checkDependencies(apiConfigs, projectFileMap); err != nil {
+			return nil, err
+		}
+	}
+
 	isProjectUploaded, err := config.AWS.IsS3File(config.Cluster.Bucket, projectKey)
 	if err != nil {
 		return nil, err
@@ -97,16 +127,29 @@ func Deploy(projectBytes []byte, configFileName string, configBytes []byte, forc
 
 	// order SyncAPIs apiconfigs first then APISplitters
 	// This is done if user specifies SyncAPIs in same file as APISplitter
-	apiConfigs = append(InclusiveFilterAPIsByKind(apiConfigs, userconfig.SyncAPIKind), InclusiveFilterAPIsByKind(apiConfigs, userconfig.APISplitterKind)...)
+	syncAPIConfigs := InclusiveFilterAPIsByKind(apiConfigs, userconfig.SyncAPIKind)
+	apiSplitterConfigs := InclusiveFilterAPIsByKind(apiConfigs, userconfig.APISplitterKind)
+	apiConfigs = append(syncAPIConfigs, apiSplitterConfigs...)
 
+	// SyncAPIs are deployed in parallel, and then APISplitters (which may reference them) are deployed in parallel
 	results := make([]schema.DeployResult, len(apiConfigs))
-	for i, apiConfig := range apiConfigs {
-		api, msg, err := UpdateAPI(&apiConfig, projectID, force)
-		results[i].Message = msg
-		if err != nil {
-			results[i].Error = errors.Message(err)
-		} else {
-			results[i].API = *api
+	for _, phase := range [][2]int{{0, len(syncAPIConfigs)}, {len(syncAPIConfigs), len(apiConfigs)}} {
+		group := operator.ParallelGroup("deploy", _maxParallelAPIOperations)
+		for i := phase[0]; i < phase[1]; i++ {
+			i := i
+			group.Go(apiConfigs[i].Name, func() error {
+				results[i] = deployAPI(&apiConfigs[i], projectID, force, ttl, previewBranch)
+				return nil
+			})
+		}
+		group.Wait()
+
+		// API splitters which aren't being deployed may route traffic to SyncAPIs whose deprecation has changed
+		if phase[0] == 0 && len(syncAPIConfigs) > 0 {
+			if err := apisplitter.UpdateDeprecationHeaders(); err != nil {
+				telemetry.Error(err)
+				errors.PrintError(err)
+			}
 		}
 	}
 
@@ -115,6 +158,62 @@ func Deploy(projectBytes []byte, configFileName string, configBytes []byte, forc
 	}, nil
 }
 
+func deployAPI(apiConfig *userconfig.API, projectID string, force bool, ttl *time.Duration, previewBranch string) schema.DeployResult {
+	var result schema.DeployResult
+
+	if previewBranch != "" {
+		if err := checkPreviewAPIName(apiConfig.Name, previewBranch); err != nil {
+			result.Error = errors.Message(err)
+			return result
+		}
+	}
+
+	api, msg, err := UpdateAPI(apiConfig, projectID, force)
+	if err == nil && previewBranch != "" {
+		err = setPreviewBranch(apiConfig.Name, previewBranch)
+	}
+	if err != nil {
+		result.Message = msg
+		result.Error = errors.Message(err)
+		return result
+	}
+
+	result.API = *api
+	result.Warnings = deprecationWarnings(api)
+
+	// the api is already live at this point, so failing to set its expiration is reported as a warning rather than
+	// as a failed deployment
+	if ttl != nil {
+		expiration := time.Now().Add(*ttl)
+		if err := SetExpiration(apiConfig.Name, &expiration); err != nil {
+			telemetry.Error(err)
+			errors.PrintError(err)
+			result.Warnings = append(result.Warnings, fmt.Sprintf("unable to set the expiration of %s, so it will not be deleted automatically (run `cortex extend %s %s` to retry): %s", apiConfig.Name, apiConfig.Name, ttl.String(), errors.Message(err)))
+		} else {
+			msg = fmt.Sprintf("%s (expires in %s)", msg, expiresInStr(expiration))
+		}
+	}
+	result.Message = msg
+
+	return result
+}
+
+func deprecationWarnings(api *spec.API) []string {
+	if api.Kind == userconfig.APISplitterKind {
+		warnings, err := apisplitter.DeprecationWarnings(api)
+		if err != nil {
+			telemetry.Error(err)
+			errors.PrintError(err)
+		}
+		return warnings
+	}
+
+	if warning := api.DeprecationWarning(); warning != "" {
+		return []string{warning}
+	}
+	return nil
+}
+
 func UpdateAPI(apiConfig *userconfig.API, projectID string, force bool) (*spec.API, string, error) {
 	deployedResource, err := GetDeployedResourceByName(apiConfig.Name)
 	if err != nil {
@@ -150,6 +249,105 @@ func RefreshAPI(apiName string, force bool) (string, error) {
 	return "", ErrorOperationNotSupportedForKind(deployedResource.Kind) // unexpected
 }
 
+func SetMaintenance(apiName string, maintenance *schema.Maintenance) (string, error) {
+	deployedResource, err := GetDeployedResourceByName(apiName)
+	if err != nil {
+		return "", err
+	} else if deployedResource == nil {
+		return "", ErrorAPINotDeployed(apiName)
+	}
+
+	if deployedResource.Kind == userconfig.SyncAPIKind {
+		return syncapi.SetMaintenance(apiName, maintenance)
+	}
+
+	return "", ErrorOperationNotSupportedForKind(deployedResource.Kind)
+}
+
+func LoadTestAPI(apiName string, payload []byte, rps int, duration time.Duration) (*schema.LoadTestResponse, error) {
+	deployedResource, err := GetDeployedResourceByName(apiName)
+	if err != nil {
+		return nil, err
+	} else if deployedResource == nil {
+		return nil, ErrorAPINotDeployed(apiName)
+	}
+
+	if deployedResource.Kind == userconfig.SyncAPIKind {
+		return syncapi.StartLoadTest(apiName, payload, rps, duration)
+	}
+
+	return nil, ErrorOperationNotSupportedForKind(deployedResource.Kind)
+}
+
+func GetLoadTest(apiName string, jobID string) (*schema.LoadTestResponse, error) {
+	deployedResource, err := GetDeployedResourceByName(apiName)
+	if err != nil {
+		return nil, err
+	} else if deployedResource == nil {
+		return nil, ErrorAPINotDeployed(apiName)
+	}
+
+	if deployedResource.Kind == userconfig.SyncAPIKind {
+		return syncapi.GetLoadTest(apiName, jobID)
+	}
+
+	return nil, ErrorOperationNotSupportedForKind(deployedResource.Kind)
+}
+
+func ReplayAPI(apiName string, targetAPIName string, start time.Time, end time.Time, limit int) (*schema.ReplayResponse, error) {
+	deployedResource, err := GetDeployedResourceByName(apiName)
+	if err != nil {
+		return nil, err
+	} else if deployedResource == nil {
+		return nil, ErrorAPINotDeployed(apiName)
+	}
+	if deployedResource.Kind != userconfig.SyncAPIKind {
+		return nil, ErrorOperationNotSupportedForKind(deployedResource.Kind)
+	}
+
+	targetResource, err := GetDeployedResourceByName(targetAPIName)
+	if err != nil {
+		return nil, err
+	} else if targetResource == nil {
+		return nil, ErrorAPINotDeployed(targetAPIName)
+	}
+	if targetResource.Kind != userconfig.SyncAPIKind {
+		return nil, ErrorOperationNotSupportedForKind(targetResource.Kind)
+	}
+
+	return syncapi.StartReplay(apiName, targetAPIName, start, end, limit)
+}
+
+func GetReplay(apiName string, replayID string) (*schema.ReplayResponse, error) {
+	deployedResource, err := GetDeployedResourceByName(apiName)
+	if err != nil {
+		return nil, err
+	} else if deployedResource == nil {
+		return nil, ErrorAPINotDeployed(apiName)
+	}
+
+	if deployedResource.Kind == userconfig.SyncAPIKind {
+		return syncapi.GetReplay(apiName, replayID)
+	}
+
+	return nil, ErrorOperationNotSupportedForKind(deployedResource.Kind)
+}
+
+func GetManifest(apiName string) (*schema.GetManifestResponse, error) {
+	deployedResource, err := GetDeployedResourceByName(apiName)
+	if err != nil {
+		return nil, err
+	} else if deployedResource == nil {
+		return nil, ErrorAPINotDeployed(apiName)
+	}
+
+	if deployedResource.Kind == userconfig.SyncAPIKind {
+		return syncapi.GetManifest(apiName)
+	}
+
+	return nil, ErrorOperationNotSupportedForKind(deployedResource.Kind)
+}
+
 func DeleteAPI(apiName string, keepCache bool) (*schema.DeleteResponse, error) {
 	deployedResource, err := GetDeployedResourceByName(apiName)
 	if err != nil {
@@ -195,6 +393,49 @@ func DeleteAPI(apiName string, keepCache bool) (*schema.DeleteResponse, error) {
 	}, nil
 }
 
+// deleteAPIs deletes the APIs which the virtual services belong to in parallel, and returns the names of the deleted APIs
+// (sorted). APISplitters are deleted before SyncAPIs, since SyncAPIs can't be deleted while they are referenced by an APISplitter
+func deleteAPIs(groupName string, virtualServices []istioclientnetworking.VirtualService) ([]string, []error) {
+	var deletedAPINames []string
+	var deletedAPINamesMux sync.Mutex
+	var errs []error
+
+	for _, apiNames := range apiNamesByDeletionPhase(virtualServices) {
+		group := operator.ParallelGroup(groupName, _maxParallelAPIOperations)
+		for _, apiName := range apiNames {
+			apiName := apiName
+			group.Go(apiName, func() error {
+				if _, err := DeleteAPI(apiName, false); err != nil {
+					return errors.Wrap(err, apiName)
+				}
+				deletedAPINamesMux.Lock()
+				deletedAPINames = append(deletedAPINames, apiName)
+				deletedAPINamesMux.Unlock()
+				return nil
+			})
+		}
+		errs = append(errs, group.Wait()...)
+	}
+
+	sort.Strings(deletedAPINames)
+	return deletedAPINames, errs
+}
+
+// apiNamesByDeletionPhase returns the names of the APIs which the virtual services belong to, grouped into
+// the APISplitters (which must be deleted first) and the SyncAPIs
+func apiNamesByDeletionPhase(virtualServices []istioclientnetworking.VirtualService) [][]string {
+	var apiSplitterNames []string
+	var syncAPINames []string
+	for _, virtualService := range virtualServices {
+		if userconfig.KindFromString(virtualService.Labels["apiKind"]) == userconfig.APISplitterKind {
+			apiSplitterNames = append(apiSplitterNames, virtualService.Labels["apiName"])
+		} else {
+			syncAPINames = append(syncAPINames, virtualService.Labels["apiName"])
+		}
+	}
+	return [][]string{apiSplitterNames, syncAPINames}
+}
+
 func StreamLogs(deployedResource userconfig.Resource, socket *websocket.Conn) error {
 	if deployedResource.Kind == userconfig.SyncAPIKind {
 		syncapi.ReadLogs(deployedResource.Name, socket)
@@ -273,6 +514,12 @@ func GetAPI(apiName string) (*schema.GetAPIResponse, error) {
 		if err != nil {
 			return nil, err
 		}
+		if status.LastCrash != nil {
+			if err := syncapi.AddCrashDetails(api, status.LastCrash); err != nil {
+				telemetry.Error(err)
+				errors.PrintError(err)
+			}
+		}
 		metrics, err := syncapi.GetMetrics(api)
 		if err != nil {
 			return nil, err
@@ -281,13 +528,29 @@ func GetAPI(apiName string) (*schema.GetAPIResponse, error) {
 		if err != nil {
 			return nil, err
 		}
+		maintenance, err := syncapi.GetMaintenance(apiName)
+		if err != nil {
+			return nil, err
+		}
+		expiration, err := GetExpiration(apiName)
+		if err != nil {
+			return nil, err
+		}
+		goldenDatasetGate, err := syncapi.GetGoldenDatasetGate(apiName)
+		if err != nil {
+			return nil, err
+		}
 		return &schema.GetAPIResponse{
 			SyncAPI: &schema.SyncAPI{
-				Spec:         *api,
-				Status:       *status,
-				Metrics:      *metrics,
-				BaseURL:      baseURL,
-				DashboardURL: syncapi.DashboardURL(),
+				Spec:              *api,
+				Status:            *status,
+				Metrics:           *metrics,
+				BaseURL:           baseURL,
+				DashboardURL:      syncapi.DashboardURL(),
+				Maintenance:       maintenance,
+				Expiration:        expiration,
+				GoldenDatasetGate: goldenDatasetGate,
+				Versions:          syncapi.GetVersionMetrics(api),
 			},
 		}, nil
 	}
@@ -304,10 +567,15 @@ func GetAPI(apiName string) (*schema.GetAPIResponse, error) {
 		if err != nil {
 			return nil, err
 		}
+		expiration, err := GetExpiration(apiName)
+		if err != nil {
+			return nil, err
+		}
 		return &schema.GetAPIResponse{
 			APISplitter: &schema.APISplitter{
-				Spec:    *api,
-				BaseURL: baseURL,
+				Spec:       *api,
+				BaseURL:    baseURL,
+				Expiration: expiration,
 			},
 		}, nil
 	}
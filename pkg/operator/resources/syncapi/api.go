@@ -20,12 +20,14 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/cortexlabs/cortex/pkg/lib/cron"
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/k8s"
 	"github.com/cortexlabs/cortex/pkg/lib/maps"
 	"github.com/cortexlabs/cortex/pkg/lib/parallel"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
 	"github.com/cortexlabs/cortex/pkg/operator/config"
 	"github.com/cortexlabs/cortex/pkg/operator/operator"
 	"github.com/cortexlabs/cortex/pkg/types/spec"
@@ -36,10 +38,14 @@ import (
 	kmeta "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-var _autoscalerCrons = make(map[string]cron.Cron) // apiName -> cron
+var (
+	_autoscalerCrons    = make(map[string]cron.Cron) // apiName -> cron
+	_autoscalerCronIDs  = make(map[string]string)    // apiName -> ID of the deployment which the cron was created for
+	_autoscalerCronsMux sync.Mutex                   // APIs may be deployed and deleted concurrently
+)
 
 func UpdateAPI(apiConfig *userconfig.API, projectID string, force bool) (*spec.API, string, error) {
-	prevDeployment, prevService, prevVirtualService, err := getK8sResources(apiConfig)
+	prevDeployment, _, prevVirtualService, err := getK8sResources(apiConfig)
 	if err != nil {
 		return nil, "", err
 	}
@@ -49,13 +55,23 @@ func UpdateAPI(apiConfig *userconfig.API, projectID string, force bool) (*spec.A
 		deploymentID = prevDeployment.Labels["deploymentID"]
 	}
 
+	// this happens before the workloads are created or updated, so unsigned images never run
+	imageVerifications, err := operator.VerifyImageSignatures(apiConfig)
+	if err != nil {
+		return nil, "", err
+	}
+
 	api := spec.GetAPISpec(apiConfig, projectID, deploymentID)
+	api.ImageVerifications = imageVerifications
 
 	if prevDeployment == nil {
+		if err := assignAPIVersion(api); err != nil {
+			return nil, "", err
+		}
 		if err := config.AWS.UploadMsgpackToS3(api, config.Cluster.Bucket, api.Key); err != nil {
 			return nil, "", errors.Wrap(err, "upload api spec")
 		}
-		if err := applyK8sResources(api, prevDeployment, prevService, prevVirtualService); err != nil {
+		if err := applyK8sResources(api, prevDeployment, prevVirtualService); err != nil {
 			go deleteK8sResources(api.Name)
 			return nil, "", err
 		}
@@ -79,10 +95,35 @@ func UpdateAPI(apiConfig *userconfig.API, projectID string, force bool) (*spec.A
 		if isUpdating && !force {
 			return nil, "", ErrorAPIUpdating(api.Name)
 		}
+		isValidating, err := isGoldenDatasetGateRunning(api.Name)
+		if err != nil {
+			return nil, "", err
+		}
+		if isValidating && !force {
+			return nil, "", ErrorGoldenDatasetGateRunning(api.Name)
+		}
+		// best effort, since the previous version's replicas may not have started
+		if err := recordVersionImages(api.Name, prevDeployment.Labels["apiID"]); err != nil {
+			errors.PrintError(err)
+		}
+		if err := assignAPIVersion(api); err != nil {
+			return nil, "", err
+		}
 		if err := config.AWS.UploadMsgpackToS3(api, config.Cluster.Bucket, api.Key); err != nil {
 			return nil, "", errors.Wrap(err, "upload api spec")
 		}
-		if err := applyK8sResources(api, prevDeployment, prevService, prevVirtualService); err != nil {
+		if api.GoldenDataset != nil {
+			if err := startGoldenDatasetGate(api); err != nil {
+				return nil, "", err
+			}
+			return api, fmt.Sprintf("validating the update to %s against its golden dataset", api.Name), nil
+		}
+		if isValidating {
+			if err := cancelGoldenDatasetGate(api.Name); err != nil {
+				return nil, "", err
+			}
+		}
+		if err := applyK8sResources(api, prevDeployment, prevVirtualService); err != nil {
 			return nil, "", err
 		}
 		if err := operator.UpdateAPIGatewayK8s(prevVirtualService, api); err != nil {
@@ -92,6 +133,20 @@ func UpdateAPI(apiConfig *userconfig.API, projectID string, force bool) (*spec.A
 	}
 
 	// deployment didn't change
+	if err := assignAPIVersion(api); err != nil {
+		return nil, "", err
+	}
+	isValidating, err := isGoldenDatasetGateRunning(api.Name)
+	if err != nil {
+		return nil, "", err
+	}
+	if isValidating {
+		// the API was reverted to the version which is serving traffic while an update to it was being validated
+		if err := cancelGoldenDatasetGate(api.Name); err != nil {
+			return nil, "", err
+		}
+	}
+
 	isUpdating, err := isAPIUpdating(prevDeployment)
 	if err != nil {
 		return nil, "", err
@@ -129,7 +184,16 @@ func RefreshAPI(apiName string, force bool) (string, error) {
 		return "", err
 	}
 
+	imageVerifications := api.ImageVerifications // the images are still pinned to the verified digests
 	api = spec.GetAPISpec(api.API, api.ProjectID, k8s.RandomName())
+	api.ImageVerifications = imageVerifications
+
+	if err := recordVersionImages(apiName, apiID); err != nil {
+		errors.PrintError(err)
+	}
+	if err := assignAPIVersion(api); err != nil {
+		return "", err
+	}
 
 	if err := config.AWS.UploadMsgpackToS3(api, config.Cluster.Bucket, api.Key); err != nil {
 		return "", errors.Wrap(err, "upload api spec")
@@ -221,13 +285,13 @@ func getK8sResources(apiConfig *userconfig.API) (*kapps.Deployment, *kcore.Servi
 	return deployment, service, virtualService, err
 }
 
-func applyK8sResources(api *spec.API, prevDeployment *kapps.Deployment, prevService *kcore.Service, prevVirtualService *istioclientnetworking.VirtualService) error {
+func applyK8sResources(api *spec.API, prevDeployment *kapps.Deployment, prevVirtualService *istioclientnetworking.VirtualService) error {
 	return parallel.RunFirstErr(
 		func() error {
 			return applyK8sDeployment(api, prevDeployment)
 		},
 		func() error {
-			return applyK8sService(api, prevService)
+			return applyK8sService(api)
 		},
 		func() error {
 			return applyK8sVirtualService(api, prevVirtualService)
@@ -238,35 +302,46 @@ func applyK8sResources(api *spec.API, prevDeployment *kapps.Deployment, prevServ
 func applyK8sDeployment(api *spec.API, prevDeployment *kapps.Deployment) error {
 	newDeployment := deploymentSpec(api, prevDeployment)
 
-	if prevDeployment == nil {
-		_, err := config.K8s.CreateDeployment(newDeployment)
-		if err != nil {
-			return err
-		}
-	} else if prevDeployment.Status.ReadyReplicas == 0 {
+	if prevDeployment != nil && prevDeployment.Status.ReadyReplicas == 0 {
 		// Delete deployment if it never became ready
 		config.K8s.DeleteDeployment(operator.K8sName(api.Name))
-		_, err := config.K8s.CreateDeployment(newDeployment)
-		if err != nil {
-			return err
-		}
-	} else {
-		_, err := config.K8s.UpdateDeployment(newDeployment)
-		if err != nil {
-			return err
+	} else if prevDeployment != nil {
+		// The replica count is owned by the autoscaler's field manager, so the deployment is applied without it. Ownership must
+		// move to the autoscaler before that (e.g. if the deployment was just created, or was created before cortex used
+		// server-side apply), otherwise the replica count would be reset to 1 when "cortex" stops setting it
+		replicas := *newDeployment.Spec.Replicas
+		if replicas != *prevDeployment.Spec.Replicas || !k8s.IsFieldManagedBy(prevDeployment, k8s.ScaleFieldManager, "spec", "replicas") {
+			if _, err := config.K8s.ScaleDeployment(newDeployment.Name, replicas); err != nil {
+				return err
+			}
 		}
+		newDeployment.Spec.Replicas = nil
+	}
+
+	deployment, err := config.K8s.ApplyDeployment(newDeployment)
+	if err != nil {
+		return err
 	}
 
-	if err := UpdateAutoscalerCron(newDeployment); err != nil {
+	if err := UpdateAutoscalerCron(deployment); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// UpdateAutoscalerCron (re)starts the API's autoscaler if this operator replica is the leader; other replicas don't run
+// autoscalers, and the leader picks up APIs which they deploy via SyncAutoscalerCrons
 func UpdateAutoscalerCron(deployment *kapps.Deployment) error {
+	if !operator.IsLeader() {
+		return nil
+	}
+
 	apiName := deployment.Labels["apiName"]
 
+	_autoscalerCronsMux.Lock()
+	defer _autoscalerCronsMux.Unlock()
+
 	if prevAutoscalerCron, ok := _autoscalerCrons[apiName]; ok {
 		prevAutoscalerCron.Cancel()
 	}
@@ -277,45 +352,108 @@ func UpdateAutoscalerCron(deployment *kapps.Deployment) error {
 	}
 
 	_autoscalerCrons[apiName] = cron.Run(autoscaler, operator.ErrorHandler(apiName+" autoscaler"), spec.AutoscalingTickInterval)
+	_autoscalerCronIDs[apiName] = autoscalerCronID(deployment)
 
 	return nil
 }
 
-func applyK8sService(api *spec.API, prevService *kcore.Service) error {
-	newService := serviceSpec(api)
+// the autoscaler must be restarted if the deployment was recreated or its API was updated
+func autoscalerCronID(deployment *kapps.Deployment) string {
+	return string(deployment.UID) + "-" + deployment.Labels["apiID"]
+}
 
-	if prevService == nil {
-		_, err := config.K8s.CreateService(newService)
+// SyncAutoscalerCrons starts autoscalers for APIs which were deployed or updated by other operator replicas, and stops
+// the autoscalers of APIs which were deleted by other replicas
+func SyncAutoscalerCrons() error {
+	deployments, err := config.K8s.ListDeploymentsWithLabelKeys("apiName")
+	if err != nil {
 		return err
 	}
 
-	_, err := config.K8s.UpdateService(prevService, newService)
+	apiNames := strset.New()
+	for i := range deployments {
+		deployment := &deployments[i]
+		if userconfig.KindFromString(deployment.Labels["apiKind"]) != userconfig.SyncAPIKind {
+			continue
+		}
+
+		apiName := deployment.Labels["apiName"]
+		apiNames.Add(apiName)
+
+		_autoscalerCronsMux.Lock()
+		cronID, ok := _autoscalerCronIDs[apiName]
+		_autoscalerCronsMux.Unlock()
+
+		if !ok || cronID != autoscalerCronID(deployment) {
+			if err := UpdateAutoscalerCron(deployment); err != nil {
+				return err
+			}
+		}
+	}
+
+	_autoscalerCronsMux.Lock()
+	defer _autoscalerCronsMux.Unlock()
+
+	for apiName, autoscalerCron := range _autoscalerCrons {
+		if !apiNames.Has(apiName) {
+			autoscalerCron.Cancel()
+			delete(_autoscalerCrons, apiName)
+			delete(_autoscalerCronIDs, apiName)
+		}
+	}
+
+	return nil
+}
+
+// StopAutoscalerCrons stops all autoscalers (e.g. when this operator replica loses the leader lease)
+func StopAutoscalerCrons() {
+	_autoscalerCronsMux.Lock()
+	defer _autoscalerCronsMux.Unlock()
+
+	for apiName, autoscalerCron := range _autoscalerCrons {
+		autoscalerCron.Cancel()
+		delete(_autoscalerCrons, apiName)
+		delete(_autoscalerCronIDs, apiName)
+	}
+}
+
+func applyK8sService(api *spec.API) error {
+	_, err := config.K8s.ApplyService(serviceSpec(api))
 	return err
 }
 
 func applyK8sVirtualService(api *spec.API, prevVirtualService *istioclientnetworking.VirtualService) error {
-	newVirtualService := virtualServiceSpec(api)
+	// maintenance mode is preserved across deploys
+	maintenance, err := maintenanceFromVirtualService(prevVirtualService)
+	if err != nil {
+		return err
+	}
 
-	if prevVirtualService == nil {
-		_, err := config.K8s.CreateVirtualService(newVirtualService)
+	if _, err := config.K8s.ApplyVirtualService(virtualServiceSpec(api, maintenance)); err != nil {
 		return err
 	}
 
-	_, err := config.K8s.UpdateVirtualService(prevVirtualService, newVirtualService)
-	return err
+	return operator.ApplyAPIIngress(api.Name, *api.Networking.Endpoint)
 }
 
 func deleteK8sResources(apiName string) error {
 	return parallel.RunFirstErr(
 		func() error {
+			_autoscalerCronsMux.Lock()
 			if autoscalerCron, ok := _autoscalerCrons[apiName]; ok {
 				autoscalerCron.Cancel()
 				delete(_autoscalerCrons, apiName)
+				delete(_autoscalerCronIDs, apiName)
 			}
+			_autoscalerCronsMux.Unlock()
 
 			_, err := config.K8s.DeleteDeployment(operator.K8sName(apiName))
 			return err
 		},
+		func() error {
+			_, err := config.K8s.DeleteDeployment(goldenDatasetDeploymentName(apiName))
+			return err
+		},
 		func() error {
 			_, err := config.K8s.DeleteService(operator.K8sName(apiName))
 			return err
@@ -324,6 +462,21 @@ func deleteK8sResources(apiName string) error {
 			_, err := config.K8s.DeleteVirtualService(operator.K8sName(apiName))
 			return err
 		},
+		func() error {
+			return operator.DeleteAPIIngress(apiName)
+		},
+		func() error {
+			jobs, err := config.K8s.ListJobsByLabels(map[string]string{"loadTest": "true", "loadTestAPIName": apiName})
+			if err != nil {
+				return err
+			}
+			for _, job := range jobs {
+				if _, err := config.K8s.DeleteJob(job.Name); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
 	)
 }
 
@@ -27,6 +27,7 @@ import (
 	s "github.com/cortexlabs/cortex/pkg/lib/strings"
 	libtime "github.com/cortexlabs/cortex/pkg/lib/time"
 	"github.com/cortexlabs/cortex/pkg/operator/config"
+	"github.com/cortexlabs/cortex/pkg/operator/operator"
 	"github.com/cortexlabs/cortex/pkg/types/spec"
 	"github.com/cortexlabs/cortex/pkg/types/userconfig"
 	kapps "k8s.io/api/apps/v1"
@@ -179,14 +180,7 @@ func autoscaleFn(initialDeployment *kapps.Deployment) (func() error, error) {
 		if currentReplicas != request {
 			log.Printf("%s autoscaling event: %d -> %d", apiName, currentReplicas, request)
 
-			deployment, err := config.K8s.GetDeployment(initialDeployment.Name)
-			if err != nil {
-				return err
-			}
-
-			deployment.Spec.Replicas = &request
-
-			if _, err := config.K8s.UpdateDeployment(deployment); err != nil {
+			if _, err := config.K8s.ScaleDeployment(initialDeployment.Name, request); err != nil {
 				return err
 			}
 
@@ -209,14 +203,17 @@ func getInflightRequests(apiName string, window time.Duration) (*float64, error)
 				Label: aws.String("InFlight"),
 				MetricStat: &cloudwatch.MetricStat{
 					Metric: &cloudwatch.Metric{
-						Namespace:  aws.String(config.Cluster.ClusterName),
+						Namespace:  aws.String(config.Cluster.MetricsNamespace),
 						MetricName: aws.String("in-flight"),
-						Dimensions: []*cloudwatch.Dimension{
-							{
-								Name:  aws.String("apiName"),
-								Value: aws.String(apiName),
+						Dimensions: append(
+							[]*cloudwatch.Dimension{
+								{
+									Name:  aws.String("apiName"),
+									Value: aws.String(apiName),
+								},
 							},
-						},
+							operator.MetricsDimensions()...,
+						),
 					},
 					Stat:   aws.String("Sum"),
 					Period: aws.Int64(10),
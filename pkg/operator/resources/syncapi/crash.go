@@ -0,0 +1,100 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncapi
+
+import (
+	"github.com/cortexlabs/cortex/pkg/lib/aws"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+	"github.com/cortexlabs/cortex/pkg/types/spec"
+	"github.com/cortexlabs/cortex/pkg/types/status"
+	kapps "k8s.io/api/apps/v1"
+	kcore "k8s.io/api/core/v1"
+)
+
+const _crashLogLines = 50
+
+// lastCrash returns the most recent unexpected exit of a container in one of the API's pods (or nil if there hasn't been one)
+func lastCrash(deployment *kapps.Deployment, pods []kcore.Pod) *status.Crash {
+	var crash *status.Crash
+
+	for _, pod := range pods {
+		if pod.Labels["apiName"] != deployment.Labels["apiName"] {
+			continue
+		}
+
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			terminated := containerStatus.State.Terminated
+			restarted := false
+			if terminated == nil {
+				terminated = containerStatus.LastTerminationState.Terminated
+				restarted = true
+			}
+			if terminated == nil || terminated.ExitCode == 0 {
+				continue
+			}
+			if crash != nil && !terminated.FinishedAt.Time.After(crash.FinishedAt) {
+				continue
+			}
+
+			crash = &status.Crash{
+				PodName:      pod.Name,
+				Container:    containerStatus.Name,
+				ExitCode:     terminated.ExitCode,
+				Signal:       terminated.Signal,
+				Reason:       terminated.Reason,
+				FinishedAt:   terminated.FinishedAt.Time,
+				RestartCount: containerStatus.RestartCount,
+				Restarted:    restarted,
+			}
+		}
+	}
+
+	return crash
+}
+
+// AddCrashDetails adds the crashed container's last logs and its crash report to the crash; the details are
+// best-effort, since the pod may have been deleted since its status was read
+func AddCrashDetails(api *spec.API, crash *status.Crash) error {
+	var logs string
+	var err error
+	if crash.Restarted {
+		logs, err = config.K8s.GetPreviousPodLogs(crash.PodName, crash.Container, pointer.Int64(_crashLogLines))
+	} else {
+		logs, err = config.K8s.GetPodLogs(crash.PodName, crash.Container, pointer.Int64(_crashLogLines))
+	}
+	if err == nil {
+		crash.Logs = &logs
+	}
+
+	objects, err := config.AWS.ListS3Prefix(config.Cluster.Bucket, spec.CrashReportsPrefix(api.Name, api.ID, crash.PodName), false, nil)
+	if err != nil {
+		return err
+	}
+
+	var latest *string
+	for _, object := range objects {
+		if latest == nil || *object.Key > *latest {
+			latest = object.Key // keys are prefixed with the crash's timestamp
+		}
+	}
+	if latest != nil {
+		crash.ReportPath = pointer.String(aws.S3Path(config.Cluster.Bucket, *latest))
+	}
+
+	return nil
+}
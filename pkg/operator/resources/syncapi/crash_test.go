@@ -0,0 +1,100 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	kapps "k8s.io/api/apps/v1"
+	kcore "k8s.io/api/core/v1"
+	kmeta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func crashTestPod(name string, apiName string, containerStatuses ...kcore.ContainerStatus) kcore.Pod {
+	return kcore.Pod{
+		ObjectMeta: kmeta.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"apiName": apiName},
+		},
+		Status: kcore.PodStatus{
+			ContainerStatuses: containerStatuses,
+		},
+	}
+}
+
+func terminated(exitCode int32, reason string, finishedAt time.Time) *kcore.ContainerStateTerminated {
+	return &kcore.ContainerStateTerminated{
+		ExitCode:   exitCode,
+		Reason:     reason,
+		FinishedAt: kmeta.NewTime(finishedAt),
+	}
+}
+
+func TestLastCrash(t *testing.T) {
+	deployment := &kapps.Deployment{
+		ObjectMeta: kmeta.ObjectMeta{Labels: map[string]string{"apiName": "my-api"}},
+	}
+	now := time.Now().Truncate(time.Second)
+
+	require.Nil(t, lastCrash(deployment, nil))
+
+	running := kcore.ContainerStatus{Name: "api", State: kcore.ContainerState{Running: &kcore.ContainerStateRunning{}}}
+	require.Nil(t, lastCrash(deployment, []kcore.Pod{crashTestPod("pod-1", "my-api", running)}))
+
+	// containers which exited successfully did not crash
+	succeeded := kcore.ContainerStatus{Name: "api", State: kcore.ContainerState{Terminated: terminated(0, "Completed", now)}}
+	require.Nil(t, lastCrash(deployment, []kcore.Pod{crashTestPod("pod-1", "my-api", succeeded)}))
+
+	restarted := kcore.ContainerStatus{
+		Name:                 "api",
+		RestartCount:         2,
+		State:                kcore.ContainerState{Running: &kcore.ContainerStateRunning{}},
+		LastTerminationState: kcore.ContainerState{Terminated: terminated(137, "OOMKilled", now.Add(-time.Minute))},
+	}
+	crashed := kcore.ContainerStatus{
+		Name:  "serve",
+		State: kcore.ContainerState{Terminated: terminated(139, "Error", now)},
+	}
+	otherAPI := kcore.ContainerStatus{
+		Name:  "api",
+		State: kcore.ContainerState{Terminated: terminated(1, "Error", now.Add(time.Minute))},
+	}
+
+	crash := lastCrash(deployment, []kcore.Pod{crashTestPod("pod-1", "my-api", restarted)})
+	require.NotNil(t, crash)
+	require.Equal(t, "pod-1", crash.PodName)
+	require.Equal(t, "api", crash.Container)
+	require.Equal(t, int32(137), crash.ExitCode)
+	require.Equal(t, "OOMKilled", crash.Reason)
+	require.Equal(t, int32(2), crash.RestartCount)
+	require.True(t, crash.Restarted)
+
+	// the most recent crash of the API's pods is returned
+	crash = lastCrash(deployment, []kcore.Pod{
+		crashTestPod("pod-1", "my-api", restarted),
+		crashTestPod("pod-2", "my-api", running, crashed),
+		crashTestPod("pod-3", "other-api", otherAPI),
+	})
+	require.NotNil(t, crash)
+	require.Equal(t, "pod-2", crash.PodName)
+	require.Equal(t, "serve", crash.Container)
+	require.Equal(t, int32(139), crash.ExitCode)
+	require.Equal(t, now, crash.FinishedAt)
+	require.False(t, crash.Restarted)
+}
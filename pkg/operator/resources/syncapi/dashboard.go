@@ -23,6 +23,7 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/aws"
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/operator/config"
+	"github.com/cortexlabs/cortex/pkg/operator/operator"
 )
 
 func addAPIToDashboard(dashboardName string, apiName string) error {
@@ -32,7 +33,7 @@ func addAPIToDashboard(dashboardName string, apiName string) error {
 		return err
 	}
 
-	err = addAPIToDashboardObject(dashboard, dashboardName, apiName)
+	err = addAPIToDashboardObject(dashboard, apiName)
 	if err != nil {
 		return err
 	}
@@ -54,7 +55,7 @@ func removeAPIFromDashboard(allAPINames []string, dashboardName string, apiToRem
 		if apiName == apiToRemove {
 			continue
 		}
-		err := addAPIToDashboardObject(dashboard, dashboardName, apiName)
+		err := addAPIToDashboardObject(dashboard, apiName)
 		if err != nil {
 			return err
 		}
@@ -68,7 +69,7 @@ func removeAPIFromDashboard(allAPINames []string, dashboardName string, apiToRem
 	return nil
 }
 
-func addAPIToDashboardObject(dashboard *aws.CloudWatchDashboard, dashboardName string, apiName string) error {
+func addAPIToDashboardObject(dashboard *aws.CloudWatchDashboard, apiName string) error {
 	// get lowest element on the dashboard (need to place new widgets below all existing widgets)
 	highestY, err := aws.HighestY(dashboard)
 	if err != nil {
@@ -84,15 +85,15 @@ func addAPIToDashboardObject(dashboard *aws.CloudWatchDashboard, dashboardName s
 	}
 
 	// first grid column
-	grid.AddWidget(statusCodeMetric(dashboardName, apiName), "responses per minute", "Sum", 60, config.AWS.Region)
-	grid.AddWidget(latencyMetric(dashboardName, apiName), "median response time (ms)", "p50", 60, config.AWS.Region)
-	grid.AddWidget(latencyMetric(dashboardName, apiName), "p99 response time (ms)", "p99", 60, config.AWS.Region)
+	grid.AddWidget(statusCodeMetric(apiName), "responses per minute", "Sum", 60, config.AWS.Region)
+	grid.AddWidget(latencyMetric(apiName), "median response time (ms)", "p50", 60, config.AWS.Region)
+	grid.AddWidget(latencyMetric(apiName), "p99 response time (ms)", "p99", 60, config.AWS.Region)
 
 	// second grid column
-	grid.AddWidget(inFlightMetric(dashboardName, apiName), "total in-flight requests", "Sum", 10, config.AWS.Region)
-	grid.AddWidget(inFlightMetric(dashboardName, apiName), "avg in-flight requests per replica", "Average", 10, config.AWS.Region)
+	grid.AddWidget(inFlightMetric(apiName), "total in-flight requests", "Sum", 10, config.AWS.Region)
+	grid.AddWidget(inFlightMetric(apiName), "avg in-flight requests per replica", "Average", 10, config.AWS.Region)
 	// setting the period to 10 seconds because the publishing frequency of the request monitor is 10 seconds
-	grid.AddWidget(inFlightMetric(dashboardName, apiName), "active replicas", "SampleCount", 10, config.AWS.Region)
+	grid.AddWidget(inFlightMetric(apiName), "active replicas", "SampleCount", 10, config.AWS.Region)
 
 	// append new API metrics widgets to existing widgets
 	dashboard.Widgets = append(dashboard.Widgets, grid.Widgets...)
@@ -100,50 +101,39 @@ func addAPIToDashboardObject(dashboard *aws.CloudWatchDashboard, dashboardName s
 	return nil
 }
 
-func inFlightMetric(dashboardName string, apiName string) []interface{} {
-	var metric []interface{}
-	metric = append(metric, dashboardName)
-	metric = append(metric, "in-flight")
-	metric = append(metric, "apiName")
-	metric = append(metric, apiName)
-
+func inFlightMetric(apiName string) []interface{} {
+	metric := metricWithDimensions("in-flight", "apiName", apiName)
 	return []interface{}{metric}
 }
 
-func latencyMetric(dashboardName string, apiName string) []interface{} {
-	var metric []interface{}
-	metric = append(metric, dashboardName)
-	metric = append(metric, "Latency")
-	metric = append(metric, "APIName")
-	metric = append(metric, apiName)
-	metric = append(metric, "metric_type")
-	metric = append(metric, "histogram")
-
+func latencyMetric(apiName string) []interface{} {
+	metric := metricWithDimensions("Latency", "APIName", apiName, "metric_type", "histogram")
 	return []interface{}{metric}
 }
 
-func statusCodeMetric(dashboardName string, apiName string) []interface{} {
-	var metric2XX []interface{}
-	metric2XX = append(metric2XX, dashboardName)
-	metric2XX = append(metric2XX, "StatusCode")
-	metric2XX = append(metric2XX, "APIName")
-	metric2XX = append(metric2XX, apiName)
-	metric2XX = append(metric2XX, "metric_type")
-	metric2XX = append(metric2XX, "counter")
-	metric2XX = append(metric2XX, "Code")
-	metric2XX = append(metric2XX, "2XX")
-
-	var metric4XX []interface{}
-	metric4XX = append(metric4XX, "...")
-	metric4XX = append(metric4XX, "4XX")
-
-	var metric5XX []interface{}
-	metric5XX = append(metric5XX, "...")
-	metric5XX = append(metric5XX, "5XX")
+func statusCodeMetric(apiName string) []interface{} {
+	metric2XX := metricWithDimensions("StatusCode", "APIName", apiName, "metric_type", "counter", "Code", "2XX")
+	metric4XX := metricWithDimensions("StatusCode", "APIName", apiName, "metric_type", "counter", "Code", "4XX")
+	metric5XX := metricWithDimensions("StatusCode", "APIName", apiName, "metric_type", "counter", "Code", "5XX")
 
 	return []interface{}{metric2XX, metric4XX, metric5XX}
 }
 
+// dimensions are specified as alternating names and values; the cluster's custom metrics dimensions are appended
+func metricWithDimensions(metricName string, dimensions ...string) []interface{} {
+	var metric []interface{}
+	metric = append(metric, config.Cluster.MetricsNamespace)
+	metric = append(metric, metricName)
+	for _, dimension := range dimensions {
+		metric = append(metric, dimension)
+	}
+	for _, dimension := range operator.MetricsDimensions() {
+		metric = append(metric, *dimension.Name)
+		metric = append(metric, *dimension.Value)
+	}
+	return metric
+}
+
 func DashboardURL() string {
 	return fmt.Sprintf("https://%s.console.aws.amazon.com/cloudwatch/home#dashboards:name=%s", *config.Cluster.Region, config.Cluster.ClusterName)
 }
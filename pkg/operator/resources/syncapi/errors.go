@@ -18,12 +18,24 @@ package syncapi
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 )
 
 const (
-	ErrAPIUpdating = "syncapi.api_updating"
+	ErrAPIUpdating                  = "syncapi.api_updating"
+	ErrInvalidLoadTestRPS           = "syncapi.invalid_load_test_rps"
+	ErrInvalidLoadTestDuration      = "syncapi.invalid_load_test_duration"
+	ErrLoadTestNotFound             = "syncapi.load_test_not_found"
+	ErrInvalidMaintenanceStatusCode = "syncapi.invalid_maintenance_status_code"
+	ErrInvalidReplayLimit           = "syncapi.invalid_replay_limit"
+	ErrInvalidReplayWindow          = "syncapi.invalid_replay_window"
+	ErrNoLoggedRequests             = "syncapi.no_logged_requests"
+	ErrReplayNotFound               = "syncapi.replay_not_found"
+	ErrGoldenDatasetGateRunning     = "syncapi.golden_dataset_gate_running"
+	ErrGoldenDatasetReplicaNotReady = "syncapi.golden_dataset_replica_not_ready"
+	ErrGoldenDatasetTimedOut        = "syncapi.golden_dataset_timed_out"
 )
 
 func ErrorAPIUpdating(apiName string) error {
@@ -32,3 +44,80 @@ func ErrorAPIUpdating(apiName string) error {
 		Message: fmt.Sprintf("%s is updating (override with --force)", apiName),
 	})
 }
+
+func ErrorInvalidLoadTestRPS(rps int, maxRPS int) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidLoadTestRPS,
+		Message: fmt.Sprintf("invalid requests per second (%d); must be between 1 and %d", rps, maxRPS),
+	})
+}
+
+func ErrorInvalidLoadTestDuration(duration time.Duration, maxDuration time.Duration) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidLoadTestDuration,
+		Message: fmt.Sprintf("invalid load test duration (%s); must be greater than 0s and at most %s", duration.String(), maxDuration.String()),
+	})
+}
+
+func ErrorLoadTestNotFound(apiName string, jobID string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrLoadTestNotFound,
+		Message: fmt.Sprintf("unable to find load test %s for api %s", jobID, apiName),
+	})
+}
+
+func ErrorInvalidMaintenanceStatusCode(statusCode int) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidMaintenanceStatusCode,
+		Message: fmt.Sprintf("invalid maintenance status code (%d); must be between 400 and 599", statusCode),
+	})
+}
+
+func ErrorInvalidReplayLimit(limit int, maxLimit int) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidReplayLimit,
+		Message: fmt.Sprintf("invalid number of requests to replay (%d); must be between 1 and %d", limit, maxLimit),
+	})
+}
+
+func ErrorInvalidReplayWindow(start time.Time, end time.Time) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidReplayWindow,
+		Message: fmt.Sprintf("the start of the replay window (%s) must be before its end (%s)", start.Format(time.RFC3339), end.Format(time.RFC3339)),
+	})
+}
+
+func ErrorNoLoggedRequests(apiName string, start time.Time, end time.Time) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrNoLoggedRequests,
+		Message: fmt.Sprintf("no requests to %s were logged between %s and %s (requests are only logged if request_log.sample_rate is set in the api's configuration)", apiName, start.Format(time.RFC3339), end.Format(time.RFC3339)),
+	})
+}
+
+func ErrorReplayNotFound(apiName string, replayID string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrReplayNotFound,
+		Message: fmt.Sprintf("unable to find replay %s for api %s", replayID, apiName),
+	})
+}
+
+func ErrorGoldenDatasetGateRunning(apiName string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrGoldenDatasetGateRunning,
+		Message: fmt.Sprintf("a previous update to %s is being validated against its golden dataset (override with --force)", apiName),
+	})
+}
+
+func ErrorGoldenDatasetReplicaNotReady(apiName string, timeout time.Duration) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrGoldenDatasetReplicaNotReady,
+		Message: fmt.Sprintf("the staging replica of the update to %s did not become ready within %s", apiName, timeout.String()),
+	})
+}
+
+func ErrorGoldenDatasetTimedOut(timeout time.Duration) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrGoldenDatasetTimedOut,
+		Message: fmt.Sprintf("the golden dataset was not evaluated within %s", timeout.String()),
+	})
+}
@@ -0,0 +1,488 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/k8s"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+	"github.com/cortexlabs/cortex/pkg/lib/telemetry"
+	"github.com/cortexlabs/cortex/pkg/lib/zip"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+	"github.com/cortexlabs/cortex/pkg/operator/operator"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+	"github.com/cortexlabs/cortex/pkg/types/spec"
+	"github.com/cortexlabs/cortex/pkg/types/userconfig"
+	kapps "k8s.io/api/apps/v1"
+	kcore "k8s.io/api/core/v1"
+)
+
+const (
+	_goldenDatasetRequestTimeout    = 60 * time.Second
+	_goldenDatasetEvaluationTimeout = 30 * time.Minute // after the staging replica is ready; gates which have not completed by then were interrupted (e.g. by an operator restart)
+	_goldenDatasetPollInterval      = 5 * time.Second
+	_goldenDatasetMaxMismatches     = 5 // the number of mismatched examples which are described in a blocked gate's message
+)
+
+// the responses of one version of an API to the examples in its golden dataset
+type goldenDatasetRun struct {
+	NumExamples int
+	NumMatches  int
+	Mismatches  []int     // the indexes of the examples which did not match
+	Latencies   []float64 // sorted, in milliseconds (requests which did not receive a response are excluded)
+}
+
+// startGoldenDatasetGate creates a staging replica of the updated API (which does not receive traffic), and validates it
+// against the API's golden dataset in the background; the update is only promoted if it doesn't regress
+func startGoldenDatasetGate(api *spec.API) error {
+	if _, err := config.K8s.ApplyDeployment(goldenDatasetDeploymentSpec(api)); err != nil {
+		return err
+	}
+
+	gate := schema.GoldenDatasetGate{
+		APIID:     api.ID,
+		Status:    schema.GoldenDatasetGateRunning,
+		StartedAt: time.Now(),
+	}
+	if err := config.AWS.UploadJSONToS3(gate, config.Cluster.Bucket, goldenDatasetGateKey(api.Name)); err != nil {
+		return err
+	}
+
+	go runGoldenDatasetGate(api, gate)
+
+	return nil
+}
+
+// GetGoldenDatasetGate returns the most recent golden dataset validation of an update to the API (nil if there hasn't been one)
+func GetGoldenDatasetGate(apiName string) (*schema.GoldenDatasetGate, error) {
+	key := goldenDatasetGateKey(apiName)
+	exists, err := config.AWS.IsS3File(config.Cluster.Bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	var gate schema.GoldenDatasetGate
+	if err := config.AWS.ReadJSONFromS3(&gate, config.Cluster.Bucket, key); err != nil {
+		return nil, err
+	}
+
+	if gate.Status == schema.GoldenDatasetGateRunning {
+		api, err := operator.DownloadAPISpec(apiName, gate.APIID)
+		if err != nil {
+			return nil, err
+		}
+		if time.Since(gate.StartedAt) > goldenDatasetGateTimeout(api) {
+			gate.Status = schema.GoldenDatasetGateFailed
+			gate.Message = "the validation was interrupted (the operator may have restarted)"
+		}
+	}
+
+	return &gate, nil
+}
+
+// cancelGoldenDatasetGate stops the validation of an update to the API which has been superseded (the update is not promoted)
+func cancelGoldenDatasetGate(apiName string) error {
+	if _, err := config.K8s.DeleteDeployment(goldenDatasetDeploymentName(apiName)); err != nil {
+		return err
+	}
+
+	gate, err := GetGoldenDatasetGate(apiName)
+	if err != nil || gate == nil {
+		return err
+	}
+	gate.Status = schema.GoldenDatasetGateFailed
+	gate.Message = "the validation was cancelled by a later deployment"
+	gate.EndedAt = pointer.Time(time.Now())
+
+	return config.AWS.UploadJSONToS3(gate, config.Cluster.Bucket, goldenDatasetGateKey(apiName))
+}
+
+func isGoldenDatasetGateRunning(apiName string) (bool, error) {
+	gate, err := GetGoldenDatasetGate(apiName)
+	if err != nil {
+		return false, err
+	}
+	return gate != nil && gate.Status == schema.GoldenDatasetGateRunning, nil
+}
+
+func runGoldenDatasetGate(api *spec.API, gate schema.GoldenDatasetGate) {
+	promoted, result, message, err := goldenDatasetGate(api)
+	if err != nil {
+		telemetry.Error(err)
+		errors.PrintError(err)
+		gate.Status = schema.GoldenDatasetGateFailed
+		gate.Message = errors.Message(err)
+	} else if promoted {
+		gate.Status = schema.GoldenDatasetGatePassed
+	} else {
+		gate.Status = schema.GoldenDatasetGateBlocked
+		gate.Message = message
+	}
+	gate.Result = result
+	gate.EndedAt = pointer.Time(time.Now())
+
+	// the gate was superseded by a newer update (which is being validated by its own gate)
+	if !isGoldenDatasetDeploymentCurrent(api) {
+		return
+	}
+
+	if _, err := config.K8s.DeleteDeployment(goldenDatasetDeploymentName(api.Name)); err != nil {
+		telemetry.Error(err)
+		errors.PrintError(err)
+	}
+
+	if err := config.AWS.UploadJSONToS3(gate, config.Cluster.Bucket, goldenDatasetGateKey(api.Name)); err != nil {
+		telemetry.Error(err)
+		errors.PrintError(err)
+	}
+}
+
+// returns whether the update was promoted, and if not, why
+func goldenDatasetGate(api *spec.API) (bool, *schema.GoldenDatasetGateResult, string, error) {
+	examples, err := readGoldenDataset(api)
+	if err != nil {
+		return false, nil, "", err
+	}
+
+	stagingPod, err := waitForGoldenDatasetPod(api)
+	if err != nil {
+		return false, nil, "", err
+	}
+
+	deadline := time.Now().Add(_goldenDatasetEvaluationTimeout)
+	client := &http.Client{
+		Timeout: _goldenDatasetRequestTimeout,
+	}
+
+	candidate, err := runGoldenDataset(client, stagingPod, examples, api.GoldenDataset.Tolerance, deadline)
+	if err != nil {
+		return false, nil, "", err
+	}
+
+	var baseline *goldenDatasetRun
+	if currentPod, err := currentAPIPod(api.Name); err != nil {
+		return false, nil, "", err
+	} else if currentPod != nil {
+		baseline, err = runGoldenDataset(client, currentPod, examples, api.GoldenDataset.Tolerance, deadline)
+		if err != nil {
+			return false, nil, "", err
+		}
+	}
+
+	result, message := evaluateGoldenDatasetGate(api.GoldenDataset, candidate, baseline)
+	if message != "" {
+		return false, result, message, nil
+	}
+
+	if !isGoldenDatasetDeploymentCurrent(api) {
+		return false, result, "", nil
+	}
+
+	if err := promoteAPI(api); err != nil {
+		return false, result, "", err
+	}
+
+	return true, result, "", nil
+}
+
+func promoteAPI(api *spec.API) error {
+	prevDeployment, _, prevVirtualService, err := getK8sResources(api.API)
+	if err != nil {
+		return err
+	}
+	if err := applyK8sResources(api, prevDeployment, prevVirtualService); err != nil {
+		return err
+	}
+	return operator.UpdateAPIGatewayK8s(prevVirtualService, api)
+}
+
+func readGoldenDataset(api *spec.API) ([]spec.GoldenExample, error) {
+	projectBytes, err := config.AWS.ReadBytesFromS3(config.Cluster.Bucket, api.ProjectKey)
+	if err != nil {
+		return nil, err
+	}
+	projectFiles, err := zip.UnzipMemToMem(projectBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	datasetBytes, ok := projectFiles[api.GoldenDataset.Path]
+	if !ok {
+		return nil, errors.ErrorUnexpected("unable to find the golden dataset in the project", api.GoldenDataset.Path)
+	}
+	return spec.ParseGoldenDataset(api.GoldenDataset.Path, datasetBytes)
+}
+
+func waitForGoldenDatasetPod(api *spec.API) (*kcore.Pod, error) {
+	timeout := time.After(api.GoldenDataset.Timeout)
+	for {
+		pods, err := config.K8s.ListPodsByLabels(map[string]string{
+			"goldenDatasetAPIName": api.Name,
+			"apiID":                api.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for i := range pods {
+			if k8s.IsPodReady(&pods[i]) {
+				return &pods[i], nil
+			}
+		}
+
+		select {
+		case <-timeout:
+			return nil, ErrorGoldenDatasetReplicaNotReady(api.Name, api.GoldenDataset.Timeout)
+		case <-time.After(_goldenDatasetPollInterval):
+		}
+	}
+}
+
+// returns a ready replica of the version of the API which is currently serving traffic (nil if none are ready)
+func currentAPIPod(apiName string) (*kcore.Pod, error) {
+	deployment, err := config.K8s.GetDeployment(operator.K8sName(apiName))
+	if err != nil || deployment == nil {
+		return nil, err
+	}
+
+	pods, err := config.K8s.ListPodsByLabels(map[string]string{
+		"apiName": apiName,
+		"apiID":   deployment.Labels["apiID"],
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range pods {
+		if k8s.IsPodReady(&pods[i]) {
+			return &pods[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// the examples are sent one at a time, so that the latencies of the two versions are comparable
+func runGoldenDataset(client *http.Client, pod *kcore.Pod, examples []spec.GoldenExample, tolerance float64, deadline time.Time) (*goldenDatasetRun, error) {
+	url := fmt.Sprintf("http://%s:%d/predict", pod.Status.PodIP, operator.DefaultPortInt32)
+	run := goldenDatasetRun{NumExamples: len(examples)}
+
+	for i, example := range examples {
+		if time.Now().After(deadline) {
+			return nil, ErrorGoldenDatasetTimedOut(_goldenDatasetEvaluationTimeout)
+		}
+
+		start := time.Now()
+		response, ok := sendGoldenExample(client, url, example.Payload)
+		if ok {
+			run.Latencies = append(run.Latencies, float64(time.Since(start))/float64(time.Millisecond))
+		}
+
+		if ok && goldenResponseMatches(example.Expected, response, tolerance) {
+			run.NumMatches++
+		} else {
+			run.Mismatches = append(run.Mismatches, i)
+		}
+	}
+
+	sort.Float64s(run.Latencies)
+	return &run, nil
+}
+
+// returns false if the request failed or did not receive a 2xx response
+func sendGoldenExample(client *http.Client, url string, payload []byte) ([]byte, bool) {
+	response, err := client.Post(url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return nil, false
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil || response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, false
+	}
+	return body, true
+}
+
+// responses which aren't json are compared to the expected value as strings
+func goldenResponseMatches(expected []byte, response []byte, tolerance float64) bool {
+	var expectedVal, responseVal interface{}
+	if err := json.Unmarshal(expected, &expectedVal); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(response, &responseVal); err != nil {
+		responseVal = string(response)
+	}
+	return valuesMatch(expectedVal, responseVal, tolerance)
+}
+
+// numbers match if they differ by at most tolerance (recursively, within lists and objects)
+func valuesMatch(expected interface{}, actual interface{}, tolerance float64) bool {
+	switch expectedVal := expected.(type) {
+	case float64:
+		actualVal, ok := actual.(float64)
+		return ok && math.Abs(expectedVal-actualVal) <= tolerance
+	case []interface{}:
+		actualVal, ok := actual.([]interface{})
+		if !ok || len(expectedVal) != len(actualVal) {
+			return false
+		}
+		for i := range expectedVal {
+			if !valuesMatch(expectedVal[i], actualVal[i], tolerance) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		actualVal, ok := actual.(map[string]interface{})
+		if !ok || len(expectedVal) != len(actualVal) {
+			return false
+		}
+		for key := range expectedVal {
+			if _, ok := actualVal[key]; !ok || !valuesMatch(expectedVal[key], actualVal[key], tolerance) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(expected, actual)
+	}
+}
+
+// returns why the update is blocked ("" if it may be promoted); without a baseline, the current version is assumed
+// to match every example, and the latency is not compared
+func evaluateGoldenDatasetGate(goldenDataset *userconfig.GoldenDataset, candidate *goldenDatasetRun, baseline *goldenDatasetRun) (*schema.GoldenDatasetGateResult, string) {
+	result := schema.GoldenDatasetGateResult{
+		NumExamples:  candidate.NumExamples,
+		Accuracy:     candidate.accuracy(),
+		LatencyP99Ms: percentile(candidate.Latencies, 0.99),
+	}
+
+	baselineAccuracy := 1.0
+	if baseline != nil {
+		baselineAccuracy = baseline.accuracy()
+		result.BaselineAccuracy = pointer.Float64(baselineAccuracy)
+		result.BaselineLatencyP99Ms = pointer.Float64(percentile(baseline.Latencies, 0.99))
+	}
+
+	if accuracyDrop := baselineAccuracy - result.Accuracy; accuracyDrop > goldenDataset.MaxAccuracyDrop+1e-9 {
+		message := fmt.Sprintf("the accuracy dropped from %s to %s (the maximum drop is %s)", percentStr(baselineAccuracy), percentStr(result.Accuracy), percentStr(goldenDataset.MaxAccuracyDrop))
+		if len(candidate.Mismatches) > 0 {
+			message += fmt.Sprintf("; mismatched examples: %s", mismatchesStr(candidate.Mismatches))
+		}
+		return &result, message
+	}
+
+	if goldenDataset.MaxLatencyIncrease != nil && result.BaselineLatencyP99Ms != nil && *result.BaselineLatencyP99Ms > 0 {
+		latencyIncrease := result.LatencyP99Ms / *result.BaselineLatencyP99Ms - 1
+		if latencyIncrease > *goldenDataset.MaxLatencyIncrease {
+			return &result, fmt.Sprintf("the p99 latency increased from %.0fms to %.0fms (the maximum increase is %s)", *result.BaselineLatencyP99Ms, result.LatencyP99Ms, percentStr(*goldenDataset.MaxLatencyIncrease))
+		}
+	}
+
+	return &result, ""
+}
+
+func (run *goldenDatasetRun) accuracy() float64 {
+	if run.NumExamples == 0 {
+		return 0
+	}
+	return float64(run.NumMatches) / float64(run.NumExamples)
+}
+
+func percentStr(fraction float64) string {
+	return fmt.Sprintf("%.1f%%", fraction*100)
+}
+
+func mismatchesStr(mismatches []int) string {
+	var str string
+	for i, index := range mismatches {
+		if i == _goldenDatasetMaxMismatches {
+			return str + fmt.Sprintf(", and %d more", len(mismatches)-_goldenDatasetMaxMismatches)
+		}
+		if i > 0 {
+			str += ", "
+		}
+		str += fmt.Sprintf("#%d", index)
+	}
+	return str
+}
+
+// whether the staging replica is still validating this version of the API (it's replaced when a newer update is deployed)
+func isGoldenDatasetDeploymentCurrent(api *spec.API) bool {
+	deployment, err := config.K8s.GetDeployment(goldenDatasetDeploymentName(api.Name))
+	if err != nil {
+		telemetry.Error(err)
+		errors.PrintError(err)
+		return false
+	}
+	return deployment != nil && deployment.Labels["apiID"] == api.ID
+}
+
+// the staging replica has no apiName label, so it's excluded from the API's service, status, and autoscaling
+func goldenDatasetDeploymentSpec(api *spec.API) *kapps.Deployment {
+	deployment := deploymentSpec(api, nil)
+	deployment.Name = goldenDatasetDeploymentName(api.Name)
+	deployment.Spec.Replicas = pointer.Int32(1)
+	deployment.Labels = map[string]string{
+		"goldenDatasetAPIName": api.Name,
+		"apiID":                api.ID,
+	}
+	deployment.Spec.Selector.MatchLabels = map[string]string{
+		"goldenDatasetAPIName": api.Name,
+	}
+	deployment.Spec.Template.Labels = map[string]string{
+		"goldenDatasetAPIName": api.Name,
+		"apiID":                api.ID,
+	}
+
+	var containers []kcore.Container
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		if container.Name != operator.RequestMonitorContainerName {
+			containers = append(containers, container)
+		}
+	}
+	deployment.Spec.Template.Spec.Containers = containers
+
+	return deployment
+}
+
+func goldenDatasetDeploymentName(apiName string) string {
+	return operator.K8sName(apiName) + "-golden"
+}
+
+func goldenDatasetGateTimeout(api *spec.API) time.Duration {
+	if api.GoldenDataset == nil {
+		return _goldenDatasetEvaluationTimeout
+	}
+	return api.GoldenDataset.Timeout + _goldenDatasetEvaluationTimeout
+}
+
+func goldenDatasetGateKey(apiName string) string {
+	return filepath.Join("apis", apiName, "golden_dataset", "gate.json")
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncapi
+
+import (
+	"testing"
+
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+	"github.com/cortexlabs/cortex/pkg/types/userconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoldenResponseMatches(t *testing.T) {
+	require.True(t, goldenResponseMatches([]byte(`{"label": "cat", "scores": [0.9, 0.1]}`), []byte(`{"scores":[0.91,0.09],"label":"cat"}`), 0.05))
+	require.False(t, goldenResponseMatches([]byte(`{"label": "cat", "scores": [0.9, 0.1]}`), []byte(`{"scores":[0.91,0.09],"label":"cat"}`), 0))
+	require.False(t, goldenResponseMatches([]byte(`{"label": "cat"}`), []byte(`{"label": "cat", "score": 1}`), 0))
+	require.False(t, goldenResponseMatches([]byte(`[1, 2]`), []byte(`[1, 2, 3]`), 1))
+	require.False(t, goldenResponseMatches([]byte(`1`), []byte(`"1"`), 1))
+
+	// responses which aren't json are compared as strings
+	require.True(t, goldenResponseMatches([]byte(`"positive"`), []byte(`positive`), 0))
+	require.False(t, goldenResponseMatches([]byte(`"positive"`), []byte(`negative`), 0))
+}
+
+func TestEvaluateGoldenDatasetGate(t *testing.T) {
+	goldenDataset := &userconfig.GoldenDataset{
+		MaxAccuracyDrop:    0.1,
+		MaxLatencyIncrease: pointer.Float64(0.5),
+	}
+
+	baseline := &goldenDatasetRun{NumExamples: 10, NumMatches: 9, Mismatches: []int{3}, Latencies: []float64{10, 20}}
+
+	result, message := evaluateGoldenDatasetGate(goldenDataset, &goldenDatasetRun{NumExamples: 10, NumMatches: 8, Mismatches: []int{3, 7}, Latencies: []float64{10, 25}}, baseline)
+	require.Empty(t, message)
+	require.Equal(t, 0.8, result.Accuracy)
+	require.Equal(t, 0.9, *result.BaselineAccuracy)
+
+	_, message = evaluateGoldenDatasetGate(goldenDataset, &goldenDatasetRun{NumExamples: 10, NumMatches: 7, Mismatches: []int{1, 3, 7}, Latencies: []float64{10, 20}}, baseline)
+	require.Contains(t, message, "accuracy dropped from 90.0% to 70.0%")
+	require.Contains(t, message, "#1, #3, #7")
+
+	_, message = evaluateGoldenDatasetGate(goldenDataset, &goldenDatasetRun{NumExamples: 10, NumMatches: 9, Mismatches: []int{3}, Latencies: []float64{10, 40}}, baseline)
+	require.Contains(t, message, "p99 latency increased from 20ms to 40ms")
+
+	// without a baseline, the current version is assumed to match every example
+	result, message = evaluateGoldenDatasetGate(goldenDataset, &goldenDatasetRun{NumExamples: 10, NumMatches: 9, Mismatches: []int{3}, Latencies: []float64{1000}}, nil)
+	require.Empty(t, message)
+	require.Nil(t, result.BaselineAccuracy)
+	_, message = evaluateGoldenDatasetGate(goldenDataset, &goldenDatasetRun{NumExamples: 10, NumMatches: 8, Mismatches: []int{3, 7}}, nil)
+	require.Contains(t, message, "accuracy dropped from 100.0% to 80.0%")
+}
+
+func TestMismatchesStr(t *testing.T) {
+	require.Equal(t, "#0", mismatchesStr([]int{0}))
+	require.Equal(t, "#0, #1, #2, #3, #4, and 2 more", mismatchesStr([]int{0, 1, 2, 3, 4, 5, 6}))
+}
@@ -17,9 +17,12 @@ limitations under the License.
 package syncapi
 
 import (
+	"github.com/cortexlabs/cortex/pkg/lib/json"
 	"github.com/cortexlabs/cortex/pkg/lib/k8s"
 	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+	s "github.com/cortexlabs/cortex/pkg/lib/strings"
 	"github.com/cortexlabs/cortex/pkg/operator/operator"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
 	"github.com/cortexlabs/cortex/pkg/types/spec"
 	"github.com/cortexlabs/cortex/pkg/types/userconfig"
 	istioclientnetworking "istio.io/client-go/pkg/apis/networking/v1alpha3"
@@ -28,22 +31,34 @@ import (
 )
 
 func deploymentSpec(api *spec.API, prevDeployment *kapps.Deployment) *kapps.Deployment {
+	var deployment *kapps.Deployment
 	switch api.Predictor.Type {
 	case userconfig.TensorFlowPredictorType:
-		return tensorflowAPISpec(api, prevDeployment)
+		deployment = tensorflowAPISpec(api, prevDeployment)
 	case userconfig.ONNXPredictorType:
-		return onnxAPISpec(api, prevDeployment)
+		deployment = onnxAPISpec(api, prevDeployment)
 	case userconfig.PythonPredictorType:
-		return pythonAPISpec(api, prevDeployment)
+		deployment = pythonAPISpec(api, prevDeployment)
 	default:
 		return nil // unexpected
 	}
+
+	operator.ApplySecurity(api, &deployment.Spec.Template)
+	operator.ApplyQoS(api, &deployment.Spec.Template)
+	operator.ApplySharedMemory(api, &deployment.Spec.Template)
+	operator.ApplyHugePages(api, &deployment.Spec.Template)
+	return deployment
 }
 
 func tensorflowAPISpec(api *spec.API, prevDeployment *kapps.Deployment) *kapps.Deployment {
-
 	containers, volumes := operator.TensorFlowPredictorContainers(api)
 	containers = append(containers, operator.RequestMonitorContainer(api))
+	initContainers := []kcore.Container{operator.InitContainer(api)}
+
+	if api.Networking.EgressAllowlist != nil {
+		initContainers = append(initContainers, operator.EgressInitContainer())
+		containers = append(containers, operator.EgressProxyContainer(api))
+	}
 
 	return k8s.Deployment(&k8s.DeploymentSpec{
 		Name:           operator.K8sName(api.Name),
@@ -55,8 +70,9 @@ func tensorflowAPISpec(api *spec.API, prevDeployment *kapps.Deployment) *kapps.D
 			"apiKind":      api.Kind.String(),
 			"apiID":        api.ID,
 			"deploymentID": api.DeploymentID,
+			"apiVersion":   s.Int(api.Version),
 		},
-		Annotations: api.ToK8sAnnotations(),
+		Annotations: deploymentAnnotations(api),
 		Selector: map[string]string{
 			"apiName": api.Name,
 			"apiKind": api.Kind.String(),
@@ -67,16 +83,15 @@ func tensorflowAPISpec(api *spec.API, prevDeployment *kapps.Deployment) *kapps.D
 				"apiKind":      api.Kind.String(),
 				"apiID":        api.ID,
 				"deploymentID": api.DeploymentID,
+				"apiVersion":   s.Int(api.Version),
 			},
 			Annotations: map[string]string{
 				"traffic.sidecar.istio.io/excludeOutboundIPRanges": "0.0.0.0/0",
 			},
 			K8sPodSpec: kcore.PodSpec{
-				RestartPolicy: "Always",
-				InitContainers: []kcore.Container{
-					operator.InitContainer(api),
-				},
-				Containers: containers,
+				RestartPolicy:  "Always",
+				InitContainers: initContainers,
+				Containers:     containers,
 				NodeSelector: map[string]string{
 					"workload": "true",
 				},
@@ -91,6 +106,12 @@ func tensorflowAPISpec(api *spec.API, prevDeployment *kapps.Deployment) *kapps.D
 func pythonAPISpec(api *spec.API, prevDeployment *kapps.Deployment) *kapps.Deployment {
 	containers, volumes := operator.PythonPredictorContainers(api)
 	containers = append(containers, operator.RequestMonitorContainer(api))
+	initContainers := []kcore.Container{operator.InitContainer(api)}
+
+	if api.Networking.EgressAllowlist != nil {
+		initContainers = append(initContainers, operator.EgressInitContainer())
+		containers = append(containers, operator.EgressProxyContainer(api))
+	}
 
 	return k8s.Deployment(&k8s.DeploymentSpec{
 		Name:           operator.K8sName(api.Name),
@@ -102,8 +123,9 @@ func pythonAPISpec(api *spec.API, prevDeployment *kapps.Deployment) *kapps.Deplo
 			"apiKind":      api.Kind.String(),
 			"apiID":        api.ID,
 			"deploymentID": api.DeploymentID,
+			"apiVersion":   s.Int(api.Version),
 		},
-		Annotations: api.ToK8sAnnotations(),
+		Annotations: deploymentAnnotations(api),
 		Selector: map[string]string{
 			"apiName": api.Name,
 			"apiKind": api.Kind.String(),
@@ -114,16 +136,15 @@ func pythonAPISpec(api *spec.API, prevDeployment *kapps.Deployment) *kapps.Deplo
 				"apiKind":      api.Kind.String(),
 				"apiID":        api.ID,
 				"deploymentID": api.DeploymentID,
+				"apiVersion":   s.Int(api.Version),
 			},
 			Annotations: map[string]string{
 				"traffic.sidecar.istio.io/excludeOutboundIPRanges": "0.0.0.0/0",
 			},
 			K8sPodSpec: kcore.PodSpec{
-				RestartPolicy: "Always",
-				InitContainers: []kcore.Container{
-					operator.InitContainer(api),
-				},
-				Containers: containers,
+				RestartPolicy:  "Always",
+				InitContainers: initContainers,
+				Containers:     containers,
 				NodeSelector: map[string]string{
 					"workload": "true",
 				},
@@ -138,6 +159,12 @@ func pythonAPISpec(api *spec.API, prevDeployment *kapps.Deployment) *kapps.Deplo
 func onnxAPISpec(api *spec.API, prevDeployment *kapps.Deployment) *kapps.Deployment {
 	containers := operator.ONNXPredictorContainers(api)
 	containers = append(containers, operator.RequestMonitorContainer(api))
+	initContainers := []kcore.Container{operator.InitContainer(api)}
+
+	if api.Networking.EgressAllowlist != nil {
+		initContainers = append(initContainers, operator.EgressInitContainer())
+		containers = append(containers, operator.EgressProxyContainer(api))
+	}
 
 	return k8s.Deployment(&k8s.DeploymentSpec{
 		Name:           operator.K8sName(api.Name),
@@ -149,8 +176,9 @@ func onnxAPISpec(api *spec.API, prevDeployment *kapps.Deployment) *kapps.Deploym
 			"apiKind":      api.Kind.String(),
 			"apiID":        api.ID,
 			"deploymentID": api.DeploymentID,
+			"apiVersion":   s.Int(api.Version),
 		},
-		Annotations: api.ToK8sAnnotations(),
+		Annotations: deploymentAnnotations(api),
 		Selector: map[string]string{
 			"apiName": api.Name,
 			"apiKind": api.Kind.String(),
@@ -161,15 +189,14 @@ func onnxAPISpec(api *spec.API, prevDeployment *kapps.Deployment) *kapps.Deploym
 				"apiKind":      api.Kind.String(),
 				"apiID":        api.ID,
 				"deploymentID": api.DeploymentID,
+				"apiVersion":   s.Int(api.Version),
 			},
 			Annotations: map[string]string{
 				"traffic.sidecar.istio.io/excludeOutboundIPRanges": "0.0.0.0/0",
 			},
 			K8sPodSpec: kcore.PodSpec{
-				InitContainers: []kcore.Container{
-					operator.InitContainer(api),
-				},
-				Containers: containers,
+				InitContainers: initContainers,
+				Containers:     containers,
 				NodeSelector: map[string]string{
 					"workload": "true",
 				},
@@ -181,6 +208,15 @@ func onnxAPISpec(api *spec.API, prevDeployment *kapps.Deployment) *kapps.Deploym
 	})
 }
 
+// deploymentAnnotations records the image verifications on the deployment, so that they can be reported in the API's status
+func deploymentAnnotations(api *spec.API) map[string]string {
+	annotations := api.ToK8sAnnotations()
+	if len(api.ImageVerifications) > 0 {
+		annotations[userconfig.ImageVerificationsAnnotationKey], _ = json.MarshalJSONStr(api.ImageVerifications)
+	}
+	return annotations
+}
+
 func serviceSpec(api *spec.API) *kcore.Service {
 	return k8s.Service(&k8s.ServiceSpec{
 		Name:        operator.K8sName(api.Name),
@@ -198,7 +234,17 @@ func serviceSpec(api *spec.API) *kcore.Service {
 	})
 }
 
-func virtualServiceSpec(api *spec.API) *istioclientnetworking.VirtualService {
+func virtualServiceSpec(api *spec.API, maintenance *schema.Maintenance) *istioclientnetworking.VirtualService {
+	annotations := api.ToK8sAnnotations()
+	var abortStatusCode int32
+	if maintenance != nil {
+		annotations[userconfig.MaintenanceStatusCodeAnnotationKey] = s.Int(maintenance.StatusCode)
+		if maintenance.Message != "" {
+			annotations[userconfig.MaintenanceMessageAnnotationKey] = maintenance.Message
+		}
+		abortStatusCode = int32(maintenance.StatusCode)
+	}
+
 	return k8s.VirtualService(&k8s.VirtualServiceSpec{
 		Name:     operator.K8sName(api.Name),
 		Gateways: []string{"apis-gateway"},
@@ -207,9 +253,11 @@ func virtualServiceSpec(api *spec.API) *istioclientnetworking.VirtualService {
 			Weight:      100,
 			Port:        uint32(operator.DefaultPortInt32),
 		}},
-		Path:        *api.Networking.Endpoint,
-		Rewrite:     pointer.String("predict"),
-		Annotations: api.ToK8sAnnotations(),
+		Path:            *api.Networking.Endpoint,
+		Rewrite:         pointer.String("predict"),
+		ResponseHeaders: deprecationResponseHeaders(api),
+		AbortStatusCode: abortStatusCode,
+		Annotations:     annotations,
 		Labels: map[string]string{
 			"apiName": api.Name,
 			"apiKind": api.Kind.String(),
@@ -217,6 +265,13 @@ func virtualServiceSpec(api *spec.API) *istioclientnetworking.VirtualService {
 	})
 }
 
+func deprecationResponseHeaders(api *spec.API) map[string]string {
+	if api.Deprecation == nil {
+		return nil
+	}
+	return operator.DeprecationResponseHeaders(api.Deprecation.SunsetDate)
+}
+
 func getRequestedReplicasFromDeployment(api *spec.API, deployment *kapps.Deployment) int32 {
 	requestedReplicas := api.Autoscaling.InitReplicas
 
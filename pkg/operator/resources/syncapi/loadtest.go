@@ -0,0 +1,352 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncapi
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/k8s"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+	s "github.com/cortexlabs/cortex/pkg/lib/strings"
+	"github.com/cortexlabs/cortex/pkg/lib/telemetry"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+	"github.com/cortexlabs/cortex/pkg/operator/operator"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+	kbatch "k8s.io/api/batch/v1"
+	kcore "k8s.io/api/core/v1"
+	kresource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	MaxLoadTestRPS      = 1000
+	MaxLoadTestDuration = 5 * time.Minute
+
+	_loadTestStartTimeout = 10 * time.Minute // time allowed for the load tester's pod to be scheduled and started
+	_loadTestPollPeriod   = 5 * time.Second
+	_loadTestLogLines     = 40
+	_apisGatewayURL       = "http://ingressgateway-apis.istio-system" // the in-cluster service of the api load balancer (also used by replays)
+
+	_loadTestPayloadFileName  = "payload.json"
+	_loadTestResultsFileName  = "results.json"
+	_loadTestReplicasFileName = "replicas.json"
+	_loadTestErrorFileName    = "error.txt"
+)
+
+// the raw results uploaded by the load tester (must be kept in sync with images/load-tester/load-tester.go)
+type loadTestResults struct {
+	ElapsedSeconds float64   `json:"elapsed_seconds"`
+	StatusCodes    []int     `json:"status_codes"` // 0 if the request could not be made
+	LatenciesMs    []float64 `json:"latencies_ms"`
+}
+
+// StartLoadTest creates a short-lived job which sends requests at a fixed rate to the API through the API load
+// balancer's in-cluster service, and returns immediately; the results can be retrieved with GetLoadTest
+func StartLoadTest(apiName string, payload []byte, rps int, duration time.Duration) (*schema.LoadTestResponse, error) {
+	if rps <= 0 || rps > MaxLoadTestRPS {
+		return nil, ErrorInvalidLoadTestRPS(rps, MaxLoadTestRPS)
+	}
+	if duration <= 0 || duration > MaxLoadTestDuration {
+		return nil, ErrorInvalidLoadTestDuration(duration, MaxLoadTestDuration)
+	}
+
+	virtualService, err := config.K8s.GetVirtualService(operator.K8sName(apiName))
+	if err != nil {
+		return nil, err
+	} else if virtualService == nil {
+		return nil, errors.ErrorUnexpected("unable to find virtual service", apiName)
+	}
+	endpoint, err := operator.GetEndpointFromVirtualService(virtualService)
+	if err != nil {
+		return nil, err
+	}
+
+	jobID := k8s.RandomName()[:10]
+
+	if err := config.AWS.UploadBytesToS3(payload, config.Cluster.Bucket, loadTestKey(apiName, jobID, _loadTestPayloadFileName)); err != nil {
+		return nil, err
+	}
+
+	if _, err := config.K8s.CreateJob(loadTestJobSpec(apiName, jobID, _apisGatewayURL+endpoint, rps, duration)); err != nil {
+		return nil, err
+	}
+
+	go monitorLoadTest(apiName, jobID, duration)
+
+	return &schema.LoadTestResponse{
+		JobID:  jobID,
+		Status: schema.LoadTestRunning,
+	}, nil
+}
+
+// GetLoadTest returns the status of the load test, and its results once it has completed
+func GetLoadTest(apiName string, jobID string) (*schema.LoadTestResponse, error) {
+	response := schema.LoadTestResponse{
+		JobID: jobID,
+	}
+
+	resultsKey := loadTestKey(apiName, jobID, _loadTestResultsFileName)
+	hasResults, err := config.AWS.IsS3File(config.Cluster.Bucket, resultsKey)
+	if err != nil {
+		return nil, err
+	}
+	if hasResults {
+		var results loadTestResults
+		if err := config.AWS.ReadJSONFromS3(&results, config.Cluster.Bucket, resultsKey); err != nil {
+			return nil, err
+		}
+
+		// the replica samples are uploaded by the operator while the load test is running, so they may be missing (e.g. if the operator restarted)
+		var replicaSamples []schema.LoadTestReplicaSample
+		replicasKey := loadTestKey(apiName, jobID, _loadTestReplicasFileName)
+		if hasReplicas, err := config.AWS.IsS3File(config.Cluster.Bucket, replicasKey); err == nil && hasReplicas {
+			if err := config.AWS.ReadJSONFromS3(&replicaSamples, config.Cluster.Bucket, replicasKey); err != nil {
+				telemetry.Error(err)
+			}
+		}
+
+		response.Status = schema.LoadTestSucceeded
+		response.Result = summarizeLoadTest(results, replicaSamples)
+		return &response, nil
+	}
+
+	errorKey := loadTestKey(apiName, jobID, _loadTestErrorFileName)
+	hasError, err := config.AWS.IsS3File(config.Cluster.Bucket, errorKey)
+	if err != nil {
+		return nil, err
+	}
+	if hasError {
+		message, err := config.AWS.ReadStringFromS3(config.Cluster.Bucket, errorKey)
+		if err != nil {
+			return nil, err
+		}
+		response.Status = schema.LoadTestFailed
+		response.Message = message
+		return &response, nil
+	}
+
+	job, err := config.K8s.GetJob(loadTestJobName(jobID))
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, ErrorLoadTestNotFound(apiName, jobID)
+	}
+
+	if job.Status.Failed > 0 {
+		response.Status = schema.LoadTestFailed
+		response.Message = loadTestLogs(jobID)
+		return &response, nil
+	}
+
+	response.Status = schema.LoadTestRunning
+	return &response, nil
+}
+
+// samples the API's replica counts until the load test job completes, records the job's logs if it fails, and
+// deletes the job (if the operator restarts, the job will still be stopped by its active deadline)
+func monitorLoadTest(apiName string, jobID string, duration time.Duration) {
+	jobName := loadTestJobName(jobID)
+	defer func() {
+		if _, err := config.K8s.DeleteJob(jobName); err != nil {
+			telemetry.Error(err)
+		}
+	}()
+
+	replicaSamples := []schema.LoadTestReplicaSample{}
+	deadline := time.Now().Add(_loadTestStartTimeout + duration)
+
+	for time.Now().Before(deadline) {
+		job, err := config.K8s.GetJob(jobName)
+		if err != nil {
+			telemetry.Error(err)
+		} else if job == nil {
+			return
+		} else if job.Status.Failed > 0 {
+			uploadLoadTestError(apiName, jobID, loadTestLogs(jobID))
+			return
+		} else if job.Status.Active > 0 || job.Status.Succeeded > 0 {
+			if sample := sampleReplicas(apiName); sample != nil {
+				replicaSamples = append(replicaSamples, *sample)
+				if err := config.AWS.UploadJSONToS3(replicaSamples, config.Cluster.Bucket, loadTestKey(apiName, jobID, _loadTestReplicasFileName)); err != nil {
+					telemetry.Error(err)
+				}
+			}
+			if job.Status.Succeeded > 0 {
+				return
+			}
+		}
+
+		time.Sleep(_loadTestPollPeriod)
+	}
+
+	uploadLoadTestError(apiName, jobID, fmt.Sprintf("the load test did not complete within %s", (_loadTestStartTimeout+duration).String()))
+}
+
+func uploadLoadTestError(apiName string, jobID string, message string) {
+	if err := config.AWS.UploadStringToS3(message, config.Cluster.Bucket, loadTestKey(apiName, jobID, _loadTestErrorFileName)); err != nil {
+		telemetry.Error(err)
+	}
+}
+
+func loadTestJobSpec(apiName string, jobID string, url string, rps int, duration time.Duration) *kbatch.Job {
+	labels := map[string]string{
+		"loadTest":        "true",
+		"loadTestAPIName": apiName, // not "apiName", so that the load tester's pod isn't counted as a replica of the API
+		"loadTestID":      jobID,
+	}
+
+	job := k8s.Job(&k8s.JobSpec{
+		Name:   loadTestJobName(jobID),
+		Labels: labels,
+		PodSpec: k8s.PodSpec{
+			Labels: labels,
+			K8sPodSpec: kcore.PodSpec{
+				RestartPolicy: "Never",
+				Containers: []kcore.Container{
+					{
+						Name:            "load-tester",
+						Image:           config.Cluster.ImageLoadTester,
+						ImagePullPolicy: kcore.PullAlways,
+						Args: []string{
+							url,
+							s.Int(rps),
+							s.Int(int(duration.Seconds())),
+							loadTestKey(apiName, jobID, _loadTestPayloadFileName),
+							loadTestKey(apiName, jobID, _loadTestResultsFileName),
+						},
+						EnvFrom: operator.BaseEnvVars,
+						Resources: kcore.ResourceRequirements{
+							Requests: kcore.ResourceList{
+								kcore.ResourceCPU:    kresource.MustParse("500m"),
+								kcore.ResourceMemory: kresource.MustParse("256Mi"),
+							},
+						},
+					},
+				},
+				NodeSelector: map[string]string{
+					"workload": "true",
+				},
+				Tolerations:        operator.Tolerations,
+				ServiceAccountName: "default",
+			},
+		},
+	})
+
+	job.Spec.BackoffLimit = pointer.Int32(0)
+	job.Spec.ActiveDeadlineSeconds = pointer.Int64(int64((_loadTestStartTimeout + duration).Seconds()))
+
+	return job
+}
+
+func loadTestJobName(jobID string) string {
+	return "load-test-" + jobID
+}
+
+func loadTestKey(apiName string, jobID string, fileName string) string {
+	return filepath.Join("apis", apiName, "loadtests", jobID, fileName)
+}
+
+// Returns the tail of the load tester's logs (or a placeholder if they can't be retrieved)
+func loadTestLogs(jobID string) string {
+	pods, err := config.K8s.ListPodsByLabel("job-name", loadTestJobName(jobID))
+	if err != nil || len(pods) == 0 {
+		return "the load test failed (unable to retrieve the logs of the load tester)"
+	}
+
+	logs, err := config.K8s.GetPodLogs(pods[0].Name, "load-tester", pointer.Int64(_loadTestLogLines))
+	if err != nil {
+		return "the load test failed (unable to retrieve the logs of the load tester)"
+	}
+	return logs
+}
+
+// returns nil if the deployment could not be retrieved (the sample is skipped)
+func sampleReplicas(apiName string) *schema.LoadTestReplicaSample {
+	deployment, err := config.K8s.GetDeployment(operator.K8sName(apiName))
+	if err != nil || deployment == nil {
+		return nil
+	}
+
+	var requested int32
+	if deployment.Spec.Replicas != nil {
+		requested = *deployment.Spec.Replicas
+	}
+
+	return &schema.LoadTestReplicaSample{
+		Timestamp: time.Now(),
+		Requested: requested,
+		Ready:     deployment.Status.ReadyReplicas,
+	}
+}
+
+func summarizeLoadTest(results loadTestResults, replicaSamples []schema.LoadTestReplicaSample) *schema.LoadTestResult {
+	result := schema.LoadTestResult{
+		NumRequests:    len(results.StatusCodes),
+		StatusCodes:    map[int]int{},
+		ReplicaSamples: replicaSamples,
+	}
+
+	if results.ElapsedSeconds > 0 {
+		result.ActualRPS = float64(len(results.StatusCodes)) / results.ElapsedSeconds
+	}
+
+	for _, statusCode := range results.StatusCodes {
+		if statusCode == 0 {
+			result.NumConnectionErrors++
+		} else {
+			result.StatusCodes[statusCode]++
+		}
+		if statusCode < 200 || statusCode >= 300 {
+			result.NumErrors++
+		}
+	}
+
+	latencies := make([]float64, len(results.LatenciesMs))
+	copy(latencies, results.LatenciesMs)
+	sort.Float64s(latencies)
+	result.LatencyP50 = percentile(latencies, 0.50)
+	result.LatencyP90 = percentile(latencies, 0.90)
+	result.LatencyP99 = percentile(latencies, 0.99)
+	if len(latencies) > 0 {
+		result.LatencyMax = latencies[len(latencies)-1]
+	}
+
+	for _, sample := range replicaSamples {
+		if sample.Requested > result.MaxRequestedReplicas {
+			result.MaxRequestedReplicas = sample.Requested
+		}
+	}
+
+	return &result
+}
+
+// sortedVals must be sorted in ascending order
+func percentile(sortedVals []float64, p float64) float64 {
+	if len(sortedVals) == 0 {
+		return 0
+	}
+	index := int(math.Ceil(p*float64(len(sortedVals)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return sortedVals[index]
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentile(t *testing.T) {
+	require.Equal(t, 0.0, percentile(nil, 0.5))
+	require.Equal(t, 0.0, percentile([]float64{}, 0.99))
+
+	require.Equal(t, 7.0, percentile([]float64{7}, 0))
+	require.Equal(t, 7.0, percentile([]float64{7}, 0.5))
+	require.Equal(t, 7.0, percentile([]float64{7}, 1))
+
+	vals := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	require.Equal(t, 1.0, percentile(vals, 0))
+	require.Equal(t, 5.0, percentile(vals, 0.5))
+	require.Equal(t, 9.0, percentile(vals, 0.9))
+	require.Equal(t, 10.0, percentile(vals, 0.99))
+	require.Equal(t, 10.0, percentile(vals, 1))
+}
+
+func TestSummarizeLoadTestEmpty(t *testing.T) {
+	result := summarizeLoadTest(loadTestResults{}, nil)
+	require.Equal(t, 0, result.NumRequests)
+	require.Equal(t, 0, result.NumErrors)
+	require.Equal(t, 0, result.NumConnectionErrors)
+	require.Empty(t, result.StatusCodes)
+	require.Equal(t, 0.0, result.ActualRPS)
+	require.Equal(t, 0.0, result.LatencyP50)
+	require.Equal(t, 0.0, result.LatencyP99)
+	require.Equal(t, 0.0, result.LatencyMax)
+	require.Equal(t, int32(0), result.MaxRequestedReplicas)
+}
+
+func TestSummarizeLoadTestSingleSample(t *testing.T) {
+	result := summarizeLoadTest(loadTestResults{
+		ElapsedSeconds: 2,
+		StatusCodes:    []int{200},
+		LatenciesMs:    []float64{12.5},
+	}, nil)
+	require.Equal(t, 1, result.NumRequests)
+	require.Equal(t, 0, result.NumErrors)
+	require.Equal(t, map[int]int{200: 1}, result.StatusCodes)
+	require.Equal(t, 0.5, result.ActualRPS)
+	require.Equal(t, 12.5, result.LatencyP50)
+	require.Equal(t, 12.5, result.LatencyP90)
+	require.Equal(t, 12.5, result.LatencyP99)
+	require.Equal(t, 12.5, result.LatencyMax)
+}
+
+func TestSummarizeLoadTest(t *testing.T) {
+	now := time.Now()
+	replicaSamples := []schema.LoadTestReplicaSample{
+		{Timestamp: now, Requested: 1, Ready: 1},
+		{Timestamp: now.Add(5 * time.Second), Requested: 3, Ready: 1},
+		{Timestamp: now.Add(10 * time.Second), Requested: 2, Ready: 2},
+	}
+
+	results := loadTestResults{
+		ElapsedSeconds: 1,
+		StatusCodes:    []int{200, 200, 503, 0},
+		LatenciesMs:    []float64{40, 10, 30, 20},
+	}
+
+	result := summarizeLoadTest(results, replicaSamples)
+	require.Equal(t, 4, result.NumRequests)
+	require.Equal(t, 2, result.NumErrors)
+	require.Equal(t, 1, result.NumConnectionErrors)
+	require.Equal(t, map[int]int{200: 2, 503: 1}, result.StatusCodes)
+	require.Equal(t, 4.0, result.ActualRPS)
+	require.Equal(t, 20.0, result.LatencyP50)
+	require.Equal(t, 40.0, result.LatencyP99)
+	require.Equal(t, 40.0, result.LatencyMax)
+	require.Equal(t, int32(3), result.MaxRequestedReplicas)
+	require.Equal(t, replicaSamples, result.ReplicaSamples)
+
+	// the raw results are not reordered
+	require.Equal(t, []float64{40, 10, 30, 20}, results.LatenciesMs)
+}
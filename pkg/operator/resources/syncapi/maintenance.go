@@ -0,0 +1,97 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncapi
+
+import (
+	"fmt"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/k8s"
+	s "github.com/cortexlabs/cortex/pkg/lib/strings"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+	"github.com/cortexlabs/cortex/pkg/operator/operator"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+	"github.com/cortexlabs/cortex/pkg/types/userconfig"
+	istioclientnetworking "istio.io/client-go/pkg/apis/networking/v1alpha3"
+)
+
+const DefaultMaintenanceStatusCode = 503
+
+// SetMaintenance puts the API into maintenance mode (or takes it out of maintenance mode if maintenance is nil).
+// While in maintenance mode, requests are responded to at the gateway, and the API's replicas are left running
+func SetMaintenance(apiName string, maintenance *schema.Maintenance) (string, error) {
+	if maintenance != nil && (maintenance.StatusCode < 400 || maintenance.StatusCode > 599) {
+		return "", ErrorInvalidMaintenanceStatusCode(maintenance.StatusCode)
+	}
+
+	deployment, err := config.K8s.GetDeployment(operator.K8sName(apiName))
+	if err != nil {
+		return "", err
+	} else if deployment == nil {
+		return "", errors.ErrorUnexpected("unable to find deployment", apiName)
+	}
+
+	apiID, err := k8s.GetLabel(deployment, "apiID")
+	if err != nil {
+		return "", err
+	}
+
+	api, err := operator.DownloadAPISpec(apiName, apiID)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := config.K8s.ApplyVirtualService(virtualServiceSpec(api, maintenance)); err != nil {
+		return "", err
+	}
+
+	if maintenance == nil {
+		return fmt.Sprintf("%s is no longer in maintenance mode", apiName), nil
+	}
+	return fmt.Sprintf("%s is in maintenance mode (requests will receive status code %d)", apiName, maintenance.StatusCode), nil
+}
+
+// Returns nil if the API is not in maintenance mode
+func GetMaintenance(apiName string) (*schema.Maintenance, error) {
+	virtualService, err := config.K8s.GetVirtualService(operator.K8sName(apiName))
+	if err != nil {
+		return nil, err
+	}
+	return maintenanceFromVirtualService(virtualService)
+}
+
+// Returns nil if the virtual service is nil or the API is not in maintenance mode
+func maintenanceFromVirtualService(virtualService *istioclientnetworking.VirtualService) (*schema.Maintenance, error) {
+	if virtualService == nil {
+		return nil, nil
+	}
+
+	statusCodeStr, ok := virtualService.Annotations[userconfig.MaintenanceStatusCodeAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+
+	statusCode, ok := s.ParseInt(statusCodeStr)
+	if !ok {
+		return nil, k8s.ErrorParseAnnotation(userconfig.MaintenanceStatusCodeAnnotationKey, statusCodeStr, "int")
+	}
+
+	return &schema.Maintenance{
+		StatusCode: statusCode,
+		Message:    virtualService.Annotations[userconfig.MaintenanceMessageAnnotationKey],
+	}, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncapi
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/cortexlabs/cortex/pkg/lib/aws"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/k8s"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+	"github.com/cortexlabs/cortex/pkg/operator/operator"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+	"github.com/cortexlabs/cortex/pkg/types/spec"
+)
+
+// GetManifest returns the image digests of the API's running containers and the environment which its replicas
+// recorded at startup, which together describe how to reproduce the API's current deployment
+func GetManifest(apiName string) (*schema.GetManifestResponse, error) {
+	deployment, err := config.K8s.GetDeployment(operator.K8sName(apiName))
+	if err != nil {
+		return nil, err
+	} else if deployment == nil {
+		return nil, errors.ErrorUnexpected("unable to find deployment", apiName)
+	}
+
+	apiID, err := k8s.GetLabel(deployment, "apiID")
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := containerImages(apiName, apiID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := schema.GetManifestResponse{
+		APIName: apiName,
+		APIID:   apiID,
+		Images:  images,
+	}
+
+	var environment schema.Environment
+	if err := config.AWS.ReadJSONFromS3(&environment, config.Cluster.Bucket, spec.EnvironmentKey(apiName, apiID)); err != nil {
+		if aws.IsNoSuchKeyErr(err) {
+			return &response, nil // no replica has started yet
+		}
+		return nil, err
+	}
+	response.Environment = &environment
+
+	return &response, nil
+}
+
+// image digests are only known once a container has been started, so they are read from the API's running pods
+func containerImages(apiName string, apiID string) ([]schema.ContainerImage, error) {
+	pods, err := config.K8s.ListPodsByLabels(map[string]string{"apiName": apiName, "apiID": apiID})
+	if err != nil {
+		return nil, err
+	}
+
+	imagesByContainer := map[string]schema.ContainerImage{}
+	for _, pod := range pods {
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.ImageID == "" {
+				continue
+			}
+			if _, ok := imagesByContainer[containerStatus.Name]; ok {
+				continue
+			}
+			imagesByContainer[containerStatus.Name] = schema.ContainerImage{
+				Container:   containerStatus.Name,
+				Image:       containerStatus.Image,
+				ImageDigest: imageDigest(containerStatus.ImageID),
+			}
+		}
+	}
+
+	images := make([]schema.ContainerImage, 0, len(imagesByContainer))
+	for _, image := range imagesByContainer {
+		images = append(images, image)
+	}
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].Container < images[j].Container
+	})
+
+	return images, nil
+}
+
+// e.g. docker-pullable://cortexlabs/python-predictor-cpu@sha256:abc... -> sha256:abc...
+func imageDigest(imageID string) string {
+	if idx := strings.LastIndex(imageID, "@"); idx != -1 {
+		return imageID[idx+1:]
+	}
+	return strings.TrimPrefix(imageID, "docker://")
+}
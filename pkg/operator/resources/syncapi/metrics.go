@@ -31,6 +31,7 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/pointer"
 	"github.com/cortexlabs/cortex/pkg/lib/slices"
 	"github.com/cortexlabs/cortex/pkg/operator/config"
+	"github.com/cortexlabs/cortex/pkg/operator/operator"
 	"github.com/cortexlabs/cortex/pkg/types/metrics"
 	"github.com/cortexlabs/cortex/pkg/types/spec"
 	"github.com/cortexlabs/cortex/pkg/types/userconfig"
@@ -38,26 +39,23 @@ import (
 
 func GetMultipleMetrics(apis []spec.API) ([]metrics.Metrics, error) {
 	allMetrics := make([]metrics.Metrics, len(apis))
-	fns := make([]func() error, len(apis))
+	group := operator.ParallelGroup("get metrics", operator.MaxParallelAWSRequests)
 
 	for i := range apis {
 		localIdx := i
 		api := apis[i]
-		fns[i] = func() error {
+		group.Go(api.Name, func() error {
 			metrics, err := GetMetrics(&api)
 			if err != nil {
 				return err
 			}
 			allMetrics[localIdx] = *metrics
 			return nil
-		}
+		})
 	}
 
-	if len(fns) > 0 {
-		err := parallel.RunFirstErr(fns[0], fns[1:]...)
-		if err != nil {
-			return nil, err
-		}
+	if err := group.WaitFirstErr(); err != nil {
+		return nil, err
 	}
 
 	return allMetrics, nil
@@ -145,6 +143,49 @@ func queryMetrics(api *spec.API, period int64, startTime *time.Time, endTime *ti
 	return output.MetricDataResults, nil
 }
 
+// GetRequestCount returns the number of requests which the API received between start and end (metrics are recorded per API
+// ID, which changes when the API is updated, so the IDs which the API had during that time must be provided)
+func GetRequestCount(apiName string, apiIDs []string, start time.Time, end time.Time) (int64, error) {
+	if len(apiIDs) == 0 {
+		return 0, nil
+	}
+
+	metricDataQueries := make([]*cloudwatch.MetricDataQuery, len(apiIDs))
+	for i, apiID := range apiIDs {
+		api := &spec.API{
+			API: &userconfig.API{Resource: userconfig.Resource{Name: apiName}},
+			ID:  apiID,
+		}
+		metricDataQueries[i] = &cloudwatch.MetricDataQuery{
+			Id: aws.String(fmt.Sprintf("request_count_%d", i)),
+			MetricStat: &cloudwatch.MetricStat{
+				Metric: &cloudwatch.Metric{
+					Namespace:  aws.String(config.Cluster.MetricsNamespace),
+					MetricName: aws.String("Latency"),
+					Dimensions: getAPIDimensionsHistogram(api),
+				},
+				Stat:   aws.String("SampleCount"),
+				Period: aws.Int64(int64(end.Sub(start).Seconds())),
+			},
+		}
+	}
+
+	output, err := config.AWS.CloudWatch().GetMetricData(&cloudwatch.GetMetricDataInput{
+		StartTime:         &start,
+		EndTime:           &end,
+		MetricDataQueries: metricDataQueries,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var requestCount int64
+	for _, metricDataResult := range output.MetricDataResults {
+		requestCount += int64(slices.Float64PtrSumInt(metricDataResult.Values...))
+	}
+	return requestCount, nil
+}
+
 func extractNetworkMetrics(metricsDataResults []*cloudwatch.MetricDataResult) (*metrics.NetworkStats, error) {
 	var networkStats metrics.NetworkStats
 	var requestCounts []*float64
@@ -227,16 +268,19 @@ func extractRegressionMetrics(metricsDataResults []*cloudwatch.MetricDataResult)
 }
 
 func getAPIDimensions(api *spec.API) []*cloudwatch.Dimension {
-	return []*cloudwatch.Dimension{
-		{
-			Name:  aws.String("APIName"),
-			Value: aws.String(api.Name),
-		},
-		{
-			Name:  aws.String("APIID"),
-			Value: aws.String(api.ID),
+	return append(
+		[]*cloudwatch.Dimension{
+			{
+				Name:  aws.String("APIName"),
+				Value: aws.String(api.Name),
+			},
+			{
+				Name:  aws.String("APIID"),
+				Value: aws.String(api.ID),
+			},
 		},
-	}
+		operator.MetricsDimensions()...,
+	)
 }
 
 func getAPIDimensionsCounter(api *spec.API) []*cloudwatch.Dimension {
@@ -261,7 +305,7 @@ func getAPIDimensionsHistogram(api *spec.API) []*cloudwatch.Dimension {
 
 func getRegressionMetricDef(api *spec.API, period int64) []*cloudwatch.MetricDataQuery {
 	metric := &cloudwatch.Metric{
-		Namespace:  aws.String(config.Cluster.ClusterName),
+		Namespace:  aws.String(config.Cluster.MetricsNamespace),
 		MetricName: aws.String("Prediction"),
 		Dimensions: getAPIDimensionsHistogram(api),
 	}
@@ -323,7 +367,7 @@ func getNetworkStatsDef(api *spec.API, period int64) []*cloudwatch.MetricDataQue
 			Label: aws.String(code),
 			MetricStat: &cloudwatch.MetricStat{
 				Metric: &cloudwatch.Metric{
-					Namespace:  aws.String(config.Cluster.ClusterName),
+					Namespace:  aws.String(config.Cluster.MetricsNamespace),
 					MetricName: aws.String("StatusCode"),
 					Dimensions: statusCodeDimensions,
 				},
@@ -338,7 +382,7 @@ func getNetworkStatsDef(api *spec.API, period int64) []*cloudwatch.MetricDataQue
 		Label: aws.String("Latency"),
 		MetricStat: &cloudwatch.MetricStat{
 			Metric: &cloudwatch.Metric{
-				Namespace:  aws.String(config.Cluster.ClusterName),
+				Namespace:  aws.String(config.Cluster.MetricsNamespace),
 				MetricName: aws.String("Latency"),
 				Dimensions: getAPIDimensionsHistogram(api),
 			},
@@ -352,7 +396,7 @@ func getNetworkStatsDef(api *spec.API, period int64) []*cloudwatch.MetricDataQue
 		Label: aws.String("RequestCount"),
 		MetricStat: &cloudwatch.MetricStat{
 			Metric: &cloudwatch.Metric{
-				Namespace:  aws.String(config.Cluster.ClusterName),
+				Namespace:  aws.String(config.Cluster.MetricsNamespace),
 				MetricName: aws.String("Latency"),
 				Dimensions: getAPIDimensionsHistogram(api),
 			},
@@ -394,7 +438,7 @@ func getClassesMetricDef(api *spec.API, period int64) ([]*cloudwatch.MetricDataQ
 			Id: aws.String(fmt.Sprintf("id_%d", i)),
 			MetricStat: &cloudwatch.MetricStat{
 				Metric: &cloudwatch.Metric{
-					Namespace:  aws.String(config.Cluster.ClusterName),
+					Namespace:  aws.String(config.Cluster.MetricsNamespace),
 					MetricName: aws.String("Prediction"),
 					Dimensions: append(getAPIDimensionsCounter(api), &cloudwatch.Dimension{
 						Name:  aws.String("Class"),
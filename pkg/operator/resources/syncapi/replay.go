@@ -0,0 +1,380 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/k8s"
+	"github.com/cortexlabs/cortex/pkg/lib/parallel"
+	s "github.com/cortexlabs/cortex/pkg/lib/strings"
+	"github.com/cortexlabs/cortex/pkg/lib/telemetry"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+	"github.com/cortexlabs/cortex/pkg/operator/operator"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+	"github.com/cortexlabs/cortex/pkg/types/spec"
+)
+
+const (
+	MaxReplayRequests = 1000
+
+	_replayConcurrency       = 10
+	_replayRequestTimeout    = 60 * time.Second
+	_replayTimeout           = 30 * time.Minute // replays which have not completed by then were interrupted (e.g. by an operator restart)
+	_replayMaxMismatches     = 20               // the number of mismatches which are included in the result
+	_replayMaxResponseLength = 500              // characters of each mismatched response which are included in the result
+
+	_replaySpecFileName    = "spec.json"
+	_replayResultsFileName = "results.json"
+	_replayErrorFileName   = "error.txt"
+)
+
+// a sampled request which was uploaded by the API (must be kept in sync with request_log_record() in pkg/workloads/cortex/serve/serve.py)
+type requestLogRecord struct {
+	RequestID           string  `json:"request_id"`
+	APIID               string  `json:"api_id"`
+	Timestamp           float64 `json:"timestamp"` // unix seconds
+	ContentType         string  `json:"content_type"`
+	QueryParams         string  `json:"query_params"`
+	Payload             []byte  `json:"payload"`
+	StatusCode          int     `json:"status_code"`
+	LatencyMs           float64 `json:"latency_ms"`
+	ResponseContentType string  `json:"response_content_type"`
+	Response            []byte  `json:"response"`
+}
+
+type replaySpec struct {
+	TargetAPIName string    `json:"target_api_name"`
+	Start         time.Time `json:"start"`
+	End           time.Time `json:"end"`
+	Limit         int       `json:"limit"`
+	StartedAt     time.Time `json:"started_at"`
+}
+
+// the target's response to a replayed request
+type replayOutcome struct {
+	Record      *requestLogRecord
+	StatusCode  int // 0 if the target did not respond
+	LatencyMs   float64
+	ContentType string
+	Response    []byte
+}
+
+// StartReplay re-sends up to limit of the requests which were logged by the API between start and end to the target API
+// (in the order they were logged), and returns immediately; the comparison of the responses can be retrieved with GetReplay
+func StartReplay(apiName string, targetAPIName string, start time.Time, end time.Time, limit int) (*schema.ReplayResponse, error) {
+	if limit <= 0 || limit > MaxReplayRequests {
+		return nil, ErrorInvalidReplayLimit(limit, MaxReplayRequests)
+	}
+	if !start.Before(end) {
+		return nil, ErrorInvalidReplayWindow(start, end)
+	}
+
+	virtualService, err := config.K8s.GetVirtualService(operator.K8sName(targetAPIName))
+	if err != nil {
+		return nil, err
+	} else if virtualService == nil {
+		return nil, errors.ErrorUnexpected("unable to find virtual service", targetAPIName)
+	}
+	endpoint, err := operator.GetEndpointFromVirtualService(virtualService)
+	if err != nil {
+		return nil, err
+	}
+
+	replayID := k8s.RandomName()[:10]
+	replaySpec := replaySpec{
+		TargetAPIName: targetAPIName,
+		Start:         start,
+		End:           end,
+		Limit:         limit,
+		StartedAt:     time.Now(),
+	}
+
+	if err := config.AWS.UploadJSONToS3(replaySpec, config.Cluster.Bucket, replayKey(apiName, replayID, _replaySpecFileName)); err != nil {
+		return nil, err
+	}
+
+	go runReplay(apiName, replayID, replaySpec, _apisGatewayURL+endpoint)
+
+	return &schema.ReplayResponse{
+		ReplayID: replayID,
+		Status:   schema.ReplayRunning,
+	}, nil
+}
+
+// GetReplay returns the status of the replay, and its result once it has completed
+func GetReplay(apiName string, replayID string) (*schema.ReplayResponse, error) {
+	response := schema.ReplayResponse{
+		ReplayID: replayID,
+	}
+
+	resultsKey := replayKey(apiName, replayID, _replayResultsFileName)
+	hasResults, err := config.AWS.IsS3File(config.Cluster.Bucket, resultsKey)
+	if err != nil {
+		return nil, err
+	}
+	if hasResults {
+		var result schema.ReplayResult
+		if err := config.AWS.ReadJSONFromS3(&result, config.Cluster.Bucket, resultsKey); err != nil {
+			return nil, err
+		}
+		response.Status = schema.ReplaySucceeded
+		response.Result = &result
+		return &response, nil
+	}
+
+	errorKey := replayKey(apiName, replayID, _replayErrorFileName)
+	hasError, err := config.AWS.IsS3File(config.Cluster.Bucket, errorKey)
+	if err != nil {
+		return nil, err
+	}
+	if hasError {
+		message, err := config.AWS.ReadStringFromS3(config.Cluster.Bucket, errorKey)
+		if err != nil {
+			return nil, err
+		}
+		response.Status = schema.ReplayFailed
+		response.Message = message
+		return &response, nil
+	}
+
+	specKey := replayKey(apiName, replayID, _replaySpecFileName)
+	hasSpec, err := config.AWS.IsS3File(config.Cluster.Bucket, specKey)
+	if err != nil {
+		return nil, err
+	}
+	if !hasSpec {
+		return nil, ErrorReplayNotFound(apiName, replayID)
+	}
+
+	var replaySpec replaySpec
+	if err := config.AWS.ReadJSONFromS3(&replaySpec, config.Cluster.Bucket, specKey); err != nil {
+		return nil, err
+	}
+	if time.Since(replaySpec.StartedAt) > _replayTimeout {
+		response.Status = schema.ReplayFailed
+		response.Message = "the replay was interrupted (the operator may have restarted)"
+		return &response, nil
+	}
+
+	response.Status = schema.ReplayRunning
+	return &response, nil
+}
+
+func runReplay(apiName string, replayID string, replaySpec replaySpec, url string) {
+	result, err := replay(apiName, replaySpec, url)
+	if err != nil {
+		if err := config.AWS.UploadStringToS3(errors.Message(err), config.Cluster.Bucket, replayKey(apiName, replayID, _replayErrorFileName)); err != nil {
+			telemetry.Error(err)
+		}
+		return
+	}
+
+	if err := config.AWS.UploadJSONToS3(result, config.Cluster.Bucket, replayKey(apiName, replayID, _replayResultsFileName)); err != nil {
+		telemetry.Error(err)
+	}
+}
+
+func replay(apiName string, replaySpec replaySpec, url string) (*schema.ReplayResult, error) {
+	keys, err := listRequestLogKeys(apiName, replaySpec.Start, replaySpec.End, replaySpec.Limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, ErrorNoLoggedRequests(apiName, replaySpec.Start, replaySpec.End)
+	}
+
+	client := &http.Client{
+		Timeout: _replayRequestTimeout,
+		// compare redirects (e.g. to offloaded responses) rather than following them
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	outcomes := make([]replayOutcome, len(keys))
+	fns := make([]func() error, len(keys))
+	for i := range keys {
+		i := i
+		fns[i] = func() error {
+			var record requestLogRecord
+			if err := config.AWS.ReadJSONFromS3(&record, config.Cluster.Bucket, keys[i]); err != nil {
+				return err
+			}
+			outcomes[i] = sendReplayRequest(client, url, &record)
+			return nil
+		}
+	}
+	if err := parallel.RunFirstErrWithLimit(_replayConcurrency, fns...); err != nil {
+		return nil, err
+	}
+
+	return summarizeReplay(apiName, replaySpec.TargetAPIName, outcomes), nil
+}
+
+// returns the keys of the first limit requests which were logged between start and end, in the order they were logged
+func listRequestLogKeys(apiName string, start time.Time, end time.Time, limit int) ([]string, error) {
+	var keys []string
+
+	for hour := start.UTC().Truncate(time.Hour); hour.Before(end) && len(keys) < limit; hour = hour.Add(time.Hour) {
+		err := config.AWS.S3Iterator(config.Cluster.Bucket, requestLogPrefix(apiName, hour), false, nil, func(object *s3.Object) (bool, error) {
+			timestamp, ok := requestLogRecordTime(*object.Key)
+			if !ok || timestamp.Before(start) || !timestamp.Before(end) {
+				return true, nil
+			}
+			keys = append(keys, *object.Key)
+			return len(keys) < limit, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return keys, nil
+}
+
+func sendReplayRequest(client *http.Client, url string, record *requestLogRecord) replayOutcome {
+	outcome := replayOutcome{Record: record}
+
+	if record.QueryParams != "" {
+		url += "?" + record.QueryParams
+	}
+	request, err := http.NewRequest("POST", url, bytes.NewReader(record.Payload))
+	if err != nil {
+		return outcome
+	}
+	if record.ContentType != "" {
+		request.Header.Set("Content-Type", record.ContentType)
+	}
+
+	start := time.Now()
+	response, err := client.Do(request)
+	if err != nil {
+		return outcome
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return outcome
+	}
+
+	outcome.StatusCode = response.StatusCode
+	outcome.LatencyMs = float64(time.Since(start)) / float64(time.Millisecond)
+	outcome.ContentType = response.Header.Get("Content-Type")
+	outcome.Response = body
+	return outcome
+}
+
+func summarizeReplay(apiName string, targetAPIName string, outcomes []replayOutcome) *schema.ReplayResult {
+	result := schema.ReplayResult{
+		APIName:       apiName,
+		TargetAPIName: targetAPIName,
+		NumRequests:   len(outcomes),
+		StatusCodes:   map[int]int{},
+		Mismatches:    []schema.ReplayMismatch{},
+	}
+
+	var loggedLatencies []float64
+	var targetLatencies []float64
+
+	for _, outcome := range outcomes {
+		loggedLatencies = append(loggedLatencies, outcome.Record.LatencyMs)
+
+		if outcome.StatusCode == 0 {
+			result.NumConnectionErrors++
+			continue
+		}
+
+		result.StatusCodes[outcome.StatusCode]++
+		targetLatencies = append(targetLatencies, outcome.LatencyMs)
+
+		if outcome.StatusCode == outcome.Record.StatusCode && responsesMatch(outcome.Record.ResponseContentType, outcome.Record.Response, outcome.ContentType, outcome.Response) {
+			result.NumMatches++
+			continue
+		}
+
+		result.NumMismatches++
+		if len(result.Mismatches) < _replayMaxMismatches {
+			result.Mismatches = append(result.Mismatches, schema.ReplayMismatch{
+				RequestID:        outcome.Record.RequestID,
+				LoggedStatusCode: outcome.Record.StatusCode,
+				TargetStatusCode: outcome.StatusCode,
+				LoggedResponse:   s.TruncateEllipses(string(outcome.Record.Response), _replayMaxResponseLength),
+				TargetResponse:   s.TruncateEllipses(string(outcome.Response), _replayMaxResponseLength),
+			})
+		}
+	}
+
+	sort.Float64s(loggedLatencies)
+	sort.Float64s(targetLatencies)
+	result.LoggedLatencyP50 = percentile(loggedLatencies, 0.50)
+	result.LoggedLatencyP99 = percentile(loggedLatencies, 0.99)
+	result.TargetLatencyP50 = percentile(targetLatencies, 0.50)
+	result.TargetLatencyP99 = percentile(targetLatencies, 0.99)
+
+	return &result
+}
+
+// json responses are compared by value (so that e.g. the order of keys doesn't matter), and other responses byte for byte
+func responsesMatch(contentType1 string, response1 []byte, contentType2 string, response2 []byte) bool {
+	if isJSONContentType(contentType1) && isJSONContentType(contentType2) {
+		var val1, val2 interface{}
+		if json.Unmarshal(response1, &val1) == nil && json.Unmarshal(response2, &val2) == nil {
+			return reflect.DeepEqual(val1, val2)
+		}
+	}
+	return bytes.Equal(response1, response2)
+}
+
+func isJSONContentType(contentType string) bool {
+	return strings.HasPrefix(strings.ToLower(contentType), "application/json")
+}
+
+// the prefix of the requests which were logged by the API during the hour (must be kept in sync with
+// upload_request_log() in pkg/workloads/cortex/lib/type/api.py)
+func requestLogPrefix(apiName string, hour time.Time) string {
+	return filepath.Join(spec.MetadataRoot(apiName), "request_log", hour.UTC().Format("2006-01-02"), hour.UTC().Format("15")) + "/"
+}
+
+// request log records are named <unix milliseconds>-<request id>.json
+func requestLogRecordTime(key string) (time.Time, bool) {
+	fileName := filepath.Base(key)
+	if !strings.HasSuffix(fileName, ".json") {
+		return time.Time{}, false
+	}
+
+	unixMillis, ok := s.ParseInt64(strings.SplitN(fileName, "-", 2)[0])
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(0, unixMillis*int64(time.Millisecond)), true
+}
+
+func replayKey(apiName string, replayID string, fileName string) string {
+	return filepath.Join("apis", apiName, "replays", replayID, fileName)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponsesMatch(t *testing.T) {
+	require.True(t, responsesMatch("application/json", []byte(`{"a": 1, "b": [1, 2]}`), "application/json; charset=utf-8", []byte(`{"b":[1,2],"a":1}`)))
+	require.False(t, responsesMatch("application/json", []byte(`{"a": 1}`), "application/json", []byte(`{"a": 2}`)))
+	require.False(t, responsesMatch("application/json", []byte(`{"b": [1, 2]}`), "application/json", []byte(`{"b": [2, 1]}`)))
+
+	// invalid json is compared byte for byte
+	require.True(t, responsesMatch("application/json", []byte(`{"a"`), "application/json", []byte(`{"a"`)))
+	require.False(t, responsesMatch("application/json", []byte(`{"a"`), "application/json", []byte(`{"a" `)))
+
+	require.True(t, responsesMatch("text/plain", []byte("positive"), "text/plain", []byte("positive")))
+	require.False(t, responsesMatch("text/plain", []byte(`{"a":1}`), "application/json", []byte(`{"a": 1}`)))
+	require.True(t, responsesMatch("", nil, "", []byte{}))
+}
+
+func TestRequestLogRecordTime(t *testing.T) {
+	timestamp, ok := requestLogRecordTime("apis/my-api/metadata/request_log/2020-07-02/09/1593680400123-8a1b3f47.json")
+	require.True(t, ok)
+	require.Equal(t, time.Date(2020, 7, 2, 9, 0, 0, 123000000, time.UTC), timestamp.UTC())
+
+	_, ok = requestLogRecordTime("apis/my-api/metadata/request_log/2020-07-02/09/")
+	require.False(t, ok)
+	_, ok = requestLogRecordTime("apis/my-api/metadata/request_log/2020-07-02/09/notes.json")
+	require.False(t, ok)
+}
+
+func TestRequestLogPrefix(t *testing.T) {
+	hour := time.Date(2020, 7, 2, 9, 0, 0, 0, time.UTC)
+	require.Equal(t, "apis/my-api/metadata/request_log/2020-07-02/09/", requestLogPrefix("my-api", hour))
+	require.Equal(t, "apis/my-api/metadata/request_log/2020-07-02/09/", requestLogPrefix("my-api", hour.In(time.FixedZone("PDT", -7*3600))))
+}
+
+func TestSummarizeReplay(t *testing.T) {
+	outcomes := []replayOutcome{
+		{
+			Record:      &requestLogRecord{RequestID: "1", StatusCode: 200, LatencyMs: 10, ResponseContentType: "application/json", Response: []byte(`{"label": "cat"}`)},
+			StatusCode:  200,
+			LatencyMs:   20,
+			ContentType: "application/json",
+			Response:    []byte(`{"label":"cat"}`),
+		},
+		{
+			Record:      &requestLogRecord{RequestID: "2", StatusCode: 200, LatencyMs: 30, ResponseContentType: "application/json", Response: []byte(`{"label": "cat"}`)},
+			StatusCode:  200,
+			LatencyMs:   40,
+			ContentType: "application/json",
+			Response:    []byte(`{"label": "dog"}`),
+		},
+		{
+			Record:      &requestLogRecord{RequestID: "3", StatusCode: 200, LatencyMs: 50, ResponseContentType: "text/plain", Response: []byte("cat")},
+			StatusCode:  500,
+			LatencyMs:   5,
+			ContentType: "text/plain",
+			Response:    []byte("cat"),
+		},
+		{
+			Record: &requestLogRecord{RequestID: "4", StatusCode: 200, LatencyMs: 70},
+		},
+	}
+
+	result := summarizeReplay("classifier", "classifier-v2", outcomes)
+	require.Equal(t, "classifier", result.APIName)
+	require.Equal(t, "classifier-v2", result.TargetAPIName)
+	require.Equal(t, 4, result.NumRequests)
+	require.Equal(t, 1, result.NumMatches)
+	require.Equal(t, 2, result.NumMismatches)
+	require.Equal(t, 1, result.NumConnectionErrors)
+	require.Equal(t, map[int]int{200: 2, 500: 1}, result.StatusCodes)
+	require.Equal(t, 30.0, result.LoggedLatencyP50)
+	require.Equal(t, 70.0, result.LoggedLatencyP99)
+	require.Equal(t, 20.0, result.TargetLatencyP50)
+	require.Equal(t, 40.0, result.TargetLatencyP99)
+
+	require.Len(t, result.Mismatches, 2)
+	require.Equal(t, "2", result.Mismatches[0].RequestID)
+	require.Equal(t, `{"label": "dog"}`, result.Mismatches[0].TargetResponse)
+	require.Equal(t, "3", result.Mismatches[1].RequestID)
+	require.Equal(t, 200, result.Mismatches[1].LoggedStatusCode)
+	require.Equal(t, 500, result.Mismatches[1].TargetStatusCode)
+}
+
+func TestSummarizeReplayMismatchLimit(t *testing.T) {
+	var outcomes []replayOutcome
+	for i := 0; i < _replayMaxMismatches+5; i++ {
+		outcomes = append(outcomes, replayOutcome{
+			Record:     &requestLogRecord{StatusCode: 200, Response: []byte("a")},
+			StatusCode: 200,
+			Response:   []byte("b"),
+		})
+	}
+
+	result := summarizeReplay("classifier", "classifier-v2", outcomes)
+	require.Equal(t, _replayMaxMismatches+5, result.NumMismatches)
+	require.Len(t, result.Mismatches, _replayMaxMismatches)
+}
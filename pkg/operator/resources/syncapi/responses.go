@@ -0,0 +1,77 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncapi
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+	"github.com/cortexlabs/cortex/pkg/types/spec"
+	"github.com/cortexlabs/cortex/pkg/types/userconfig"
+)
+
+// responses larger than networking.max_response_size are written to S3, and the client is redirected to a presigned URL;
+// this must match OFFLOADED_RESPONSE_URL_EXPIRATION in pkg/workloads/cortex/serve/serve.py, since the responses are
+// deleted once their URLs have expired
+const _offloadedResponseURLExpiration = time.Hour
+
+// DeleteExpiredOffloadedResponses deletes the offloaded responses of all APIs whose presigned URLs have expired
+func DeleteExpiredOffloadedResponses() error {
+	deployments, err := config.K8s.ListDeploymentsWithLabelKeys("apiName")
+	if err != nil {
+		return err
+	}
+
+	apiNames := strset.New()
+	for _, deployment := range deployments {
+		if userconfig.KindFromString(deployment.Labels["apiKind"]) == userconfig.SyncAPIKind {
+			apiNames.Add(deployment.Labels["apiName"])
+		}
+	}
+
+	var errs []error
+	for apiName := range apiNames {
+		if err := deleteExpiredOffloadedResponses(apiName); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.FirstError(errs...)
+}
+
+func deleteExpiredOffloadedResponses(apiName string) error {
+	var expiredKeys []string
+	err := config.AWS.S3Iterator(config.Cluster.Bucket, offloadedResponsesPrefix(apiName), false, nil, func(object *s3.Object) (bool, error) {
+		if object.LastModified != nil && time.Since(*object.LastModified) > _offloadedResponseURLExpiration {
+			expiredKeys = append(expiredKeys, *object.Key)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return config.AWS.DeleteS3Objects(config.Cluster.Bucket, expiredKeys)
+}
+
+func offloadedResponsesPrefix(apiName string) string {
+	return filepath.Join(spec.MetadataRoot(apiName), "responses") + "/"
+}
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/json"
 	"github.com/cortexlabs/cortex/pkg/lib/k8s"
 	"github.com/cortexlabs/cortex/pkg/lib/parallel"
 	"github.com/cortexlabs/cortex/pkg/operator/config"
@@ -107,10 +108,26 @@ func apiStatus(deployment *kapps.Deployment, allPods []kcore.Pod) (*status.Statu
 	status.APIID = deployment.Labels["apiID"]
 	status.ReplicaCounts = getReplicaCounts(deployment, allPods)
 	status.Code = getStatusCode(&status.ReplicaCounts, autoscalingSpec.MinReplicas)
+	status.LastCrash = lastCrash(deployment, allPods)
+	status.ImageVerifications = imageVerifications(deployment)
 
 	return status, nil
 }
 
+func imageVerifications(deployment *kapps.Deployment) []status.ImageVerification {
+	annotation, ok := deployment.Annotations[userconfig.ImageVerificationsAnnotationKey]
+	if !ok {
+		return nil
+	}
+
+	var imageVerifications []status.ImageVerification
+	if err := json.Unmarshal([]byte(annotation), &imageVerifications); err != nil {
+		errors.PrintError(err)
+		return nil
+	}
+	return imageVerifications
+}
+
 func getReplicaCounts(deployment *kapps.Deployment, pods []kcore.Pod) status.ReplicaCounts {
 	counts := status.ReplicaCounts{}
 	counts.Requested = *deployment.Spec.Replicas
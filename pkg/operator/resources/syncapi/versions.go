@@ -0,0 +1,172 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncapi
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/lib/aws"
+	"github.com/cortexlabs/cortex/pkg/lib/parallel"
+	"github.com/cortexlabs/cortex/pkg/lib/telemetry"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+	"github.com/cortexlabs/cortex/pkg/operator/schema"
+	"github.com/cortexlabs/cortex/pkg/types/spec"
+	"github.com/cortexlabs/cortex/pkg/types/userconfig"
+)
+
+const (
+	_maxRecordedVersions    = 50 // the number of versions which are kept in an API's version history
+	_maxVersionsWithMetrics = 5  // the number of versions whose metrics are shown by `cortex get <api>`
+)
+
+// assignAPIVersion sets the API's version from its version history, adding a new version to the history if the API hasn't
+// been deployed with this ID before (e.g. reverting an API to a previous configuration restores the previous version)
+func assignAPIVersion(api *spec.API) error {
+	versions, err := getVersionHistory(api.Name)
+	if err != nil {
+		return err
+	}
+
+	for _, version := range versions {
+		if version.APIID == api.ID {
+			api.Version = version.Version
+			return nil
+		}
+	}
+
+	api.Version = 1
+	if len(versions) > 0 {
+		api.Version = versions[len(versions)-1].Version + 1
+	}
+
+	versions = append(versions, schema.APIVersion{
+		Version:    api.Version,
+		APIID:      api.ID,
+		DeployedAt: time.Now(),
+		Models:     modelPaths(api),
+	})
+	if len(versions) > _maxRecordedVersions {
+		versions = versions[len(versions)-_maxRecordedVersions:]
+	}
+
+	return config.AWS.UploadJSONToS3(versions, config.Cluster.Bucket, versionHistoryKey(api.Name))
+}
+
+// recordVersionImages adds the image digests of the version's running containers to the API's version history (image
+// digests are only known once a container has been started, and the version's pods are deleted when it's superseded)
+func recordVersionImages(apiName string, apiID string) error {
+	versions, err := getVersionHistory(apiName)
+	if err != nil {
+		return err
+	}
+
+	for i := range versions {
+		if versions[i].APIID != apiID || len(versions[i].Images) > 0 {
+			continue
+		}
+
+		images, err := containerImages(apiName, apiID)
+		if err != nil || len(images) == 0 {
+			return err
+		}
+		versions[i].Images = images
+		return config.AWS.UploadJSONToS3(versions, config.Cluster.Bucket, versionHistoryKey(apiName))
+	}
+
+	return nil
+}
+
+// GetVersionMetrics returns the most recent versions of the API (latest first), and the metrics which were recorded for each
+// (metrics are recorded per API ID, and each version has a unique ID); this is best-effort, so versions whose metrics can't
+// be retrieved are omitted
+func GetVersionMetrics(api *spec.API) []schema.APIVersionMetrics {
+	versions, err := getVersionHistory(api.Name)
+	if err != nil {
+		telemetry.Error(err)
+		return nil
+	}
+
+	var versionMetrics []schema.APIVersionMetrics
+	for i := len(versions) - 1; i >= 0 && len(versionMetrics) < _maxVersionsWithMetrics; i-- {
+		versionMetrics = append(versionMetrics, schema.APIVersionMetrics{APIVersion: versions[i]})
+	}
+
+	// the running version's images are recorded when it's superseded, so until then they are read from its pods
+	if len(versionMetrics) > 0 && versionMetrics[0].APIID == api.ID && len(versionMetrics[0].Images) == 0 {
+		images, err := containerImages(api.Name, api.ID)
+		if err != nil {
+			telemetry.Error(err)
+		}
+		versionMetrics[0].Images = images
+	}
+
+	fns := make([]func() error, len(versionMetrics))
+	for i := range versionMetrics {
+		i := i
+		fns[i] = func() error {
+			versionAPI := spec.API{
+				API: &userconfig.API{Resource: userconfig.Resource{Name: api.Name, Kind: api.Kind}},
+				ID:  versionMetrics[i].APIID,
+			}
+			metrics, err := GetMetrics(&versionAPI)
+			if err != nil {
+				return err
+			}
+			versionMetrics[i].Metrics = *metrics
+			return nil
+		}
+	}
+	errs := parallel.RunWithLimit(0, fns...)
+
+	var retrievedVersionMetrics []schema.APIVersionMetrics
+	for i := range versionMetrics {
+		if errs[i] != nil {
+			telemetry.Error(errs[i])
+			continue
+		}
+		retrievedVersionMetrics = append(retrievedVersionMetrics, versionMetrics[i])
+	}
+
+	return retrievedVersionMetrics
+}
+
+func getVersionHistory(apiName string) ([]schema.APIVersion, error) {
+	var versions []schema.APIVersion
+	if err := config.AWS.ReadJSONFromS3(&versions, config.Cluster.Bucket, versionHistoryKey(apiName)); err != nil {
+		if aws.IsNoSuchKeyErr(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return versions, nil
+}
+
+func modelPaths(api *spec.API) []string {
+	var paths []string
+	if api.Predictor.ModelPath != nil {
+		paths = append(paths, *api.Predictor.ModelPath)
+	}
+	for _, model := range api.Predictor.Models {
+		paths = append(paths, model.Name+": "+model.ModelPath)
+	}
+	return paths
+}
+
+func versionHistoryKey(apiName string) string {
+	return filepath.Join("apis", apiName, "versions.json")
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/k8s"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+	libtime "github.com/cortexlabs/cortex/pkg/lib/time"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+	"github.com/cortexlabs/cortex/pkg/operator/operator"
+	"github.com/cortexlabs/cortex/pkg/types/userconfig"
+	istioclientnetworking "istio.io/client-go/pkg/apis/networking/v1alpha3"
+)
+
+const (
+	// the expiration annotation is patched by its own field manager so that it isn't removed when the API is updated
+	_expirationFieldManager = "cortex-expiration"
+
+	// APIs which will expire within this period are logged by the expiration cron
+	_expirationWarningPeriod = 1 * time.Hour
+)
+
+// Tracks the expiration time which was last warned about for each API (only accessed from the expiration cron)
+var _warnedExpirations = map[string]time.Time{}
+
+// SetExpiration sets the time at which the API will be deleted (or clears it if expiration is nil)
+func SetExpiration(apiName string, expiration *time.Time) error {
+	var expirationStr *string
+	if expiration != nil {
+		expirationStr = pointer.String(expiration.UTC().Format(time.RFC3339))
+	}
+	annotations := map[string]*string{
+		userconfig.ExpirationAnnotationKey: expirationStr,
+	}
+
+	_, err := config.K8s.PatchVirtualServiceAnnotations(operator.K8sName(apiName), annotations, _expirationFieldManager)
+	return err
+}
+
+// Returns nil if the API does not expire
+func GetExpiration(apiName string) (*time.Time, error) {
+	virtualService, err := config.K8s.GetVirtualService(operator.K8sName(apiName))
+	if err != nil {
+		return nil, err
+	}
+	return expirationFromVirtualService(virtualService)
+}
+
+// ExtendAPI pushes back the API's expiration by duration (counting from now if the API has already expired). If
+// duration is nil, the expiration is removed and the API will not be deleted automatically
+func ExtendAPI(apiName string, duration *time.Duration) (string, error) {
+	deployedResource, err := GetDeployedResourceByName(apiName)
+	if err != nil {
+		return "", err
+	} else if deployedResource == nil {
+		return "", ErrorAPINotDeployed(apiName)
+	}
+
+	if duration == nil {
+		if err := SetExpiration(apiName, nil); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s will no longer expire", apiName), nil
+	}
+
+	if *duration <= 0 {
+		return "", ErrorInvalidTTL(*duration)
+	}
+
+	expiration, err := GetExpiration(apiName)
+	if err != nil {
+		return "", err
+	}
+
+	start := time.Now()
+	if expiration != nil && expiration.After(start) {
+		start = *expiration
+	}
+	newExpiration := start.Add(*duration)
+
+	if err := SetExpiration(apiName, &newExpiration); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s will expire in %s (at %s)", apiName, expiresInStr(newExpiration), libtime.LocalTimestamp(&newExpiration)), nil
+}
+
+// DeleteExpiredAPIs deletes all APIs whose expiration has passed, and logs a warning for APIs which will expire soon
+func DeleteExpiredAPIs() error {
+	virtualServices, err := config.K8s.ListVirtualServicesWithLabelKeys("apiName")
+	if err != nil {
+		return err
+	}
+
+	var expiredVirtualServices []istioclientnetworking.VirtualService
+	for i := range virtualServices {
+		virtualService := &virtualServices[i]
+		apiName := virtualService.Labels["apiName"]
+
+		expiration, err := expirationFromVirtualService(virtualService)
+		if err != nil {
+			log.Printf("warning: unable to read the expiration of %s: %s", apiName, errors.Message(err))
+			continue
+		}
+		if expiration == nil {
+			delete(_warnedExpirations, apiName)
+			continue
+		}
+
+		if time.Now().After(*expiration) {
+			expiredVirtualServices = append(expiredVirtualServices, *virtualService)
+			continue
+		}
+
+		if time.Until(*expiration) < _expirationWarningPeriod && !_warnedExpirations[apiName].Equal(*expiration) {
+			log.Printf("warning: %s will expire in %s (at %s); run `cortex extend %s` to keep it running", apiName, expiresInStr(*expiration), expiration.UTC().Format(time.RFC3339), apiName)
+			_warnedExpirations[apiName] = *expiration
+		}
+	}
+
+	for _, virtualService := range expiredVirtualServices {
+		log.Printf("deleting %s because it has expired", virtualService.Labels["apiName"])
+	}
+
+	deletedAPINames, errs := deleteAPIs("delete expired apis", expiredVirtualServices)
+	for _, apiName := range deletedAPINames {
+		delete(_warnedExpirations, apiName)
+	}
+
+	return errors.FirstError(errs...)
+}
+
+// Returns nil if the virtual service is nil or the API does not expire
+func expirationFromVirtualService(virtualService *istioclientnetworking.VirtualService) (*time.Time, error) {
+	if virtualService == nil {
+		return nil, nil
+	}
+
+	expirationStr, ok := virtualService.Annotations[userconfig.ExpirationAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+
+	expiration, err := time.Parse(time.RFC3339, expirationStr)
+	if err != nil {
+		return nil, k8s.ErrorParseAnnotation(userconfig.ExpirationAnnotationKey, expirationStr, "timestamp")
+	}
+
+	return &expiration, nil
+}
+
+func expiresInStr(expiration time.Time) string {
+	now := time.Now()
+	return libtime.DifferenceStr(&now, &expiration)
+}
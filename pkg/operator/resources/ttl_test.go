@@ -0,0 +1,65 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/k8s"
+	"github.com/cortexlabs/cortex/pkg/types/userconfig"
+	"github.com/stretchr/testify/require"
+	istioclientnetworking "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	kmeta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func virtualServiceWithAnnotations(annotations map[string]string) *istioclientnetworking.VirtualService {
+	return &istioclientnetworking.VirtualService{
+		ObjectMeta: kmeta.ObjectMeta{
+			Name:        "api-test",
+			Annotations: annotations,
+		},
+	}
+}
+
+func TestExpirationFromVirtualService(t *testing.T) {
+	expiration, err := expirationFromVirtualService(nil)
+	require.NoError(t, err)
+	require.Nil(t, expiration)
+
+	expiration, err = expirationFromVirtualService(virtualServiceWithAnnotations(nil))
+	require.NoError(t, err)
+	require.Nil(t, expiration)
+
+	expiration, err = expirationFromVirtualService(virtualServiceWithAnnotations(map[string]string{
+		userconfig.ExpirationAnnotationKey: "2020-07-01T12:30:00Z",
+	}))
+	require.NoError(t, err)
+	require.True(t, expiration.Equal(time.Date(2020, 7, 1, 12, 30, 0, 0, time.UTC)))
+
+	expiration, err = expirationFromVirtualService(virtualServiceWithAnnotations(map[string]string{
+		userconfig.ExpirationAnnotationKey: "2020-07-01T14:30:00+02:00",
+	}))
+	require.NoError(t, err)
+	require.True(t, expiration.Equal(time.Date(2020, 7, 1, 12, 30, 0, 0, time.UTC)))
+
+	_, err = expirationFromVirtualService(virtualServiceWithAnnotations(map[string]string{
+		userconfig.ExpirationAnnotationKey: "2020-07-01",
+	}))
+	require.Equal(t, k8s.ErrParseAnnotation, errors.GetKind(err))
+}
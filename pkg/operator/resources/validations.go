@@ -19,16 +19,21 @@ package resources
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/cortexlabs/cortex/pkg/consts"
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/files"
 	"github.com/cortexlabs/cortex/pkg/lib/k8s"
 	"github.com/cortexlabs/cortex/pkg/lib/parallel"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
 	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
+	"github.com/cortexlabs/cortex/pkg/lib/slices"
 	s "github.com/cortexlabs/cortex/pkg/lib/strings"
 	"github.com/cortexlabs/cortex/pkg/operator/config"
 	"github.com/cortexlabs/cortex/pkg/operator/operator"
 	"github.com/cortexlabs/cortex/pkg/types"
+	"github.com/cortexlabs/cortex/pkg/types/clusterconfig"
 	"github.com/cortexlabs/cortex/pkg/types/spec"
 	"github.com/cortexlabs/cortex/pkg/types/userconfig"
 	istioclientnetworking "istio.io/client-go/pkg/apis/networking/v1alpha3"
@@ -95,9 +100,16 @@ func ValidateClusterAPIs(apis []userconfig.API, projectFiles spec.ProjectFiles)
 			if err := spec.ValidateAPI(api, projectFiles, types.AWSProviderType, config.AWS); err != nil {
 				return errors.Wrap(err, api.Identify())
 			}
+			mirrorPredictorImages(api)
+			if err := validateAPIGateway(api); err != nil {
+				return errors.Wrap(err, api.Identify(), userconfig.NetworkingKey, userconfig.APIGatewayKey)
+			}
 			if err := validateK8s(api, virtualServices, maxMem); err != nil {
 				return errors.Wrap(err, api.Identify())
 			}
+			if err := validateSecurity(api, projectFiles); err != nil {
+				return errors.Wrap(err, api.Identify(), userconfig.SecurityKey)
+			}
 
 			if !didPrintWarning && api.Networking.LocalPort != nil {
 				fmt.Println(fmt.Sprintf("warning: %s will be ignored because it is not supported in an environment using aws provider\n", userconfig.LocalPortKey))
@@ -108,9 +120,15 @@ func ValidateClusterAPIs(apis []userconfig.API, projectFiles spec.ProjectFiles)
 			if err := spec.ValidateAPISplitter(api, types.AWSProviderType, config.AWS); err != nil {
 				return errors.Wrap(err, api.Identify())
 			}
+			if err := validateAPIGateway(api); err != nil {
+				return errors.Wrap(err, api.Identify(), userconfig.NetworkingKey, userconfig.APIGatewayKey)
+			}
 			if err := checkIfAPIExists(api.APIs, withoutAPISplitter); err != nil {
 				return errors.Wrap(err, api.Identify())
 			}
+			if err := checkForNewDeprecatedAPIReferences(api, withoutAPISplitter, virtualServices); err != nil {
+				return errors.Wrap(err, api.Identify())
+			}
 			if err := validateEndpointCollisions(api, virtualServices); err != nil {
 				return errors.Wrap(err, api.Identify())
 			}
@@ -128,6 +146,123 @@ func ValidateClusterAPIs(apis []userconfig.API, projectFiles spec.ProjectFiles)
 	return nil
 }
 
+// validateSecurity applies the cluster's api_security_policy to the API, and checks that the API can run with its security settings
+func validateSecurity(api *userconfig.API, projectFiles spec.ProjectFiles) error {
+	policy := config.Cluster.APISecurityPolicy
+	if policy != nil && api.Security == nil {
+		api.Security = &userconfig.Security{}
+	}
+
+	if policy != nil {
+		// only cluster administrators can exempt apis from the restricted pod security standard (via the cluster configuration)
+		if api.Security.PodSecurityStandard == nil {
+			api.Security.PodSecurityStandard = pointer.String(policy.PodSecurityStandard)
+		} else if policy.PodSecurityStandard == k8s.PodSecurityStandardRestricted && *api.Security.PodSecurityStandard != k8s.PodSecurityStandardRestricted && !slices.HasString(policy.PodSecurityExemptAPIs, api.Name) {
+			return ErrorPodSecurityExemptionRequired(api.Name)
+		}
+	}
+
+	// the restricted standard's requirements are applied before the policy's defaults, which may be less strict
+	if api.Security != nil && api.Security.PodSecurityStandard != nil && *api.Security.PodSecurityStandard == k8s.PodSecurityStandardRestricted {
+		if err := applyRestrictedPodSecurityStandard(api); err != nil {
+			return err
+		}
+	}
+
+	if policy != nil {
+		if api.Security.RunAsNonRoot == nil {
+			api.Security.RunAsNonRoot = pointer.Bool(policy.RunAsNonRoot)
+		} else if policy.RunAsNonRoot && !*api.Security.RunAsNonRoot {
+			return ErrorSecurityPolicyViolation(userconfig.RunAsNonRootKey, s.Bool(*api.Security.RunAsNonRoot))
+		}
+
+		if api.Security.ReadOnlyRootFilesystem == nil {
+			api.Security.ReadOnlyRootFilesystem = pointer.Bool(policy.ReadOnlyRootFilesystem)
+		} else if policy.ReadOnlyRootFilesystem && !*api.Security.ReadOnlyRootFilesystem {
+			return ErrorSecurityPolicyViolation(userconfig.ReadOnlyRootFilesystemKey, s.Bool(*api.Security.ReadOnlyRootFilesystem))
+		}
+
+		if api.Security.SeccompProfile == nil {
+			api.Security.SeccompProfile = policy.SeccompProfile
+		} else if policy.SeccompProfile != nil && *api.Security.SeccompProfile != *policy.SeccompProfile {
+			return ErrorSecurityPolicyViolation(userconfig.SeccompProfileKey, *api.Security.SeccompProfile)
+		}
+
+		if api.Security.AppArmorProfile == nil {
+			api.Security.AppArmorProfile = policy.AppArmorProfile
+		} else if policy.AppArmorProfile != nil && *api.Security.AppArmorProfile != *policy.AppArmorProfile {
+			return ErrorSecurityPolicyViolation(userconfig.AppArmorProfileKey, *api.Security.AppArmorProfile)
+		}
+	}
+
+	if api.Security == nil {
+		return nil
+	}
+
+	runAsNonRoot := api.Security.RunAsNonRoot != nil && *api.Security.RunAsNonRoot
+	readOnlyRootFilesystem := api.Security.ReadOnlyRootFilesystem != nil && *api.Security.ReadOnlyRootFilesystem
+	if !runAsNonRoot && !readOnlyRootFilesystem {
+		return nil
+	}
+
+	// dependencies are installed into the predictor image when the API starts, which requires root and a writable filesystem
+	for _, fileName := range append([]string{"dependencies.sh"}, _dependencyFileNames...) {
+		if projectFiles.HasFile(fileName) {
+			return ErrorDependenciesRequireRoot(fileName)
+		}
+	}
+
+	return nil
+}
+
+// API Gateway integrates with the istio gateway's network load balancer (directly, or through a VPC link if it's internal);
+// other api_ingress controllers only provision their load balancer after the first API's ingress is created
+func validateAPIGateway(api *userconfig.API) error {
+	if api.Networking.APIGateway != userconfig.NoneAPIGatewayType && config.Cluster.APIIngress != clusterconfig.IstioAPIIngress {
+		return ErrorAPIGatewayRequiresIstioIngress()
+	}
+	return nil
+}
+
+// mirrorPredictorImages points the API's cortex images at the cluster's image registry (if one is configured)
+func mirrorPredictorImages(api *userconfig.API) {
+	if api.Predictor == nil {
+		return
+	}
+	api.Predictor.Image = clusterconfig.MirroredImage(api.Predictor.Image, config.Cluster.ImageRegistry)
+	api.Predictor.TensorFlowServingImage = clusterconfig.MirroredImage(api.Predictor.TensorFlowServingImage, config.Cluster.ImageRegistry)
+}
+
+// applyRestrictedPodSecurityStandard defaults the API's security settings to values which comply with the restricted pod
+// security standard, and returns an error if the API's configuration can't comply with it
+func applyRestrictedPodSecurityStandard(api *userconfig.API) error {
+	// the neuron runtime and egress proxy containers require elevated privileges
+	if api.Compute != nil && api.Compute.Inf > 0 {
+		return ErrorIncompatibleWithRestrictedPodSecurityStandard(userconfig.ComputeKey + "." + userconfig.InfKey)
+	}
+	if api.Networking != nil && api.Networking.EgressAllowlist != nil {
+		return ErrorIncompatibleWithRestrictedPodSecurityStandard(userconfig.NetworkingKey + "." + userconfig.EgressAllowlistKey)
+	}
+
+	if api.Security.RunAsNonRoot == nil {
+		api.Security.RunAsNonRoot = pointer.Bool(true)
+	} else if !*api.Security.RunAsNonRoot {
+		return ErrorRestrictedPodSecurityStandardViolation(userconfig.RunAsNonRootKey, s.Bool(*api.Security.RunAsNonRoot))
+	}
+
+	if api.Security.SeccompProfile == nil {
+		api.Security.SeccompProfile = pointer.String(k8s.SeccompProfileRuntimeDefault)
+	} else if *api.Security.SeccompProfile == k8s.SeccompProfileUnconfined {
+		return ErrorRestrictedPodSecurityStandardViolation(userconfig.SeccompProfileKey, *api.Security.SeccompProfile)
+	}
+
+	if api.Security.AppArmorProfile != nil && *api.Security.AppArmorProfile == k8s.AppArmorProfileUnconfined {
+		return ErrorRestrictedPodSecurityStandardViolation(userconfig.AppArmorProfileKey, *api.Security.AppArmorProfile)
+	}
+
+	return nil
+}
+
 func validateK8s(api *userconfig.API, virtualServices []istioclientnetworking.VirtualService, maxMem kresource.Quantity) error {
 	if err := validateK8sCompute(api.Compute, maxMem); err != nil {
 		return errors.Wrap(err, api.Identify(), userconfig.ComputeKey)
@@ -186,6 +321,10 @@ func validateK8sCompute(compute *userconfig.Compute, maxMem kresource.Quantity)
 		maxMem.Sub(_inferentiaMemReserve)
 	}
 
+	// preallocated huge pages can't be used as regular memory
+	maxHugePages := *kresource.NewQuantity(config.Cluster.InstanceHugePages*consts.HugePageSize, kresource.BinarySI)
+	maxMem.Sub(maxHugePages)
+
 	if compute.CPU != nil && maxCPU.Cmp(compute.CPU.Quantity) < 0 {
 		return ErrorNoAvailableNodeComputeLimit("CPU", compute.CPU.String(), maxCPU.String())
 	}
@@ -198,6 +337,27 @@ func validateK8sCompute(compute *userconfig.Compute, maxMem kresource.Quantity)
 	if compute.Inf > maxInf {
 		return ErrorNoAvailableNodeComputeLimit("Inf", fmt.Sprintf("%d", compute.Inf), fmt.Sprintf("%d", maxInf))
 	}
+	if compute.Shm != nil && maxMem.Cmp(compute.Shm.Quantity) < 0 {
+		return ErrorNoAvailableNodeComputeLimit("shared memory", compute.Shm.String(), maxMem.String())
+	}
+	if compute.HugePages != nil && maxHugePages.Cmp(compute.HugePages.Quantity) < 0 {
+		if config.Cluster.InstanceHugePages == 0 {
+			return ErrorInstanceHugePagesRequired()
+		}
+		return ErrorNoAvailableNodeComputeLimit("huge pages", compute.HugePages.String(), maxHugePages.String())
+	}
+
+	if compute.ExclusiveCPUs {
+		if config.Cluster.CPUManagerPolicy != clusterconfig.StaticCPUManagerPolicy {
+			return ErrorExclusiveCPUsRequireStaticCPUManagerPolicy()
+		}
+		// the static cpu manager policy reserves a whole core for system processes
+		maxExclusiveCPUs := config.Cluster.InstanceMetadata.CPU.MilliValue()/1000 - 1
+		if compute.CPU.MilliValue()/1000 > maxExclusiveCPUs {
+			return ErrorNoAvailableNodeComputeLimit("exclusive CPU", compute.CPU.String(), s.Int64(maxExclusiveCPUs))
+		}
+	}
+
 	return nil
 }
 
@@ -305,3 +465,54 @@ func checkIfAPIExists(trafficSplitterAPIs []*userconfig.TrafficSplit, apis []use
 	return nil
 
 }
+
+// checkForNewDeprecatedAPIReferences prevents an API splitter from adding a reference to a deprecated API (existing references are allowed)
+func checkForNewDeprecatedAPIReferences(apiSplitter *userconfig.API, apis []userconfig.API, virtualServices []istioclientnetworking.VirtualService) error {
+	sunsetDates := map[string]time.Time{} // k8s name -> sunset date
+	prevReferences := strset.New()        // k8s names
+
+	for i := range virtualServices {
+		virtualService := &virtualServices[i]
+
+		if virtualService.Labels["apiKind"] == userconfig.SyncAPIKind.String() {
+			sunsetDate, err := userconfig.SunsetDateFromAnnotations(virtualService)
+			if err != nil {
+				return err
+			}
+			if sunsetDate != nil {
+				sunsetDates[virtualService.Name] = *sunsetDate
+			}
+		}
+
+		if virtualService.Name == operator.K8sName(apiSplitter.Name) {
+			for _, httpRoute := range virtualService.Spec.Http {
+				for _, routeDestination := range httpRoute.Route {
+					if routeDestination.Destination != nil {
+						prevReferences.Add(routeDestination.Destination.Host)
+					}
+				}
+			}
+		}
+	}
+
+	// APIs in the current deployment take precedence over what is currently deployed
+	for _, api := range apis {
+		if api.Deprecation != nil {
+			sunsetDates[operator.K8sName(api.Name)] = api.Deprecation.SunsetDate
+		} else {
+			delete(sunsetDates, operator.K8sName(api.Name))
+		}
+	}
+
+	for _, trafficSplitAPI := range apiSplitter.APIs {
+		k8sName := operator.K8sName(trafficSplitAPI.Name)
+		if prevReferences.Has(k8sName) {
+			continue
+		}
+		if sunsetDate, ok := sunsetDates[k8sName]; ok {
+			return ErrorAPISplitterReferencesDeprecatedAPI(trafficSplitAPI.Name, sunsetDate)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/k8s"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+	"github.com/cortexlabs/cortex/pkg/types/userconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func restrictedTestAPI(security userconfig.Security) *userconfig.API {
+	security.PodSecurityStandard = pointer.String(k8s.PodSecurityStandardRestricted)
+	return &userconfig.API{
+		Compute:    &userconfig.Compute{},
+		Networking: &userconfig.Networking{},
+		Security:   &security,
+	}
+}
+
+func TestApplyRestrictedPodSecurityStandard(t *testing.T) {
+	api := restrictedTestAPI(userconfig.Security{})
+	require.NoError(t, applyRestrictedPodSecurityStandard(api))
+	require.Equal(t, pointer.Bool(true), api.Security.RunAsNonRoot)
+	require.Equal(t, pointer.String(k8s.SeccompProfileRuntimeDefault), api.Security.SeccompProfile)
+	require.Nil(t, api.Security.AppArmorProfile)
+
+	api = restrictedTestAPI(userconfig.Security{SeccompProfile: pointer.String("localhost/cortex")})
+	require.NoError(t, applyRestrictedPodSecurityStandard(api))
+	require.Equal(t, pointer.String("localhost/cortex"), api.Security.SeccompProfile)
+
+	api = restrictedTestAPI(userconfig.Security{RunAsNonRoot: pointer.Bool(false)})
+	require.Equal(t, ErrRestrictedPodSecurityViolation, errors.GetKind(applyRestrictedPodSecurityStandard(api)))
+
+	api = restrictedTestAPI(userconfig.Security{SeccompProfile: pointer.String(k8s.SeccompProfileUnconfined)})
+	require.Equal(t, ErrRestrictedPodSecurityViolation, errors.GetKind(applyRestrictedPodSecurityStandard(api)))
+
+	api = restrictedTestAPI(userconfig.Security{AppArmorProfile: pointer.String(k8s.AppArmorProfileUnconfined)})
+	require.Equal(t, ErrRestrictedPodSecurityViolation, errors.GetKind(applyRestrictedPodSecurityStandard(api)))
+
+	api = restrictedTestAPI(userconfig.Security{})
+	api.Compute.Inf = 1
+	require.Equal(t, ErrRestrictedPodSecurityIncompatible, errors.GetKind(applyRestrictedPodSecurityStandard(api)))
+
+	api = restrictedTestAPI(userconfig.Security{})
+	api.Networking.EgressAllowlist = []string{"api.example.com"}
+	require.Equal(t, ErrRestrictedPodSecurityIncompatible, errors.GetKind(applyRestrictedPodSecurityStandard(api)))
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package schema
 
 import (
+	"time"
+
 	"github.com/cortexlabs/cortex/pkg/types/clusterconfig"
 	"github.com/cortexlabs/cortex/pkg/types/metrics"
 	"github.com/cortexlabs/cortex/pkg/types/spec"
@@ -46,9 +48,10 @@ type DeployResponse struct {
 }
 
 type DeployResult struct {
-	API     spec.API
-	Message string
-	Error   string
+	API      spec.API
+	Message  string
+	Error    string
+	Warnings []string
 }
 
 type GetAPIsResponse struct {
@@ -57,16 +60,39 @@ type GetAPIsResponse struct {
 }
 
 type SyncAPI struct {
-	Spec         spec.API        `json:"spec"`
-	Status       status.Status   `json:"status"`
-	Metrics      metrics.Metrics `json:"metrics"`
-	BaseURL      string          `json:"base_url"`
-	DashboardURL string          `json:"dashboard_url"`
+	Spec              spec.API            `json:"spec"`
+	Status            status.Status       `json:"status"`
+	Metrics           metrics.Metrics     `json:"metrics"`
+	BaseURL           string              `json:"base_url"`
+	DashboardURL      string              `json:"dashboard_url"`
+	Maintenance       *Maintenance        `json:"maintenance"`
+	Expiration        *time.Time          `json:"expiration"`
+	GoldenDatasetGate *GoldenDatasetGate  `json:"golden_dataset_gate"` // the most recent golden dataset validation of an update to the API
+	Versions          []APIVersionMetrics `json:"versions"`            // the most recent versions of the API, latest first
+}
+
+type APIVersion struct {
+	Version    int              `json:"version"`
+	APIID      string           `json:"api_id"`
+	DeployedAt time.Time        `json:"deployed_at"`
+	Models     []string         `json:"models"` // the model paths of the version's predictor
+	Images     []ContainerImage `json:"images"` // recorded once the version's replicas have started
+}
+
+type APIVersionMetrics struct {
+	APIVersion
+	Metrics metrics.Metrics `json:"metrics"`
+}
+
+type Maintenance struct {
+	StatusCode int    `json:"status_code"`
+	Message    string `json:"message"`
 }
 
 type APISplitter struct {
-	Spec    spec.API `json:"spec"`
-	BaseURL string   `json:"base_url"`
+	Spec       spec.API   `json:"spec"`
+	BaseURL    string     `json:"base_url"`
+	Expiration *time.Time `json:"expiration"`
 }
 
 type GetAPIResponse struct {
@@ -82,6 +108,182 @@ type RefreshResponse struct {
 	Message string `json:"message"`
 }
 
+type MaintenanceResponse struct {
+	Message string `json:"message"`
+}
+
+type ExtendResponse struct {
+	Message string `json:"message"`
+}
+
+type GetPreviewsResponse struct {
+	Previews []Preview `json:"previews"`
+}
+
+type Preview struct {
+	Branch     string     `json:"branch"`
+	APINames   []string   `json:"api_names"`
+	Expiration *time.Time `json:"expiration"` // the earliest expiration of the preview's apis
+}
+
+type GetManifestResponse struct {
+	APIName     string           `json:"api_name"`
+	APIID       string           `json:"api_id"`
+	Images      []ContainerImage `json:"images"`
+	Environment *Environment     `json:"environment"` // nil if no replica has recorded its environment yet
+}
+
+type ContainerImage struct {
+	Container   string `json:"container"`
+	Image       string `json:"image"`
+	ImageDigest string `json:"image_digest"`
+}
+
+// Environment is recorded by the API's replicas when they start
+type Environment struct {
+	PythonVersion       string            `json:"python_version"`
+	PipPackages         []string          `json:"pip_packages"`
+	CondaPackages       []string          `json:"conda_packages"`
+	CUDAVersion         *string           `json:"cuda_version"`
+	CUDNNVersion        *string           `json:"cudnn_version"`
+	NvidiaDriverVersion *string           `json:"nvidia_driver_version"`
+	EnvVars             map[string]string `json:"env_vars"`
+}
+
+type LoadTestStatus string
+
+const (
+	LoadTestRunning   LoadTestStatus = "running"
+	LoadTestSucceeded LoadTestStatus = "succeeded"
+	LoadTestFailed    LoadTestStatus = "failed"
+)
+
+type LoadTestResponse struct {
+	JobID   string          `json:"job_id"`
+	Status  LoadTestStatus  `json:"status"`
+	Message string          `json:"message,omitempty"` // the reason the load test failed
+	Result  *LoadTestResult `json:"result,omitempty"`  // set once the load test has succeeded
+}
+
+type LoadTestResult struct {
+	NumRequests          int                     `json:"num_requests"`
+	NumErrors            int                     `json:"num_errors"`            // requests which did not receive a 2xx response
+	NumConnectionErrors  int                     `json:"num_connection_errors"` // requests which did not receive a response
+	StatusCodes          map[int]int             `json:"status_codes"`
+	ActualRPS            float64                 `json:"actual_rps"`
+	LatencyP50           float64                 `json:"latency_p50"` // milliseconds
+	LatencyP90           float64                 `json:"latency_p90"` // milliseconds
+	LatencyP99           float64                 `json:"latency_p99"` // milliseconds
+	LatencyMax           float64                 `json:"latency_max"` // milliseconds
+	MaxRequestedReplicas int32                   `json:"max_requested_replicas"`
+	ReplicaSamples       []LoadTestReplicaSample `json:"replica_samples"`
+}
+
+type LoadTestReplicaSample struct {
+	Timestamp time.Time `json:"timestamp"`
+	Requested int32     `json:"requested"`
+	Ready     int32     `json:"ready"`
+}
+
+type GoldenDatasetGateStatus string
+
+const (
+	GoldenDatasetGateRunning GoldenDatasetGateStatus = "running"
+	GoldenDatasetGatePassed  GoldenDatasetGateStatus = "passed"  // the update was promoted
+	GoldenDatasetGateBlocked GoldenDatasetGateStatus = "blocked" // the update regressed, and was not promoted
+	GoldenDatasetGateFailed  GoldenDatasetGateStatus = "failed"  // the update could not be validated, and was not promoted
+)
+
+type GoldenDatasetGate struct {
+	APIID     string                   `json:"api_id"` // the version of the API which was validated
+	Status    GoldenDatasetGateStatus  `json:"status"`
+	Message   string                   `json:"message,omitempty"`
+	StartedAt time.Time                `json:"started_at"`
+	EndedAt   *time.Time               `json:"ended_at,omitempty"`
+	Result    *GoldenDatasetGateResult `json:"result,omitempty"`
+}
+
+type GoldenDatasetGateResult struct {
+	NumExamples          int      `json:"num_examples"`
+	Accuracy             float64  `json:"accuracy"`                    // the fraction of examples for which the new version's response matched the expected response
+	BaselineAccuracy     *float64 `json:"baseline_accuracy,omitempty"` // the current version's accuracy (nil if no replica of the current version was available)
+	LatencyP99Ms         float64  `json:"latency_p99_ms"`
+	BaselineLatencyP99Ms *float64 `json:"baseline_latency_p99_ms,omitempty"`
+}
+
+type ReplayStatus string
+
+const (
+	ReplayRunning   ReplayStatus = "running"
+	ReplaySucceeded ReplayStatus = "succeeded"
+	ReplayFailed    ReplayStatus = "failed"
+)
+
+type ReplayResponse struct {
+	ReplayID string        `json:"replay_id"`
+	Status   ReplayStatus  `json:"status"`
+	Message  string        `json:"message,omitempty"` // the reason the replay failed
+	Result   *ReplayResult `json:"result,omitempty"`  // set once the replay has succeeded
+}
+
+type ReplayResult struct {
+	APIName             string           `json:"api_name"`
+	TargetAPIName       string           `json:"target_api_name"`
+	NumRequests         int              `json:"num_requests"`
+	NumMatches          int              `json:"num_matches"`           // the target's status code and response were the same as the logged ones (json responses are compared by value)
+	NumMismatches       int              `json:"num_mismatches"`        // the target responded with a different status code or response
+	NumConnectionErrors int              `json:"num_connection_errors"` // requests which the target did not respond to
+	StatusCodes         map[int]int      `json:"status_codes"`          // the target's status codes
+	LoggedLatencyP50    float64          `json:"logged_latency_p50"`    // milliseconds
+	LoggedLatencyP99    float64          `json:"logged_latency_p99"`    // milliseconds
+	TargetLatencyP50    float64          `json:"target_latency_p50"`    // milliseconds
+	TargetLatencyP99    float64          `json:"target_latency_p99"`    // milliseconds
+	Mismatches          []ReplayMismatch `json:"mismatches"`            // the first mismatches, in the order the requests were logged
+}
+
+type ReplayMismatch struct {
+	RequestID        string `json:"request_id"`
+	LoggedStatusCode int    `json:"logged_status_code"`
+	TargetStatusCode int    `json:"target_status_code"`
+	LoggedResponse   string `json:"logged_response"` // truncated
+	TargetResponse   string `json:"target_response"` // truncated
+}
+
+const (
+	AccessReportFormatCSV  = "csv"
+	AccessReportFormatJSON = "json"
+)
+
+var AccessReportFormats = []string{AccessReportFormatCSV, AccessReportFormatJSON}
+
+// UsageResponse reports the usage of each project between Start (inclusive) and End (exclusive), which are aligned to hours
+type UsageResponse struct {
+	Start    time.Time      `json:"start"`
+	End      time.Time      `json:"end"`
+	Projects []ProjectUsage `json:"projects"`
+}
+
+type ProjectUsage struct {
+	Project        string   `json:"project"`
+	APINames       []string `json:"api_names"`
+	RequestCount   int64    `json:"request_count"`
+	ReplicaHours   float64  `json:"replica_hours"`
+	CPUHours       float64  `json:"cpu_hours"` // requested CPU cores × hours
+	GPUHours       float64  `json:"gpu_hours"`
+	StorageGBHours float64  `json:"storage_gb_hours"` // data stored in the cluster's bucket for the project's APIs
+}
+
+// AuditEvent records a request to the operator which changed the cluster's APIs
+type AuditEvent struct {
+	Time       time.Time         `json:"time"`
+	Identity   string            `json:"identity"`  // ARN of the IAM user or role which made the request
+	ClientID   string            `json:"client_id"` // identifies the CLI installation which made the request
+	Action     string            `json:"action"`    // e.g. deploy, delete, or refresh
+	Resources  []string          `json:"resources"` // names of the APIs which were acted on
+	Parameters map[string]string `json:"parameters"`
+	StatusCode int               `json:"status_code"`
+}
+
 type ErrorResponse struct {
 	Kind    string `json:"kind"`
 	Message string `json:"message"`
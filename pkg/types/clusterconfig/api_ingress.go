@@ -0,0 +1,79 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterconfig
+
+type APIIngress int
+
+const (
+	UnknownAPIIngress APIIngress = iota
+	IstioAPIIngress
+	ALBAPIIngress
+	NGINXAPIIngress
+	GatewayAPIAPIIngress
+)
+
+var _apiIngresses = []string{
+	"unknown",
+	"istio",
+	"alb",
+	"nginx",
+	"gateway-api",
+}
+
+func APIIngressFromString(s string) APIIngress {
+	for i := 0; i < len(_apiIngresses); i++ {
+		if s == _apiIngresses[i] {
+			return APIIngress(i)
+		}
+	}
+	return UnknownAPIIngress
+}
+
+func APIIngressStrings() []string {
+	return _apiIngresses[1:]
+}
+
+func (t APIIngress) String() string {
+	return _apiIngresses[t]
+}
+
+// MarshalText satisfies TextMarshaler
+func (t APIIngress) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText satisfies TextUnmarshaler
+func (t *APIIngress) UnmarshalText(text []byte) error {
+	enum := string(text)
+	for i := 0; i < len(_apiIngresses); i++ {
+		if enum == _apiIngresses[i] {
+			*t = APIIngress(i)
+			return nil
+		}
+	}
+
+	*t = UnknownAPIIngress
+	return nil
+}
+
+// UnmarshalBinary satisfies BinaryUnmarshaler
+// Needed for msgpack
+func (t *APIIngress) UnmarshalBinary(data []byte) error {
+	return t.UnmarshalText(data)
+}
+
+// MarshalBinary satisfies BinaryMarshaler
+func (t APIIngress) MarshalBinary() ([]byte, error) {
+	return []byte(t.String()), nil
+}
@@ -19,6 +19,7 @@ package clusterconfig
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/aws/amazon-vpc-cni-k8s/pkg/awsutils"
@@ -27,60 +28,87 @@ import (
 	"github.com/cortexlabs/cortex/pkg/consts"
 	"github.com/cortexlabs/cortex/pkg/lib/aws"
 	cr "github.com/cortexlabs/cortex/pkg/lib/configreader"
+	"github.com/cortexlabs/cortex/pkg/lib/cosign"
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/hash"
+	"github.com/cortexlabs/cortex/pkg/lib/k8s"
 	libmath "github.com/cortexlabs/cortex/pkg/lib/math"
 	"github.com/cortexlabs/cortex/pkg/lib/pointer"
 	"github.com/cortexlabs/cortex/pkg/lib/prompt"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
 	s "github.com/cortexlabs/cortex/pkg/lib/strings"
 	"github.com/cortexlabs/cortex/pkg/lib/table"
+	"github.com/cortexlabs/cortex/pkg/lib/transport"
 )
 
 const ClusterNameTag = "cortex.dev/cluster-name"
 
+// the repository on Docker Hub which cortex's own images are published to
+const _cortexImagePrefix = "cortexlabs/"
+
 var (
 	_spotInstanceDistributionLength = 2
 	_maxInstancePools               = 20
+	// CloudWatch allows up to 10 dimensions per metric, and cortex uses up to 4 (e.g. APIName, APIID, metric_type, Code)
+	_maxCustomMetricsDimensions = 6
+	_reservedMetricsDimensions  = strset.New("APIName", "APIID", "apiName", "metric_type", "Code", "Class")
 	// This regex is stricter than the actual S3 rules
 	_strictS3BucketRegex = regexp.MustCompile(`^([a-z0-9])+(-[a-z0-9]+)*$`)
 )
 
 type Config struct {
-	InstanceType               *string            `json:"instance_type" yaml:"instance_type"`
-	MinInstances               *int64             `json:"min_instances" yaml:"min_instances"`
-	MaxInstances               *int64             `json:"max_instances" yaml:"max_instances"`
-	InstanceVolumeSize         int64              `json:"instance_volume_size" yaml:"instance_volume_size"`
-	InstanceVolumeType         VolumeType         `json:"instance_volume_type" yaml:"instance_volume_type"`
-	InstanceVolumeIOPS         *int64             `json:"instance_volume_iops" yaml:"instance_volume_iops"`
-	Tags                       map[string]string  `json:"tags" yaml:"tags"`
-	Spot                       *bool              `json:"spot" yaml:"spot"`
-	SpotConfig                 *SpotConfig        `json:"spot_config" yaml:"spot_config"`
-	ClusterName                string             `json:"cluster_name" yaml:"cluster_name"`
-	Region                     *string            `json:"region" yaml:"region"`
-	AvailabilityZones          []string           `json:"availability_zones" yaml:"availability_zones"`
-	SSLCertificateARN          *string            `json:"ssl_certificate_arn,omitempty" yaml:"ssl_certificate_arn,omitempty"`
-	Bucket                     string             `json:"bucket" yaml:"bucket"`
-	LogGroup                   string             `json:"log_group" yaml:"log_group"`
-	SubnetVisibility           SubnetVisibility   `json:"subnet_visibility" yaml:"subnet_visibility"`
-	NATGateway                 NATGateway         `json:"nat_gateway" yaml:"nat_gateway"`
-	APILoadBalancerScheme      LoadBalancerScheme `json:"api_load_balancer_scheme" yaml:"api_load_balancer_scheme"`
-	OperatorLoadBalancerScheme LoadBalancerScheme `json:"operator_load_balancer_scheme" yaml:"operator_load_balancer_scheme"`
-	Telemetry                  bool               `json:"telemetry" yaml:"telemetry"`
-	ImageOperator              string             `json:"image_operator" yaml:"image_operator"`
-	ImageManager               string             `json:"image_manager" yaml:"image_manager"`
-	ImageDownloader            string             `json:"image_downloader" yaml:"image_downloader"`
-	ImageRequestMonitor        string             `json:"image_request_monitor" yaml:"image_request_monitor"`
-	ImageClusterAutoscaler     string             `json:"image_cluster_autoscaler" yaml:"image_cluster_autoscaler"`
-	ImageMetricsServer         string             `json:"image_metrics_server" yaml:"image_metrics_server"`
-	ImageInferentia            string             `json:"image_inferentia" yaml:"image_inferentia"`
-	ImageNeuronRTD             string             `json:"image_neuron_rtd" yaml:"image_neuron_rtd"`
-	ImageNvidia                string             `json:"image_nvidia" yaml:"image_nvidia"`
-	ImageFluentd               string             `json:"image_fluentd" yaml:"image_fluentd"`
-	ImageStatsd                string             `json:"image_statsd" yaml:"image_statsd"`
-	ImageIstioProxy            string             `json:"image_istio_proxy" yaml:"image_istio_proxy"`
-	ImageIstioPilot            string             `json:"image_istio_pilot" yaml:"image_istio_pilot"`
-	ImageIstioCitadel          string             `json:"image_istio_citadel" yaml:"image_istio_citadel"`
-	ImageIstioGalley           string             `json:"image_istio_galley" yaml:"image_istio_galley"`
+	InstanceType               *string               `json:"instance_type" yaml:"instance_type"`
+	MinInstances               *int64                `json:"min_instances" yaml:"min_instances"`
+	MaxInstances               *int64                `json:"max_instances" yaml:"max_instances"`
+	InstanceVolumeSize         int64                 `json:"instance_volume_size" yaml:"instance_volume_size"`
+	InstanceVolumeType         VolumeType            `json:"instance_volume_type" yaml:"instance_volume_type"`
+	InstanceVolumeIOPS         *int64                `json:"instance_volume_iops" yaml:"instance_volume_iops"`
+	CPUManagerPolicy           CPUManagerPolicy      `json:"cpu_manager_policy" yaml:"cpu_manager_policy"`
+	TopologyManagerPolicy      TopologyManagerPolicy `json:"topology_manager_policy" yaml:"topology_manager_policy"`
+	InstanceHugePages          int64                 `json:"instance_hugepages" yaml:"instance_hugepages"`
+	Tags                       map[string]string     `json:"tags" yaml:"tags"`
+	Spot                       *bool                 `json:"spot" yaml:"spot"`
+	SpotConfig                 *SpotConfig           `json:"spot_config" yaml:"spot_config"`
+	ClusterName                string                `json:"cluster_name" yaml:"cluster_name"`
+	Region                     *string               `json:"region" yaml:"region"`
+	AvailabilityZones          []string              `json:"availability_zones" yaml:"availability_zones"`
+	SSLCertificateARN          *string               `json:"ssl_certificate_arn,omitempty" yaml:"ssl_certificate_arn,omitempty"`
+	Bucket                     string                `json:"bucket" yaml:"bucket"`
+	LogGroup                   string                `json:"log_group" yaml:"log_group"`
+	MetricsNamespace           string                `json:"metrics_namespace" yaml:"metrics_namespace"`
+	MetricsDimensions          map[string]string     `json:"metrics_dimensions" yaml:"metrics_dimensions"`
+	SubnetVisibility           SubnetVisibility      `json:"subnet_visibility" yaml:"subnet_visibility"`
+	NATGateway                 NATGateway            `json:"nat_gateway" yaml:"nat_gateway"`
+	APILoadBalancerScheme      LoadBalancerScheme    `json:"api_load_balancer_scheme" yaml:"api_load_balancer_scheme"`
+	APIIngress                 APIIngress            `json:"api_ingress" yaml:"api_ingress"`
+	GatewayAPIParent           *GatewayAPIParent     `json:"gateway_api_parent" yaml:"gateway_api_parent"`
+	OperatorLoadBalancerScheme LoadBalancerScheme    `json:"operator_load_balancer_scheme" yaml:"operator_load_balancer_scheme"`
+	OperatorServer             *OperatorServer       `json:"operator_server" yaml:"operator_server"`
+	OperatorPrivateLink        *OperatorPrivateLink  `json:"operator_private_link" yaml:"operator_private_link"`
+	Proxy                      *Proxy                `json:"proxy" yaml:"proxy"`
+	APISecurityPolicy          *APISecurityPolicy    `json:"api_security_policy" yaml:"api_security_policy"`
+	ImageSignaturePolicy       *ImageSignaturePolicy `json:"image_signature_policy" yaml:"image_signature_policy"`
+	AccessReportBucket         *string               `json:"access_report_bucket" yaml:"access_report_bucket"`
+	AirGapped                  bool                  `json:"air_gapped" yaml:"air_gapped"`
+	ImageRegistry              *string               `json:"image_registry" yaml:"image_registry"`
+	Telemetry                  bool                  `json:"telemetry" yaml:"telemetry"`
+	ImageOperator              string                `json:"image_operator" yaml:"image_operator"`
+	ImageManager               string                `json:"image_manager" yaml:"image_manager"`
+	ImageDownloader            string                `json:"image_downloader" yaml:"image_downloader"`
+	ImageRequestMonitor        string                `json:"image_request_monitor" yaml:"image_request_monitor"`
+	ImageEgressProxy           string                `json:"image_egress_proxy" yaml:"image_egress_proxy"`
+	ImageLoadTester            string                `json:"image_load_tester" yaml:"image_load_tester"`
+	ImageClusterAutoscaler     string                `json:"image_cluster_autoscaler" yaml:"image_cluster_autoscaler"`
+	ImageMetricsServer         string                `json:"image_metrics_server" yaml:"image_metrics_server"`
+	ImageInferentia            string                `json:"image_inferentia" yaml:"image_inferentia"`
+	ImageNeuronRTD             string                `json:"image_neuron_rtd" yaml:"image_neuron_rtd"`
+	ImageNvidia                string                `json:"image_nvidia" yaml:"image_nvidia"`
+	ImageFluentd               string                `json:"image_fluentd" yaml:"image_fluentd"`
+	ImageStatsd                string                `json:"image_statsd" yaml:"image_statsd"`
+	ImageIstioProxy            string                `json:"image_istio_proxy" yaml:"image_istio_proxy"`
+	ImageIstioPilot            string                `json:"image_istio_pilot" yaml:"image_istio_pilot"`
+	ImageIstioCitadel          string                `json:"image_istio_citadel" yaml:"image_istio_citadel"`
+	ImageIstioGalley           string                `json:"image_istio_galley" yaml:"image_istio_galley"`
 }
 
 type SpotConfig struct {
@@ -92,6 +120,46 @@ type SpotConfig struct {
 	OnDemandBackup                      *bool    `json:"on_demand_backup" yaml:"on_demand_backup"`
 }
 
+type OperatorServer struct {
+	ReadHeaderTimeout  int64   `json:"read_header_timeout" yaml:"read_header_timeout"`     // seconds
+	ReadTimeout        int64   `json:"read_timeout" yaml:"read_timeout"`                   // seconds
+	WriteTimeout       int64   `json:"write_timeout" yaml:"write_timeout"`                 // seconds
+	IdleTimeout        int64   `json:"idle_timeout" yaml:"idle_timeout"`                   // seconds
+	MaxHeaderSize      int64   `json:"max_header_size" yaml:"max_header_size"`             // Ki
+	MaxRequestBodySize int64   `json:"max_request_body_size" yaml:"max_request_body_size"` // Mi
+	SSLCertificateARN  *string `json:"ssl_certificate_arn" yaml:"ssl_certificate_arn"`
+	Replicas           int64   `json:"replicas" yaml:"replicas"`
+}
+
+type GatewayAPIParent struct {
+	Name      string `json:"name" yaml:"name"`
+	Namespace string `json:"namespace" yaml:"namespace"`
+}
+
+type OperatorPrivateLink struct {
+	AllowedPrincipals  []string `json:"allowed_principals" yaml:"allowed_principals"`
+	AcceptanceRequired bool     `json:"acceptance_required" yaml:"acceptance_required"`
+}
+
+type Proxy struct {
+	HTTPSProxy string   `json:"https_proxy" yaml:"https_proxy"`
+	NoProxy    []string `json:"no_proxy" yaml:"no_proxy"`
+	CABundle   *string  `json:"ca_bundle" yaml:"ca_bundle"` // PEM-encoded certificate authorities to trust in addition to the system's
+}
+
+type APISecurityPolicy struct {
+	RunAsNonRoot           bool     `json:"run_as_non_root" yaml:"run_as_non_root"`
+	ReadOnlyRootFilesystem bool     `json:"read_only_root_filesystem" yaml:"read_only_root_filesystem"`
+	SeccompProfile         *string  `json:"seccomp_profile" yaml:"seccomp_profile"`
+	AppArmorProfile        *string  `json:"apparmor_profile" yaml:"apparmor_profile"`
+	PodSecurityStandard    string   `json:"pod_security_standard" yaml:"pod_security_standard"`
+	PodSecurityExemptAPIs  []string `json:"pod_security_exempt_apis" yaml:"pod_security_exempt_apis"`
+}
+
+type ImageSignaturePolicy struct {
+	PublicKeys []string `json:"public_keys" yaml:"public_keys"` // PEM-encoded cosign public keys
+}
+
 type InternalConfig struct {
 	Config
 
@@ -107,9 +175,10 @@ type InternalConfig struct {
 
 // The bare minimum to identify a cluster
 type AccessConfig struct {
-	ClusterName  *string `json:"cluster_name" yaml:"cluster_name"`
-	Region       *string `json:"region" yaml:"region"`
-	ImageManager string  `json:"image_manager" yaml:"image_manager"`
+	ClusterName   *string `json:"cluster_name" yaml:"cluster_name"`
+	Region        *string `json:"region" yaml:"region"`
+	ImageRegistry *string `json:"image_registry" yaml:"image_registry"`
+	ImageManager  string  `json:"image_manager" yaml:"image_manager"`
 }
 
 var UserValidation = &cr.StructValidation{
@@ -173,6 +242,33 @@ var UserValidation = &cr.StructValidation{
 				AllowExplicitNull:    true,
 			},
 		},
+		{
+			StructField: "CPUManagerPolicy",
+			StringValidation: &cr.StringValidation{
+				AllowedValues: CPUManagerPolicyStrings(),
+				Default:       NoneCPUManagerPolicy.String(),
+			},
+			Parser: func(str string) (interface{}, error) {
+				return CPUManagerPolicyFromString(str), nil
+			},
+		},
+		{
+			StructField: "TopologyManagerPolicy",
+			StringValidation: &cr.StringValidation{
+				AllowedValues: TopologyManagerPolicyStrings(),
+				Default:       NoneTopologyManagerPolicy.String(),
+			},
+			Parser: func(str string) (interface{}, error) {
+				return TopologyManagerPolicyFromString(str), nil
+			},
+		},
+		{
+			StructField: "InstanceHugePages",
+			Int64Validation: &cr.Int64Validation{
+				Default:              0,
+				GreaterThanOrEqualTo: pointer.Int64(0),
+			},
+		},
 		{
 			StructField: "Spot",
 			BoolPtrValidation: &cr.BoolPtrValidation{
@@ -271,6 +367,21 @@ var UserValidation = &cr.StructValidation{
 			},
 			DefaultField: "ClusterName",
 		},
+		{
+			StructField: "MetricsNamespace",
+			StringValidation: &cr.StringValidation{
+				MaxLength: 255,
+			},
+			DefaultField: "ClusterName",
+		},
+		{
+			StructField: "MetricsDimensions",
+			StringMapValidation: &cr.StringMapValidation{
+				AllowExplicitNull:  true,
+				AllowEmpty:         true,
+				ConvertNullToEmpty: true,
+			},
+		},
 		{
 			StructField: "SubnetVisibility",
 			StringValidation: &cr.StringValidation{
@@ -307,6 +418,39 @@ var UserValidation = &cr.StructValidation{
 				return LoadBalancerSchemeFromString(str), nil
 			},
 		},
+		{
+			StructField: "APIIngress",
+			StringValidation: &cr.StringValidation{
+				AllowedValues: APIIngressStrings(),
+				Default:       IstioAPIIngress.String(),
+			},
+			Parser: func(str string) (interface{}, error) {
+				return APIIngressFromString(str), nil
+			},
+		},
+		{
+			StructField: "GatewayAPIParent",
+			StructValidation: &cr.StructValidation{
+				DefaultNil:        true,
+				AllowExplicitNull: true,
+				StructFieldValidations: []*cr.StructFieldValidation{
+					{
+						StructField: "Name",
+						StringValidation: &cr.StringValidation{
+							Required: true,
+							DNS1123:  true,
+						},
+					},
+					{
+						StructField: "Namespace",
+						StringValidation: &cr.StringValidation{
+							Required: true,
+							DNS1123:  true,
+						},
+					},
+				},
+			},
+		},
 		{
 			StructField: "OperatorLoadBalancerScheme",
 			StringValidation: &cr.StringValidation{
@@ -317,6 +461,206 @@ var UserValidation = &cr.StructValidation{
 				return LoadBalancerSchemeFromString(str), nil
 			},
 		},
+		{
+			StructField: "OperatorServer",
+			StructValidation: &cr.StructValidation{
+				StructFieldValidations: []*cr.StructFieldValidation{
+					{
+						StructField: "ReadHeaderTimeout",
+						Int64Validation: &cr.Int64Validation{
+							Default:     10,
+							GreaterThan: pointer.Int64(0),
+						},
+					},
+					{
+						StructField: "ReadTimeout",
+						Int64Validation: &cr.Int64Validation{
+							Default:     300,
+							GreaterThan: pointer.Int64(0),
+						},
+					},
+					{
+						StructField: "WriteTimeout",
+						Int64Validation: &cr.Int64Validation{
+							Default:     600, // the cli waits up to 600 seconds for the operator to respond
+							GreaterThan: pointer.Int64(0),
+						},
+					},
+					{
+						StructField: "IdleTimeout",
+						Int64Validation: &cr.Int64Validation{
+							Default:     120,
+							GreaterThan: pointer.Int64(0),
+						},
+					},
+					{
+						StructField: "MaxHeaderSize",
+						Int64Validation: &cr.Int64Validation{
+							Default:           64,
+							GreaterThan:       pointer.Int64(0),
+							LessThanOrEqualTo: pointer.Int64(1024),
+						},
+					},
+					{
+						StructField: "MaxRequestBodySize",
+						Int64Validation: &cr.Int64Validation{
+							Default:     600, // project zips can be up to 512Mi
+							GreaterThan: pointer.Int64(0),
+						},
+					},
+					{
+						StructField: "SSLCertificateARN",
+						StringPtrValidation: &cr.StringPtrValidation{
+							AllowExplicitNull: true,
+						},
+					},
+					{
+						StructField: "Replicas",
+						Int64Validation: &cr.Int64Validation{
+							Default:              1,
+							GreaterThanOrEqualTo: pointer.Int64(1),
+							LessThanOrEqualTo:    pointer.Int64(3), // the replicas share the operator's instance
+						},
+					},
+				},
+			},
+		},
+		{
+			StructField: "OperatorPrivateLink",
+			StructValidation: &cr.StructValidation{
+				DefaultNil:        true,
+				AllowExplicitNull: true,
+				StructFieldValidations: []*cr.StructFieldValidation{
+					{
+						StructField: "AllowedPrincipals",
+						StringListValidation: &cr.StringListValidation{
+							AllowEmpty:        true,
+							AllowExplicitNull: true,
+							DisallowDups:      true,
+							Validator:         validatePrincipals,
+						},
+					},
+					{
+						StructField: "AcceptanceRequired",
+						BoolValidation: &cr.BoolValidation{
+							Default: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			StructField: "Proxy",
+			StructValidation: &cr.StructValidation{
+				DefaultNil:        true,
+				AllowExplicitNull: true,
+				StructFieldValidations: []*cr.StructFieldValidation{
+					{
+						StructField: "HTTPSProxy",
+						StringValidation: &cr.StringValidation{
+							Required:  true,
+							Validator: cr.GetURLValidator(false, false),
+						},
+					},
+					{
+						StructField: "NoProxy",
+						StringListValidation: &cr.StringListValidation{
+							AllowEmpty:        true,
+							AllowExplicitNull: true,
+							DisallowDups:      true,
+						},
+					},
+					{
+						StructField: "CABundle",
+						StringPtrValidation: &cr.StringPtrValidation{
+							AllowExplicitNull: true,
+							Validator:         transport.ValidateCABundle,
+						},
+					},
+				},
+			},
+		},
+		{
+			StructField: "APISecurityPolicy",
+			StructValidation: &cr.StructValidation{
+				DefaultNil:        true,
+				AllowExplicitNull: true,
+				StructFieldValidations: []*cr.StructFieldValidation{
+					{
+						StructField:    "RunAsNonRoot",
+						BoolValidation: &cr.BoolValidation{},
+					},
+					{
+						StructField:    "ReadOnlyRootFilesystem",
+						BoolValidation: &cr.BoolValidation{},
+					},
+					{
+						StructField: "SeccompProfile",
+						StringPtrValidation: &cr.StringPtrValidation{
+							AllowExplicitNull: true,
+							Validator:         k8s.ValidateSeccompProfile,
+						},
+					},
+					{
+						StructField: "AppArmorProfile",
+						StringPtrValidation: &cr.StringPtrValidation{
+							AllowExplicitNull: true,
+							Validator:         k8s.ValidateAppArmorProfile,
+						},
+					},
+					{
+						StructField: "PodSecurityStandard",
+						StringValidation: &cr.StringValidation{
+							Default:       k8s.PodSecurityStandardPrivileged,
+							AllowedValues: k8s.PodSecurityStandards,
+						},
+					},
+					{
+						StructField: "PodSecurityExemptAPIs",
+						StringListValidation: &cr.StringListValidation{
+							AllowEmpty:        true,
+							AllowExplicitNull: true,
+							DisallowDups:      true,
+						},
+					},
+				},
+			},
+		},
+		{
+			StructField: "ImageSignaturePolicy",
+			StructValidation: &cr.StructValidation{
+				DefaultNil:        true,
+				AllowExplicitNull: true,
+				StructFieldValidations: []*cr.StructFieldValidation{
+					{
+						StructField: "PublicKeys",
+						StringListValidation: &cr.StringListValidation{
+							Required:     true,
+							DisallowDups: true,
+							Validator:    cosign.ValidatePublicKeys,
+						},
+					},
+				},
+			},
+		},
+		{
+			StructField: "AccessReportBucket",
+			StringPtrValidation: &cr.StringPtrValidation{
+				AllowExplicitNull: true,
+				Validator:         validateBucketName,
+			},
+		},
+		{
+			StructField:    "AirGapped",
+			BoolValidation: &cr.BoolValidation{},
+		},
+		{
+			StructField: "ImageRegistry",
+			StringPtrValidation: &cr.StringPtrValidation{
+				AllowExplicitNull: true,
+				Validator:         validateImageRegistry,
+			},
+		},
 		{
 			StructField: "ImageOperator",
 			StringValidation: &cr.StringValidation{
@@ -345,6 +689,20 @@ var UserValidation = &cr.StructValidation{
 				Validator: validateImageVersion,
 			},
 		},
+		{
+			StructField: "ImageEgressProxy",
+			StringValidation: &cr.StringValidation{
+				Default:   "cortexlabs/egress-proxy:" + consts.CortexVersion,
+				Validator: validateImageVersion,
+			},
+		},
+		{
+			StructField: "ImageLoadTester",
+			StringValidation: &cr.StringValidation{
+				Default:   "cortexlabs/load-tester:" + consts.CortexVersion,
+				Validator: validateImageVersion,
+			},
+		},
 		{
 			StructField: "ImageClusterAutoscaler",
 			StringValidation: &cr.StringValidation{
@@ -488,6 +846,13 @@ var AccessValidation = &cr.StructValidation{
 				Validator: RegionValidator,
 			},
 		},
+		{
+			StructField: "ImageRegistry",
+			StringPtrValidation: &cr.StringPtrValidation{
+				AllowExplicitNull: true,
+				Validator:         validateImageRegistry,
+			},
+		},
 		{
 			StructField: "ImageManager",
 			StringValidation: &cr.StringValidation{
@@ -502,10 +867,60 @@ func (cc *Config) ToAccessConfig() AccessConfig {
 	clusterName := cc.ClusterName
 	region := *cc.Region
 	return AccessConfig{
-		ClusterName:  &clusterName,
-		Region:       &region,
-		ImageManager: cc.ImageManager,
+		ClusterName:   &clusterName,
+		Region:        &region,
+		ImageRegistry: cc.ImageRegistry,
+		ImageManager:  cc.ImageManager,
+	}
+}
+
+// Images returns pointers to the cluster's image fields, keyed by their config keys
+func (cc *Config) Images() map[string]*string {
+	return map[string]*string{
+		ImageOperatorKey:          &cc.ImageOperator,
+		ImageManagerKey:           &cc.ImageManager,
+		ImageDownloaderKey:        &cc.ImageDownloader,
+		ImageRequestMonitorKey:    &cc.ImageRequestMonitor,
+		ImageEgressProxyKey:       &cc.ImageEgressProxy,
+		ImageLoadTesterKey:        &cc.ImageLoadTester,
+		ImageClusterAutoscalerKey: &cc.ImageClusterAutoscaler,
+		ImageMetricsServerKey:     &cc.ImageMetricsServer,
+		ImageInferentiaKey:        &cc.ImageInferentia,
+		ImageNeuronRTDKey:         &cc.ImageNeuronRTD,
+		ImageNvidiaKey:            &cc.ImageNvidia,
+		ImageFluentdKey:           &cc.ImageFluentd,
+		ImageStatsdKey:            &cc.ImageStatsd,
+		ImageIstioProxyKey:        &cc.ImageIstioProxy,
+		ImageIstioPilotKey:        &cc.ImageIstioPilot,
+		ImageIstioCitadelKey:      &cc.ImageIstioCitadel,
+		ImageIstioGalleyKey:       &cc.ImageIstioGalley,
+	}
+}
+
+// MirroredImage returns the path of one of cortex's own images (i.e. cortexlabs/* on Docker Hub) in the image registry
+// which mirrors them; other images, and all images when there is no mirror, are returned unchanged
+func MirroredImage(image string, imageRegistry *string) string {
+	if imageRegistry == nil || !strings.HasPrefix(image, _cortexImagePrefix) {
+		return image
+	}
+	return *imageRegistry + "/" + strings.TrimPrefix(image, _cortexImagePrefix)
+}
+
+// UnmirroredImage is the inverse of MirroredImage
+func UnmirroredImage(image string, imageRegistry *string) string {
+	if imageRegistry == nil || !strings.HasPrefix(image, *imageRegistry+"/") {
+		return image
 	}
+	return _cortexImagePrefix + strings.TrimPrefix(image, *imageRegistry+"/")
+}
+
+// isDockerHubImage returns whether the image is pulled from Docker Hub, i.e. whether its path doesn't start with a registry host
+func isDockerHubImage(image string) bool {
+	firstComponent := strings.Split(image, "/")[0]
+	if !strings.Contains(image, "/") {
+		return true
+	}
+	return !strings.ContainsAny(firstComponent, ".:") && firstComponent != "localhost"
 }
 
 func (cc *Config) Validate(awsClient *aws.Client) error {
@@ -519,6 +934,10 @@ func (cc *Config) Validate(awsClient *aws.Client) error {
 		return ErrorNATRequiredWithPrivateSubnetVisibility()
 	}
 
+	if cc.APIIngress == GatewayAPIAPIIngress && cc.GatewayAPIParent == nil {
+		return ErrorGatewayAPIParentRequired()
+	}
+
 	if cc.Bucket == "" {
 		accountID, _, err := awsClient.GetCachedAccountID()
 		if err != nil {
@@ -559,6 +978,17 @@ func (cc *Config) Validate(awsClient *aws.Client) error {
 		}
 	}
 
+	if cc.OperatorServer.SSLCertificateARN != nil {
+		exists, err := awsClient.DoesCertificateExist(*cc.OperatorServer.SSLCertificateARN)
+		if err != nil {
+			return errors.Wrap(err, OperatorServerKey, SSLCertificateARNKey)
+		}
+
+		if !exists {
+			return errors.Wrap(ErrorSSLCertificateARNNotFound(*cc.OperatorServer.SSLCertificateARN, *cc.Region), OperatorServerKey, SSLCertificateARNKey)
+		}
+	}
+
 	// Throw error if IOPS defined for other storage than io1
 	if cc.InstanceVolumeType != IO1VolumeType && cc.InstanceVolumeIOPS != nil {
 		return ErrorIOPSNotSupported(cc.InstanceVolumeType)
@@ -574,6 +1004,28 @@ func (cc *Config) Validate(awsClient *aws.Client) error {
 		cc.InstanceVolumeIOPS = pointer.Int64(libmath.MinInt64(cc.InstanceVolumeSize*50, 3000))
 	}
 
+	if cc.TopologyManagerPolicy != NoneTopologyManagerPolicy && cc.CPUManagerPolicy != StaticCPUManagerPolicy {
+		return ErrorTopologyPolicyRequiresStaticCPUPolicy(cc.TopologyManagerPolicy)
+	}
+
+	if cc.CPUManagerPolicy == StaticCPUManagerPolicy {
+		// the static policy reserves a whole core for system daemons, so at least one other core is needed for exclusive allocation
+		if instanceCPU := aws.InstanceMetadatas[*cc.Region][primaryInstanceType].CPU; instanceCPU.Value() < 2 {
+			return errors.Wrap(ErrorStaticCPUPolicyInstanceTooSmall(primaryInstanceType, instanceCPU.Value()), CPUManagerPolicyKey)
+		}
+	}
+
+	if cc.InstanceHugePages > 0 {
+		instanceMetadata := aws.InstanceMetadatas[*cc.Region][primaryInstanceType]
+		// the neuron runtime daemon allocates the huge pages on Inferentia instances
+		if instanceMetadata.Inf > 0 {
+			return errors.Wrap(ErrorInstanceHugePagesNotSupportedOnInf(primaryInstanceType), InstanceHugePagesKey)
+		}
+		if hugePagesMem := cc.InstanceHugePages * consts.HugePageSize; hugePagesMem >= instanceMetadata.Memory.Value()/2 {
+			return errors.Wrap(ErrorInstanceHugePagesTooLarge(cc.InstanceHugePages, primaryInstanceType, instanceMetadata.Memory.Value()/2/consts.HugePageSize), InstanceHugePagesKey)
+		}
+	}
+
 	if err := awsClient.VerifyInstanceQuota(primaryInstanceType); err != nil {
 		// Skip AWS errors, since some regions (e.g. eu-north-1) do not support this API
 		if _, ok := errors.CauseOrSelf(err).(awserr.Error); !ok {
@@ -581,11 +1033,34 @@ func (cc *Config) Validate(awsClient *aws.Client) error {
 		}
 	}
 
+	for _, image := range cc.Images() {
+		*image = MirroredImage(*image, cc.ImageRegistry)
+	}
+
+	if cc.AirGapped {
+		cc.Telemetry = false
+
+		var publicImageKeys []string
+		for key, image := range cc.Images() {
+			if isDockerHubImage(*image) {
+				publicImageKeys = append(publicImageKeys, key)
+			}
+		}
+		if len(publicImageKeys) > 0 {
+			sort.Strings(publicImageKeys)
+			return errors.Wrap(ErrorAirGappedPublicImages(publicImageKeys), AirGappedKey)
+		}
+	}
+
 	if cc.Tags[ClusterNameTag] != "" && cc.Tags[ClusterNameTag] != cc.ClusterName {
 		return ErrorCantOverrideDefaultTag()
 	}
 	cc.Tags[ClusterNameTag] = cc.ClusterName
 
+	if err := validateMetricsDimensions(cc.MetricsDimensions); err != nil {
+		return errors.Wrap(err, MetricsDimensionsKey)
+	}
+
 	if err := cc.validateAvailabilityZones(awsClient); err != nil {
 		return errors.Wrap(err, AvailabilityZonesKey)
 	}
@@ -629,6 +1104,26 @@ func (cc *Config) Validate(awsClient *aws.Client) error {
 	return nil
 }
 
+func validateMetricsDimensions(dimensions map[string]string) error {
+	if len(dimensions) > _maxCustomMetricsDimensions {
+		return ErrorTooManyMetricsDimensions(len(dimensions), _maxCustomMetricsDimensions)
+	}
+
+	for name, value := range dimensions {
+		if _reservedMetricsDimensions.Has(name) {
+			return ErrorReservedMetricsDimension(name, _reservedMetricsDimensions.SliceSorted())
+		}
+		if name == "" || len(name) > 255 {
+			return ErrorInvalidMetricsDimension(name)
+		}
+		if value == "" || len(value) > 255 {
+			return errors.Wrap(ErrorInvalidMetricsDimension(value), name)
+		}
+	}
+
+	return nil
+}
+
 func CheckCortexSupport(instanceMetadata aws.InstanceMetadata) error {
 	if strings.HasSuffix(instanceMetadata.Type, "nano") ||
 		strings.HasSuffix(instanceMetadata.Type, "micro") {
@@ -951,6 +1446,23 @@ func validateBucketName(bucket string) (string, error) {
 	return bucket, nil
 }
 
+func validateImageRegistry(imageRegistry string) (string, error) {
+	imageRegistry = strings.TrimSuffix(imageRegistry, "/")
+	if strings.Contains(imageRegistry, "://") || isDockerHubImage(imageRegistry+"/image") {
+		return "", ErrorInvalidImageRegistry(imageRegistry)
+	}
+	return imageRegistry, nil
+}
+
+func validatePrincipals(principals []string) ([]string, error) {
+	for _, principal := range principals {
+		if principal != "*" && !strings.HasPrefix(principal, "arn:") {
+			return nil, ErrorInvalidPrincipal(principal)
+		}
+	}
+	return principals, nil
+}
+
 func validateInstanceType(instanceType string) (string, error) {
 	var foundInstance *aws.InstanceMetadata
 	for _, instanceMap := range aws.InstanceMetadatas {
@@ -1044,6 +1556,9 @@ func (cc *Config) UserTable() table.KeyValuePairs {
 	items.Add(InstanceVolumeSizeUserKey, cc.InstanceVolumeSize)
 	items.Add(InstanceVolumeTypeUserKey, cc.InstanceVolumeType)
 	items.Add(InstanceVolumeIOPSUserKey, cc.InstanceVolumeIOPS)
+	items.Add(CPUManagerPolicyUserKey, cc.CPUManagerPolicy)
+	items.Add(TopologyManagerPolicyUserKey, cc.TopologyManagerPolicy)
+	items.Add(InstanceHugePagesUserKey, cc.InstanceHugePages)
 	items.Add(SpotUserKey, s.YesNo(*cc.Spot))
 
 	if cc.Spot != nil && *cc.Spot {
@@ -1055,15 +1570,76 @@ func (cc *Config) UserTable() table.KeyValuePairs {
 		items.Add(OnDemandBackupUserKey, s.YesNo(*cc.SpotConfig.OnDemandBackup))
 	}
 	items.Add(LogGroupUserKey, cc.LogGroup)
+	items.Add(MetricsNamespaceUserKey, cc.MetricsNamespace)
+	if len(cc.MetricsDimensions) > 0 {
+		items.Add(MetricsDimensionsUserKey, s.ObjFlat(cc.MetricsDimensions))
+	}
 	items.Add(SubnetVisibilityUserKey, cc.SubnetVisibility)
 	items.Add(NATGatewayUserKey, cc.NATGateway)
 	items.Add(APILoadBalancerSchemeUserKey, cc.APILoadBalancerScheme)
+	items.Add(APIIngressUserKey, cc.APIIngress)
+	if cc.GatewayAPIParent != nil {
+		items.Add(GatewayAPIParentUserKey, cc.GatewayAPIParent.Namespace+"/"+cc.GatewayAPIParent.Name)
+	}
 	items.Add(OperatorLoadBalancerSchemeUserKey, cc.OperatorLoadBalancerScheme)
+	items.Add(OperatorReadHeaderTimeoutUserKey, cc.OperatorServer.ReadHeaderTimeout)
+	items.Add(OperatorReadTimeoutUserKey, cc.OperatorServer.ReadTimeout)
+	items.Add(OperatorWriteTimeoutUserKey, cc.OperatorServer.WriteTimeout)
+	items.Add(OperatorIdleTimeoutUserKey, cc.OperatorServer.IdleTimeout)
+	items.Add(OperatorMaxHeaderSizeUserKey, cc.OperatorServer.MaxHeaderSize)
+	items.Add(OperatorMaxRequestBodySizeUserKey, cc.OperatorServer.MaxRequestBodySize)
+	items.Add(OperatorReplicasUserKey, cc.OperatorServer.Replicas)
+	if cc.OperatorServer.SSLCertificateARN != nil {
+		items.Add(OperatorSSLCertificateARNUserKey, *cc.OperatorServer.SSLCertificateARN)
+	}
+	if cc.Proxy != nil {
+		items.Add(HTTPSProxyUserKey, cc.Proxy.HTTPSProxy)
+		if len(cc.Proxy.NoProxy) > 0 {
+			items.Add(NoProxyUserKey, s.StrsAnd(cc.Proxy.NoProxy))
+		}
+		items.Add(CABundleUserKey, s.YesNo(cc.Proxy.CABundle != nil))
+	}
+	if cc.OperatorPrivateLink != nil {
+		items.Add(OperatorPrivateLinkAllowedPrincipalsUserKey, cc.OperatorPrivateLink.AllowedPrincipals)
+		items.Add(OperatorPrivateLinkAcceptanceRequiredUserKey, s.YesNo(cc.OperatorPrivateLink.AcceptanceRequired))
+	}
+	if cc.APISecurityPolicy != nil {
+		items.Add(RunAsNonRootUserKey, s.YesNo(cc.APISecurityPolicy.RunAsNonRoot))
+		items.Add(ReadOnlyRootFilesystemUserKey, s.YesNo(cc.APISecurityPolicy.ReadOnlyRootFilesystem))
+		if cc.APISecurityPolicy.SeccompProfile != nil {
+			items.Add(SeccompProfileUserKey, *cc.APISecurityPolicy.SeccompProfile)
+		}
+		if cc.APISecurityPolicy.AppArmorProfile != nil {
+			items.Add(AppArmorProfileUserKey, *cc.APISecurityPolicy.AppArmorProfile)
+		}
+		items.Add(PodSecurityStandardUserKey, cc.APISecurityPolicy.PodSecurityStandard)
+		if len(cc.APISecurityPolicy.PodSecurityExemptAPIs) > 0 {
+			items.Add(PodSecurityExemptAPIsUserKey, s.StrsAnd(cc.APISecurityPolicy.PodSecurityExemptAPIs))
+		}
+	}
+	if cc.ImageSignaturePolicy != nil {
+		keyIDs := make([]string, len(cc.ImageSignaturePolicy.PublicKeys))
+		for i, publicKey := range cc.ImageSignaturePolicy.PublicKeys {
+			keyIDs[i], _ = cosign.KeyID(publicKey)
+		}
+		items.Add(ImageSignaturePublicKeysUserKey, s.StrsAnd(keyIDs))
+	}
+	if cc.AccessReportBucket != nil {
+		items.Add(AccessReportBucketUserKey, *cc.AccessReportBucket)
+	}
+	if cc.AirGapped {
+		items.Add(AirGappedUserKey, s.YesNo(cc.AirGapped))
+	}
+	if cc.ImageRegistry != nil {
+		items.Add(ImageRegistryUserKey, *cc.ImageRegistry)
+	}
 	items.Add(TelemetryUserKey, cc.Telemetry)
 	items.Add(ImageOperatorUserKey, cc.ImageOperator)
 	items.Add(ImageManagerUserKey, cc.ImageManager)
 	items.Add(ImageDownloaderUserKey, cc.ImageDownloader)
 	items.Add(ImageRequestMonitorUserKey, cc.ImageRequestMonitor)
+	items.Add(ImageEgressProxyUserKey, cc.ImageEgressProxy)
+	items.Add(ImageLoadTesterUserKey, cc.ImageLoadTester)
 	items.Add(ImageClusterAutoscalerUserKey, cc.ImageClusterAutoscaler)
 	items.Add(ImageMetricsServerUserKey, cc.ImageMetricsServer)
 	items.Add(ImageInferentiaUserKey, cc.ImageInferentia)
@@ -24,6 +24,9 @@ const (
 	InstanceVolumeSizeKey                  = "instance_volume_size"
 	InstanceVolumeTypeKey                  = "instance_volume_type"
 	InstanceVolumeIOPSKey                  = "instance_volume_iops"
+	CPUManagerPolicyKey                    = "cpu_manager_policy"
+	TopologyManagerPolicyKey               = "topology_manager_policy"
+	InstanceHugePagesKey                   = "instance_hugepages"
 	SpotKey                                = "spot"
 	SpotConfigKey                          = "spot_config"
 	InstanceDistributionKey                = "instance_distribution"
@@ -38,15 +41,48 @@ const (
 	SSLCertificateARNKey                   = "ssl_certificate_arn"
 	BucketKey                              = "bucket"
 	LogGroupKey                            = "log_group"
+	MetricsNamespaceKey                    = "metrics_namespace"
+	MetricsDimensionsKey                   = "metrics_dimensions"
 	SubnetVisibilityKey                    = "subnet_visibility"
 	NATGatewayKey                          = "nat_gateway"
 	APILoadBalancerSchemeKey               = "api_load_balancer_scheme"
+	APIIngressKey                          = "api_ingress"
+	GatewayAPIParentKey                    = "gateway_api_parent"
 	OperatorLoadBalancerSchemeKey          = "operator_load_balancer_scheme"
+	OperatorServerKey                      = "operator_server"
+	ReadHeaderTimeoutKey                   = "read_header_timeout"
+	ReadTimeoutKey                         = "read_timeout"
+	WriteTimeoutKey                        = "write_timeout"
+	IdleTimeoutKey                         = "idle_timeout"
+	MaxHeaderSizeKey                       = "max_header_size"
+	MaxRequestBodySizeKey                  = "max_request_body_size"
+	ReplicasKey                            = "replicas"
+	OperatorPrivateLinkKey                 = "operator_private_link"
+	AllowedPrincipalsKey                   = "allowed_principals"
+	AcceptanceRequiredKey                  = "acceptance_required"
+	ProxyKey                               = "proxy"
+	HTTPSProxyKey                          = "https_proxy"
+	NoProxyKey                             = "no_proxy"
+	CABundleKey                            = "ca_bundle"
+	APISecurityPolicyKey                   = "api_security_policy"
+	RunAsNonRootKey                        = "run_as_non_root"
+	ReadOnlyRootFilesystemKey              = "read_only_root_filesystem"
+	SeccompProfileKey                      = "seccomp_profile"
+	AppArmorProfileKey                     = "apparmor_profile"
+	PodSecurityStandardKey                 = "pod_security_standard"
+	PodSecurityExemptAPIsKey               = "pod_security_exempt_apis"
+	ImageSignaturePolicyKey                = "image_signature_policy"
+	PublicKeysKey                          = "public_keys"
+	AccessReportBucketKey                  = "access_report_bucket"
+	AirGappedKey                           = "air_gapped"
+	ImageRegistryKey                       = "image_registry"
 	TelemetryKey                           = "telemetry"
 	ImageOperatorKey                       = "image_operator"
 	ImageManagerKey                        = "image_manager"
 	ImageDownloaderKey                     = "image_downloader"
 	ImageRequestMonitorKey                 = "image_request_monitor"
+	ImageEgressProxyKey                    = "image_egress_proxy"
+	ImageLoadTesterKey                     = "image_load_tester"
 	ImageClusterAutoscalerKey              = "image_cluster_autoscaler"
 	ImageMetricsServerKey                  = "image_metrics_server"
 	ImageInferentiaKey                     = "image_inferentia"
@@ -60,45 +96,77 @@ const (
 	ImageIstioGalleyKey                    = "image_istio_galley"
 
 	// User facing string
-	APIVersionUserKey                          = "cluster version"
-	ClusterNameUserKey                         = "cluster name"
-	RegionUserKey                              = "aws region"
-	AvailabilityZonesUserKey                   = "availability zones"
-	SSLCertificateARNUserKey                   = "ssl certificate arn"
-	BucketUserKey                              = "s3 bucket"
-	SpotUserKey                                = "use spot instances"
-	InstanceTypeUserKey                        = "instance type"
-	MinInstancesUserKey                        = "min instances"
-	MaxInstancesUserKey                        = "max instances"
-	TagsUserKey                                = "tags"
-	InstanceVolumeSizeUserKey                  = "instance volume size (Gi)"
-	InstanceVolumeTypeUserKey                  = "instance volume type"
-	InstanceVolumeIOPSUserKey                  = "instance volume iops"
-	InstanceDistributionUserKey                = "spot instance distribution"
-	OnDemandBaseCapacityUserKey                = "spot on demand base capacity"
-	OnDemandPercentageAboveBaseCapacityUserKey = "spot on demand percentage above base capacity"
-	MaxPriceUserKey                            = "spot max price ($ per hour)"
-	InstancePoolsUserKey                       = "spot instance pools"
-	OnDemandBackupUserKey                      = "on demand backup"
-	LogGroupUserKey                            = "cloudwatch log group"
-	SubnetVisibilityUserKey                    = "subnet visibility"
-	NATGatewayUserKey                          = "nat gateway"
-	APILoadBalancerSchemeUserKey               = "api load balancer scheme"
-	OperatorLoadBalancerSchemeUserKey          = "operator load balancer scheme"
-	TelemetryUserKey                           = "telemetry"
-	ImageOperatorUserKey                       = "operator image"
-	ImageManagerUserKey                        = "manager image"
-	ImageDownloaderUserKey                     = "downloader image"
-	ImageRequestMonitorUserKey                 = "request monitor image"
-	ImageClusterAutoscalerUserKey              = "cluster autoscaler image"
-	ImageMetricsServerUserKey                  = "metrics server image"
-	ImageInferentiaUserKey                     = "inferentia image"
-	ImageNeuronRTDUserKey                      = "neuron rtd image"
-	ImageNvidiaUserKey                         = "nvidia image"
-	ImageFluentdUserKey                        = "fluentd image"
-	ImageStatsdUserKey                         = "statsd image"
-	ImageIstioProxyUserKey                     = "istio proxy image"
-	ImageIstioPilotUserKey                     = "istio pilot image"
-	ImageIstioCitadelUserKey                   = "istio citadel image"
-	ImageIstioGalleyUserKey                    = "istio galley image"
+	APIVersionUserKey                            = "cluster version"
+	ClusterNameUserKey                           = "cluster name"
+	RegionUserKey                                = "aws region"
+	AvailabilityZonesUserKey                     = "availability zones"
+	SSLCertificateARNUserKey                     = "ssl certificate arn"
+	BucketUserKey                                = "s3 bucket"
+	SpotUserKey                                  = "use spot instances"
+	InstanceTypeUserKey                          = "instance type"
+	MinInstancesUserKey                          = "min instances"
+	MaxInstancesUserKey                          = "max instances"
+	TagsUserKey                                  = "tags"
+	InstanceVolumeSizeUserKey                    = "instance volume size (Gi)"
+	InstanceVolumeTypeUserKey                    = "instance volume type"
+	InstanceVolumeIOPSUserKey                    = "instance volume iops"
+	CPUManagerPolicyUserKey                      = "cpu manager policy"
+	TopologyManagerPolicyUserKey                 = "topology manager policy"
+	InstanceHugePagesUserKey                     = "instance huge pages (2Mi)"
+	InstanceDistributionUserKey                  = "spot instance distribution"
+	OnDemandBaseCapacityUserKey                  = "spot on demand base capacity"
+	OnDemandPercentageAboveBaseCapacityUserKey   = "spot on demand percentage above base capacity"
+	MaxPriceUserKey                              = "spot max price ($ per hour)"
+	InstancePoolsUserKey                         = "spot instance pools"
+	OnDemandBackupUserKey                        = "on demand backup"
+	LogGroupUserKey                              = "cloudwatch log group"
+	MetricsNamespaceUserKey                      = "cloudwatch metrics namespace"
+	MetricsDimensionsUserKey                     = "cloudwatch metrics dimensions"
+	SubnetVisibilityUserKey                      = "subnet visibility"
+	NATGatewayUserKey                            = "nat gateway"
+	APILoadBalancerSchemeUserKey                 = "api load balancer scheme"
+	APIIngressUserKey                            = "api ingress"
+	GatewayAPIParentUserKey                      = "gateway api parent"
+	OperatorLoadBalancerSchemeUserKey            = "operator load balancer scheme"
+	OperatorReadHeaderTimeoutUserKey             = "operator read header timeout (seconds)"
+	OperatorReadTimeoutUserKey                   = "operator read timeout (seconds)"
+	OperatorWriteTimeoutUserKey                  = "operator write timeout (seconds)"
+	OperatorIdleTimeoutUserKey                   = "operator idle timeout (seconds)"
+	OperatorMaxHeaderSizeUserKey                 = "operator max header size (Ki)"
+	OperatorMaxRequestBodySizeUserKey            = "operator max request body size (Mi)"
+	OperatorReplicasUserKey                      = "operator replicas"
+	OperatorSSLCertificateARNUserKey             = "operator ssl certificate arn"
+	OperatorPrivateLinkAllowedPrincipalsUserKey  = "operator private link allowed principals"
+	OperatorPrivateLinkAcceptanceRequiredUserKey = "operator private link acceptance required"
+	HTTPSProxyUserKey                            = "operator https proxy"
+	NoProxyUserKey                               = "operator no proxy"
+	CABundleUserKey                              = "operator custom ca bundle"
+	RunAsNonRootUserKey                          = "run apis as non-root"
+	ReadOnlyRootFilesystemUserKey                = "read-only api root filesystem"
+	SeccompProfileUserKey                        = "api seccomp profile"
+	AppArmorProfileUserKey                       = "api apparmor profile"
+	PodSecurityStandardUserKey                   = "api pod security standard"
+	PodSecurityExemptAPIsUserKey                 = "apis exempt from the pod security standard"
+	ImageSignaturePublicKeysUserKey              = "image signature public keys"
+	AccessReportBucketUserKey                    = "access report bucket"
+	AirGappedUserKey                             = "air-gapped"
+	ImageRegistryUserKey                         = "image registry"
+	TelemetryUserKey                             = "telemetry"
+	ImageOperatorUserKey                         = "operator image"
+	ImageManagerUserKey                          = "manager image"
+	ImageDownloaderUserKey                       = "downloader image"
+	ImageRequestMonitorUserKey                   = "request monitor image"
+	ImageEgressProxyUserKey                      = "egress proxy image"
+	ImageLoadTesterUserKey                       = "load tester image"
+	ImageClusterAutoscalerUserKey                = "cluster autoscaler image"
+	ImageMetricsServerUserKey                    = "metrics server image"
+	ImageInferentiaUserKey                       = "inferentia image"
+	ImageNeuronRTDUserKey                        = "neuron rtd image"
+	ImageNvidiaUserKey                           = "nvidia image"
+	ImageFluentdUserKey                          = "fluentd image"
+	ImageStatsdUserKey                           = "statsd image"
+	ImageIstioProxyUserKey                       = "istio proxy image"
+	ImageIstioPilotUserKey                       = "istio pilot image"
+	ImageIstioCitadelUserKey                     = "istio citadel image"
+	ImageIstioGalleyUserKey                      = "istio galley image"
 )
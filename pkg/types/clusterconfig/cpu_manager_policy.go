@@ -0,0 +1,75 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterconfig
+
+type CPUManagerPolicy int
+
+const (
+	UnknownCPUManagerPolicy CPUManagerPolicy = iota
+	NoneCPUManagerPolicy
+	StaticCPUManagerPolicy
+)
+
+var _cpuManagerPolicies = []string{
+	"unknown",
+	"none",
+	"static",
+}
+
+func CPUManagerPolicyFromString(s string) CPUManagerPolicy {
+	for i := 0; i < len(_cpuManagerPolicies); i++ {
+		if s == _cpuManagerPolicies[i] {
+			return CPUManagerPolicy(i)
+		}
+	}
+	return UnknownCPUManagerPolicy
+}
+
+func CPUManagerPolicyStrings() []string {
+	return _cpuManagerPolicies[1:]
+}
+
+func (t CPUManagerPolicy) String() string {
+	return _cpuManagerPolicies[t]
+}
+
+// MarshalText satisfies TextMarshaler
+func (t CPUManagerPolicy) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText satisfies TextUnmarshaler
+func (t *CPUManagerPolicy) UnmarshalText(text []byte) error {
+	enum := string(text)
+	for i := 0; i < len(_cpuManagerPolicies); i++ {
+		if enum == _cpuManagerPolicies[i] {
+			*t = CPUManagerPolicy(i)
+			return nil
+		}
+	}
+
+	*t = UnknownCPUManagerPolicy
+	return nil
+}
+
+// UnmarshalBinary satisfies BinaryUnmarshaler
+// Needed for msgpack
+func (t *CPUManagerPolicy) UnmarshalBinary(data []byte) error {
+	return t.UnmarshalText(data)
+}
+
+// MarshalBinary satisfies BinaryMarshaler
+func (t CPUManagerPolicy) MarshalBinary() ([]byte, error) {
+	return []byte(t.String()), nil
+}
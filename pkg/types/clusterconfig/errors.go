@@ -54,6 +54,17 @@ const (
 	ErrIOPSTooLarge                           = "clusterconfig.iops_too_large"
 	ErrCantOverrideDefaultTag                 = "clusterconfig.cant_override_default_tag"
 	ErrSSLCertificateARNNotFound              = "clusterconfig.ssl_certificate_arn_not_found"
+	ErrTooManyMetricsDimensions               = "clusterconfig.too_many_metrics_dimensions"
+	ErrReservedMetricsDimension               = "clusterconfig.reserved_metrics_dimension"
+	ErrInvalidMetricsDimension                = "clusterconfig.invalid_metrics_dimension"
+	ErrInvalidPrincipal                       = "clusterconfig.invalid_principal"
+	ErrInvalidImageRegistry                   = "clusterconfig.invalid_image_registry"
+	ErrAirGappedPublicImages                  = "clusterconfig.air_gapped_public_images"
+	ErrGatewayAPIParentRequired               = "clusterconfig.gateway_api_parent_required"
+	ErrTopologyPolicyRequiresStaticCPUPolicy  = "clusterconfig.topology_manager_policy_requires_static_cpu_manager_policy"
+	ErrStaticCPUPolicyInstanceTooSmall        = "clusterconfig.static_cpu_manager_policy_instance_too_small"
+	ErrInstanceHugePagesNotSupportedOnInf     = "clusterconfig.instance_hugepages_not_supported_on_inf"
+	ErrInstanceHugePagesTooLarge              = "clusterconfig.instance_hugepages_too_large"
 )
 
 func ErrorInvalidRegion(region string) error {
@@ -249,3 +260,80 @@ func ErrorSSLCertificateARNNotFound(sslCertificateARN string, region string) err
 		Message: fmt.Sprintf("unable to find the specified ssl certificate in region %s: %s", region, sslCertificateARN),
 	})
 }
+
+func ErrorTooManyMetricsDimensions(numDimensions int, maxDimensions int) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrTooManyMetricsDimensions,
+		Message: fmt.Sprintf("at most %d custom metrics dimensions may be specified (got %d)", maxDimensions, numDimensions),
+	})
+}
+
+func ErrorReservedMetricsDimension(dimension string, reservedDimensions []string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrReservedMetricsDimension,
+		Message: fmt.Sprintf("%s is a reserved metrics dimension name (reserved names: %s)", s.UserStr(dimension), s.UserStrsAnd(reservedDimensions)),
+	})
+}
+
+func ErrorInvalidMetricsDimension(val string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidMetricsDimension,
+		Message: fmt.Sprintf("metrics dimension names and values must be between 1 and 255 characters (got %s)", s.UserStr(val)),
+	})
+}
+
+func ErrorInvalidPrincipal(principal string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidPrincipal,
+		Message: fmt.Sprintf("%s is not a valid principal; principals must be IAM ARNs (e.g. arn:aws:iam::123456789012:root) or \"*\"", s.UserStr(principal)),
+	})
+}
+
+func ErrorInvalidImageRegistry(imageRegistry string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidImageRegistry,
+		Message: fmt.Sprintf("%s is not a valid image registry; it must be a registry host followed by an optional repository prefix, without a scheme (e.g. 123456789012.dkr.ecr.us-west-2.amazonaws.com/cortexlabs)", s.UserStr(imageRegistry)),
+	})
+}
+
+func ErrorAirGappedPublicImages(imageKeys []string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrAirGappedPublicImages,
+		Message: fmt.Sprintf("air-gapped clusters can't pull images from Docker Hub, but the following images would be: %s; set %s to the registry which cortex's images have been mirrored to (see `cortex cluster images`), or set these fields to images in a private registry", s.StrsAnd(imageKeys), ImageRegistryKey),
+	})
+}
+
+func ErrorGatewayAPIParentRequired() error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrGatewayAPIParentRequired,
+		Message: fmt.Sprintf("%s must be specified when `%s: %s` is specified, so that APIs' routes can be attached to your gateway", GatewayAPIParentKey, APIIngressKey, GatewayAPIAPIIngress),
+	})
+}
+
+func ErrorTopologyPolicyRequiresStaticCPUPolicy(topologyManagerPolicy TopologyManagerPolicy) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrTopologyPolicyRequiresStaticCPUPolicy,
+		Message: fmt.Sprintf("`%s: %s` requires `%s: %s`, since NUMA alignment only applies to exclusively allocated cores", TopologyManagerPolicyKey, topologyManagerPolicy, CPUManagerPolicyKey, StaticCPUManagerPolicy),
+	})
+}
+
+func ErrorStaticCPUPolicyInstanceTooSmall(instanceType string, numCPUs int64) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrStaticCPUPolicyInstanceTooSmall,
+		Message: fmt.Sprintf("`%s: %s` requires instances with at least 2 vCPUs, since one core is reserved for system processes (%s has %d)", CPUManagerPolicyKey, StaticCPUManagerPolicy, instanceType, numCPUs),
+	})
+}
+
+func ErrorInstanceHugePagesNotSupportedOnInf(instanceType string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInstanceHugePagesNotSupportedOnInf,
+		Message: fmt.Sprintf("%s cannot be specified for Inferentia instances (%s), since their huge pages are reserved for the neuron runtime", InstanceHugePagesKey, instanceType),
+	})
+}
+
+func ErrorInstanceHugePagesTooLarge(hugePages int64, instanceType string, maxHugePages int64) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInstanceHugePagesTooLarge,
+		Message: fmt.Sprintf("%d huge pages cannot be preallocated on %s instances, since huge pages can't be used as regular memory; please specify fewer than %d", hugePages, instanceType, maxHugePages),
+	})
+}
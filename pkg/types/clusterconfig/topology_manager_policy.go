@@ -0,0 +1,79 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterconfig
+
+type TopologyManagerPolicy int
+
+const (
+	UnknownTopologyManagerPolicy TopologyManagerPolicy = iota
+	NoneTopologyManagerPolicy
+	BestEffortTopologyManagerPolicy
+	RestrictedTopologyManagerPolicy
+	SingleNUMANodeTopologyManagerPolicy
+)
+
+var _topologyManagerPolicies = []string{
+	"unknown",
+	"none",
+	"best_effort",
+	"restricted",
+	"single_numa_node",
+}
+
+func TopologyManagerPolicyFromString(s string) TopologyManagerPolicy {
+	for i := 0; i < len(_topologyManagerPolicies); i++ {
+		if s == _topologyManagerPolicies[i] {
+			return TopologyManagerPolicy(i)
+		}
+	}
+	return UnknownTopologyManagerPolicy
+}
+
+func TopologyManagerPolicyStrings() []string {
+	return _topologyManagerPolicies[1:]
+}
+
+func (t TopologyManagerPolicy) String() string {
+	return _topologyManagerPolicies[t]
+}
+
+// MarshalText satisfies TextMarshaler
+func (t TopologyManagerPolicy) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText satisfies TextUnmarshaler
+func (t *TopologyManagerPolicy) UnmarshalText(text []byte) error {
+	enum := string(text)
+	for i := 0; i < len(_topologyManagerPolicies); i++ {
+		if enum == _topologyManagerPolicies[i] {
+			*t = TopologyManagerPolicy(i)
+			return nil
+		}
+	}
+
+	*t = UnknownTopologyManagerPolicy
+	return nil
+}
+
+// UnmarshalBinary satisfies BinaryUnmarshaler
+// Needed for msgpack
+func (t *TopologyManagerPolicy) UnmarshalBinary(data []byte) error {
+	return t.UnmarshalText(data)
+}
+
+// MarshalBinary satisfies BinaryMarshaler
+func (t TopologyManagerPolicy) MarshalBinary() ([]byte, error) {
+	return []byte(t.String()), nil
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/hash"
 	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
 	s "github.com/cortexlabs/cortex/pkg/lib/strings"
+	"github.com/cortexlabs/cortex/pkg/types/status"
 	"github.com/cortexlabs/cortex/pkg/types/userconfig"
 )
 
@@ -33,12 +34,15 @@ type API struct {
 	ID               string             `json:"id"`
 	Key              string             `json:"key"`
 	DeploymentID     string             `json:"deployment_id"`
+	Version          int                `json:"version"` // incremented each time the API is updated (aws only)
 	LastUpdated      int64              `json:"last_updated"`
 	MetadataRoot     string             `json:"metadata_root"`
 	ProjectID        string             `json:"project_id"`
 	ProjectKey       string             `json:"project_key"`
 	LocalModelCaches []*LocalModelCache `json:"local_model_cache"` // local only
 	LocalProjectDir  string             `json:"local_project_dir"`
+
+	ImageVerifications []status.ImageVerification `json:"image_verifications"` // populated if the cluster has an image_signature_policy
 }
 
 type LocalModelCache struct {
@@ -107,6 +111,27 @@ func Key(apiName string, apiID string) string {
 	)
 }
 
+// EnvironmentKey is where the API's replicas record the environment they resolved at startup
+func EnvironmentKey(apiName string, apiID string) string {
+	return filepath.Join(
+		"apis",
+		apiName,
+		apiID,
+		"environment.json",
+	)
+}
+
+// CrashReportsPrefix is where a replica uploads the tracebacks written by its predictor's processes when they crashed
+func CrashReportsPrefix(apiName string, apiID string, podName string) string {
+	return filepath.Join(
+		"apis",
+		apiName,
+		apiID,
+		"crashes",
+		podName,
+	) + "/"
+}
+
 func MetadataRoot(apiName string) string {
 	return filepath.Join(
 		"apis",
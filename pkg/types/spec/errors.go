@@ -68,6 +68,18 @@ const (
 	ErrIncorrectAPISplitterWeight           = "spec.incorrect_api_splitter_weight"
 	ErrAPISplitterNotSupported              = "spec.apisplitter_not_supported"
 	ErrAPISplitterAPIsNotUnique             = "spec.apisplitter_apis_not_unique"
+	ErrInvalidSunsetDate                    = "spec.invalid_sunset_date"
+	ErrInvalidRuntime                       = "spec.invalid_runtime"
+	ErrRuntimeIncompatibleWithPredictorType = "spec.runtime_incompatible_with_predictor_type"
+	ErrRuntimeIncompatibleWithCompute       = "spec.runtime_incompatible_with_compute"
+	ErrInvalidGoldenDataset                 = "spec.invalid_golden_dataset"
+	ErrLimitRatioRequiresBurstableQoS       = "spec.limit_ratio_requires_burstable_qos"
+	ErrGuaranteedQoSRequiresCPUAndMem       = "spec.guaranteed_qos_requires_cpu_and_mem"
+	ErrExclusiveCPUsRequireGuaranteedQoS    = "spec.exclusive_cpus_require_guaranteed_qos"
+	ErrExclusiveCPUsRequireWholeCPUs        = "spec.exclusive_cpus_require_whole_cpus"
+	ErrShmExceedsMem                        = "spec.shm_exceeds_mem"
+	ErrHugePagesIncompatibleWithBestEffort  = "spec.hugepages_incompatible_with_best_effort"
+	ErrInvalidHugePages                     = "spec.invalid_hugepages"
 )
 
 func ErrorMalformedConfig() error {
@@ -379,3 +391,97 @@ func ErrorAPISplitterAPIsNotUnique(names []string) error {
 		Message: fmt.Sprintf("api splitter %s not unique: %s", s.PluralS("API", len(names)), s.StrsSentence(names, "")),
 	})
 }
+
+func ErrorInvalidSunsetDate(sunsetDate string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidSunsetDate,
+		Message: fmt.Sprintf("%s is not a valid date; dates must be in the format YYYY-MM-DD (e.g. 2020-12-31)", s.UserStr(sunsetDate)),
+	})
+}
+
+func ErrorInvalidRuntime(runtime string, validRuntimes []string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidRuntime,
+		Message: fmt.Sprintf("%s is not a supported runtime; valid runtimes are %s (run `cortex images` to see the full catalog)", s.UserStr(runtime), s.UserStrsOr(validRuntimes)),
+	})
+}
+
+func ErrorRuntimeIncompatibleWithPredictorType(runtime string, runtimePredictorType userconfig.PredictorType, predictorType userconfig.PredictorType, validRuntimes []string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrRuntimeIncompatibleWithPredictorType,
+		Message: fmt.Sprintf("runtime %s is for the %s predictor type, but the predictor type is %s; valid runtimes for this predictor type are %s", runtime, runtimePredictorType, predictorType, s.UserStrsOr(validRuntimes)),
+	})
+}
+
+func ErrorRuntimeIncompatibleWithCompute(runtime string, runtimeAccelerator userconfig.Accelerator, accelerator userconfig.Accelerator) error {
+	var computeStr string
+	switch accelerator {
+	case userconfig.GPUAccelerator:
+		computeStr = fmt.Sprintf("the api requests a gpu (%s)", userconfig.GPUKey)
+	case userconfig.InfAccelerator:
+		computeStr = fmt.Sprintf("the api requests an inferentia chip (%s)", userconfig.InfKey)
+	default:
+		computeStr = fmt.Sprintf("the api does not request a gpu (%s) or an inferentia chip (%s)", userconfig.GPUKey, userconfig.InfKey)
+	}
+
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrRuntimeIncompatibleWithCompute,
+		Message: fmt.Sprintf("runtime %s is built for %s instances, but %s; please choose a runtime which matches the api's compute (run `cortex images` to see the full catalog)", runtime, runtimeAccelerator, computeStr),
+	})
+}
+
+func ErrorInvalidGoldenDataset(path string, reason string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidGoldenDataset,
+		Message: fmt.Sprintf("%s: invalid golden dataset: %s (it must be a json list of objects with \"payload\" and \"expected\" fields, with at most %d examples)", path, reason, MaxGoldenDatasetExamples),
+	})
+}
+
+func ErrorLimitRatioRequiresBurstableQoS(qos userconfig.QoSClass) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrLimitRatioRequiresBurstableQoS,
+		Message: fmt.Sprintf("%s can only be specified when %s is %s (got %s)", userconfig.LimitRatioKey, userconfig.QoSKey, userconfig.BurstableQoSClass, qos),
+	})
+}
+
+func ErrorGuaranteedQoSRequiresCPUAndMem() error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrGuaranteedQoSRequiresCPUAndMem,
+		Message: fmt.Sprintf("%s and %s must be specified when %s is %s, since the API's containers are limited to the requested resources", userconfig.CPUKey, userconfig.MemKey, userconfig.QoSKey, userconfig.GuaranteedQoSClass),
+	})
+}
+
+func ErrorExclusiveCPUsRequireGuaranteedQoS(qos userconfig.QoSClass) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrExclusiveCPUsRequireGuaranteedQoS,
+		Message: fmt.Sprintf("%s can only be enabled when %s is %s (got %s)", userconfig.ExclusiveCPUsKey, userconfig.QoSKey, userconfig.GuaranteedQoSClass, qos),
+	})
+}
+
+func ErrorExclusiveCPUsRequireWholeCPUs(cpu string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrExclusiveCPUsRequireWholeCPUs,
+		Message: fmt.Sprintf("%s must be a whole number of CPUs when %s is enabled (got %s)", userconfig.CPUKey, userconfig.ExclusiveCPUsKey, cpu),
+	})
+}
+
+func ErrorShmExceedsMem(shm string, mem string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrShmExceedsMem,
+		Message: fmt.Sprintf("%s (%s) cannot be larger than %s (%s), since shared memory counts towards the API's memory usage", userconfig.ShmKey, shm, userconfig.MemKey, mem),
+	})
+}
+
+func ErrorHugePagesIncompatibleWithBestEffortQoS() error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrHugePagesIncompatibleWithBestEffort,
+		Message: fmt.Sprintf("%s cannot be requested when %s is %s, since kubernetes requires huge pages to be requested alongside cpu or memory", userconfig.HugePagesKey, userconfig.QoSKey, userconfig.BestEffortQoSClass),
+	})
+}
+
+func ErrorInvalidHugePages(hugePages string) error {
+	return errors.WithStack(&errors.Error{
+		Kind:    ErrInvalidHugePages,
+		Message: fmt.Sprintf("%s must be a multiple of the huge page size (2Mi), e.g. 512Mi or 1Gi (got %s)", userconfig.HugePagesKey, hugePages),
+	})
+}
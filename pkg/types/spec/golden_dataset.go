@@ -0,0 +1,55 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const MaxGoldenDatasetExamples = 1000
+
+// GoldenExample is a single request payload in an API's golden dataset, along with the response it is expected to produce
+type GoldenExample struct {
+	Payload  json.RawMessage `json:"payload"`
+	Expected json.RawMessage `json:"expected"`
+}
+
+func ParseGoldenDataset(path string, datasetBytes []byte) ([]GoldenExample, error) {
+	var examples []GoldenExample
+	if err := json.Unmarshal(datasetBytes, &examples); err != nil {
+		return nil, ErrorInvalidGoldenDataset(path, err.Error())
+	}
+
+	if len(examples) == 0 {
+		return nil, ErrorInvalidGoldenDataset(path, "it does not contain any examples")
+	}
+	if len(examples) > MaxGoldenDatasetExamples {
+		return nil, ErrorInvalidGoldenDataset(path, fmt.Sprintf("it contains %d examples", len(examples)))
+	}
+
+	for i, example := range examples {
+		if len(example.Payload) == 0 {
+			return nil, ErrorInvalidGoldenDataset(path, fmt.Sprintf("example %d is missing the \"payload\" field", i))
+		}
+		if len(example.Expected) == 0 {
+			return nil, ErrorInvalidGoldenDataset(path, fmt.Sprintf("example %d is missing the \"expected\" field", i))
+		}
+	}
+
+	return examples, nil
+}
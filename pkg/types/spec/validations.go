@@ -60,6 +60,9 @@ func apiValidation(provider types.ProviderType, resource userconfig.Resource) *c
 			autoscalingValidation(provider),
 			updateStrategyValidation(provider),
 		)
+		if provider == types.AWSProviderType {
+			structFieldValidations = append(structFieldValidations, deprecationValidation(), securityValidation(), projectValidation(), requestLogValidation(), goldenDatasetValidation())
+		}
 	}
 	if resource.Kind == userconfig.APISplitterKind {
 		structFieldValidations = append(structFieldValidations,
@@ -72,14 +75,17 @@ func apiValidation(provider types.ProviderType, resource userconfig.Resource) *c
 	}
 }
 
+// APINameValidation is the validation for API names (also used for names which are derived from them, e.g. preview APIs)
+var APINameValidation = &cr.StringValidation{
+	Required:  true,
+	DNS1035:   true,
+	MaxLength: 42, // k8s adds 21 characters to the pod name, and 63 is the max before it starts to truncate
+}
+
 var resourceStructValidations = []*cr.StructFieldValidation{
 	{
-		StructField: "Name",
-		StringValidation: &cr.StringValidation{
-			Required:  true,
-			DNS1035:   true,
-			MaxLength: 42, // k8s adds 21 characters to the pod name, and 63 is the max before it starts to truncate
-		},
+		StructField:      "Name",
+		StringValidation: APINameValidation,
 	},
 	{
 		StructField: "Kind",
@@ -163,6 +169,19 @@ func predictorValidation() *cr.StructFieldValidation {
 						},
 					},
 				},
+				{
+					StructField: "Runtime",
+					StringValidation: &cr.StringValidation{
+						Required:   false,
+						AllowEmpty: true,
+						Validator: func(runtime string) (string, error) {
+							if runtime != "" && userconfig.GetRuntime(runtime) == nil {
+								return "", ErrorInvalidRuntime(runtime, userconfig.AllRuntimeNames())
+							}
+							return runtime, nil
+						},
+					},
+				},
 				{
 					StructField: "Image",
 					StringValidation: &cr.StringValidation{
@@ -269,13 +288,54 @@ func networkingValidation(kind userconfig.Kind) *cr.StructFieldValidation {
 		},
 	}
 	if kind == userconfig.SyncAPIKind {
-		structFieldValidation = append(structFieldValidation, &cr.StructFieldValidation{
-			StructField: "LocalPort",
-			IntPtrValidation: &cr.IntPtrValidation{
-				GreaterThan:       pointer.Int(0),
-				LessThanOrEqualTo: pointer.Int(math.MaxUint16),
+		structFieldValidation = append(structFieldValidation,
+			&cr.StructFieldValidation{
+				StructField: "LocalPort",
+				IntPtrValidation: &cr.IntPtrValidation{
+					GreaterThan:       pointer.Int(0),
+					LessThanOrEqualTo: pointer.Int(math.MaxUint16),
+				},
 			},
-		})
+			&cr.StructFieldValidation{
+				StructField: "MaxPayloadSize",
+				StringPtrValidation: &cr.StringPtrValidation{
+					Default:           nil,
+					AllowExplicitNull: true,
+				},
+				Parser: k8s.QuantityParser(&k8s.QuantityValidation{
+					GreaterThanOrEqualTo: k8s.QuantityPtr(kresource.MustParse("1Ki")),
+				}),
+			},
+			&cr.StructFieldValidation{
+				StructField: "MaxResponseSize",
+				StringPtrValidation: &cr.StringPtrValidation{
+					Default:           nil,
+					AllowExplicitNull: true,
+				},
+				Parser: k8s.QuantityParser(&k8s.QuantityValidation{
+					GreaterThanOrEqualTo: k8s.QuantityPtr(kresource.MustParse("1Ki")),
+				}),
+			},
+			&cr.StructFieldValidation{
+				StructField: "EgressAllowlist",
+				StringListValidation: &cr.StringListValidation{
+					Default:           nil,
+					AllowExplicitNull: true,
+					AllowEmpty:        true,
+					DisallowDups:      true,
+					Validator: func(domains []string) ([]string, error) {
+						for i, domain := range domains {
+							validated, err := urls.ValidateDomain(domain)
+							if err != nil {
+								return nil, errors.Wrap(err, s.Index(i))
+							}
+							domains[i] = validated
+						}
+						return domains, nil
+					},
+				},
+			},
+		)
 	}
 	return &cr.StructFieldValidation{
 		StructField: "Networking",
@@ -329,6 +389,46 @@ func computeValidation(provider types.ProviderType) *cr.StructFieldValidation {
 						GreaterThanOrEqualTo: pointer.Int64(0),
 					},
 				},
+				{
+					StructField: "QoS",
+					StringValidation: &cr.StringValidation{
+						AllowedValues: userconfig.QoSClassStrings(),
+						Default:       userconfig.BurstableQoSClass.String(),
+					},
+					Parser: func(str string) (interface{}, error) {
+						return userconfig.QoSClassFromString(str), nil
+					},
+				},
+				{
+					StructField: "LimitRatio",
+					Float64PtrValidation: &cr.Float64PtrValidation{
+						GreaterThanOrEqualTo: pointer.Float64(1),
+					},
+				},
+				{
+					StructField:    "ExclusiveCPUs",
+					BoolValidation: &cr.BoolValidation{},
+				},
+				{
+					StructField: "Shm",
+					StringPtrValidation: &cr.StringPtrValidation{
+						Default:           nil,
+						AllowExplicitNull: true,
+					},
+					Parser: k8s.QuantityParser(&k8s.QuantityValidation{
+						GreaterThan: k8s.QuantityPtr(kresource.MustParse("0")),
+					}),
+				},
+				{
+					StructField: "HugePages",
+					StringPtrValidation: &cr.StringPtrValidation{
+						Default:           nil,
+						AllowExplicitNull: true,
+					},
+					Parser: k8s.QuantityParser(&k8s.QuantityValidation{
+						GreaterThanOrEqualTo: k8s.QuantityPtr(kresource.MustParse("2Mi")),
+					}),
+				},
 			},
 		},
 	}
@@ -471,6 +571,152 @@ func updateStrategyValidation(provider types.ProviderType) *cr.StructFieldValida
 	}
 }
 
+func projectValidation() *cr.StructFieldValidation {
+	return &cr.StructFieldValidation{
+		StructField: "Project",
+		StringPtrValidation: &cr.StringPtrValidation{
+			AllowExplicitNull:          true,
+			AlphaNumericDashUnderscore: true,
+			MaxLength:                  63,
+		},
+	}
+}
+
+func requestLogValidation() *cr.StructFieldValidation {
+	return &cr.StructFieldValidation{
+		StructField: "RequestLog",
+		StructValidation: &cr.StructValidation{
+			DefaultNil:        true,
+			AllowExplicitNull: true,
+			StructFieldValidations: []*cr.StructFieldValidation{
+				{
+					StructField: "SampleRate",
+					Float64Validation: &cr.Float64Validation{
+						Required:          true,
+						GreaterThan:       pointer.Float64(0),
+						LessThanOrEqualTo: pointer.Float64(1),
+					},
+				},
+			},
+		},
+	}
+}
+
+func goldenDatasetValidation() *cr.StructFieldValidation {
+	return &cr.StructFieldValidation{
+		StructField: "GoldenDataset",
+		StructValidation: &cr.StructValidation{
+			DefaultNil:        true,
+			AllowExplicitNull: true,
+			StructFieldValidations: []*cr.StructFieldValidation{
+				{
+					StructField: "Path",
+					StringValidation: &cr.StringValidation{
+						Required: true,
+					},
+				},
+				{
+					StructField: "Tolerance",
+					Float64Validation: &cr.Float64Validation{
+						Default:              0,
+						GreaterThanOrEqualTo: pointer.Float64(0),
+					},
+				},
+				{
+					StructField: "MaxAccuracyDrop",
+					Float64Validation: &cr.Float64Validation{
+						Default:              0,
+						GreaterThanOrEqualTo: pointer.Float64(0),
+						LessThanOrEqualTo:    pointer.Float64(1),
+					},
+				},
+				{
+					StructField: "MaxLatencyIncrease",
+					Float64PtrValidation: &cr.Float64PtrValidation{
+						GreaterThanOrEqualTo: pointer.Float64(0),
+					},
+				},
+				{
+					StructField: "Timeout",
+					StringValidation: &cr.StringValidation{
+						Default: "10m",
+					},
+					Parser: cr.DurationParser(&cr.DurationValidation{
+						GreaterThan: pointer.Duration(0),
+					}),
+				},
+			},
+		},
+	}
+}
+
+func deprecationValidation() *cr.StructFieldValidation {
+	return &cr.StructFieldValidation{
+		StructField: "Deprecation",
+		StructValidation: &cr.StructValidation{
+			DefaultNil:        true,
+			AllowExplicitNull: true,
+			StructFieldValidations: []*cr.StructFieldValidation{
+				{
+					StructField: "SunsetDate",
+					StringValidation: &cr.StringValidation{
+						Required: true,
+					},
+					Parser: func(str string) (interface{}, error) {
+						sunsetDate, err := time.Parse(userconfig.SunsetDateFormat, str)
+						if err != nil {
+							return nil, ErrorInvalidSunsetDate(str)
+						}
+						return sunsetDate, nil
+					},
+				},
+				{
+					StructField:         "Message",
+					StringPtrValidation: &cr.StringPtrValidation{},
+				},
+			},
+		},
+	}
+}
+
+func securityValidation() *cr.StructFieldValidation {
+	return &cr.StructFieldValidation{
+		StructField: "Security",
+		StructValidation: &cr.StructValidation{
+			DefaultNil:        true,
+			AllowExplicitNull: true,
+			StructFieldValidations: []*cr.StructFieldValidation{
+				{
+					StructField:       "RunAsNonRoot",
+					BoolPtrValidation: &cr.BoolPtrValidation{},
+				},
+				{
+					StructField:       "ReadOnlyRootFilesystem",
+					BoolPtrValidation: &cr.BoolPtrValidation{},
+				},
+				{
+					StructField: "SeccompProfile",
+					StringPtrValidation: &cr.StringPtrValidation{
+						Validator: k8s.ValidateSeccompProfile,
+					},
+				},
+				{
+					StructField: "AppArmorProfile",
+					StringPtrValidation: &cr.StringPtrValidation{
+						Validator: k8s.ValidateAppArmorProfile,
+					},
+				},
+				{
+					StructField: "PodSecurityStandard",
+					StringPtrValidation: &cr.StringPtrValidation{
+						AllowedValues: k8s.PodSecurityStandards,
+					},
+				},
+			},
+		},
+	}
+}
+
 func multiModelValidation() *cr.StructFieldValidation {
 	return &cr.StructFieldValidation{
 		StructField: "Models",
@@ -620,6 +866,25 @@ func ValidateAPI(
 		}
 	}
 
+	if api.GoldenDataset != nil {
+		if err := validateGoldenDataset(api.GoldenDataset, projectFiles); err != nil {
+			return errors.Wrap(err, api.Identify(), userconfig.GoldenDatasetKey)
+		}
+	}
+
+	return nil
+}
+
+func validateGoldenDataset(goldenDataset *userconfig.GoldenDataset, projectFiles ProjectFiles) error {
+	datasetBytes, err := projectFiles.GetFile(goldenDataset.Path)
+	if err != nil {
+		return errors.Wrap(err, userconfig.PathKey)
+	}
+
+	if _, err := ParseGoldenDataset(goldenDataset.Path, datasetBytes); err != nil {
+		return errors.Wrap(err, userconfig.PathKey)
+	}
+
 	return nil
 }
 
@@ -644,6 +909,12 @@ func ValidateAPISplitter(
 func validatePredictor(api *userconfig.API, projectFiles ProjectFiles, providerType types.ProviderType, awsClient *aws.Client) error {
 	predictor := api.Predictor
 
+	if predictor.Runtime != "" {
+		if err := applyRuntime(api); err != nil {
+			return errors.Wrap(err, userconfig.RuntimeKey)
+		}
+	}
+
 	switch predictor.Type {
 	case userconfig.PythonPredictorType:
 		if err := validatePythonPredictor(predictor); err != nil {
@@ -685,6 +956,36 @@ func validatePredictor(api *userconfig.API, projectFiles ProjectFiles, providerT
 	return nil
 }
 
+// applyRuntime checks that the runtime is compatible with the API, and sets the predictor's images to the runtime's images
+func applyRuntime(api *userconfig.API) error {
+	predictor := api.Predictor
+
+	runtime := userconfig.GetRuntime(predictor.Runtime)
+	if runtime == nil {
+		return ErrorInvalidRuntime(predictor.Runtime, userconfig.AllRuntimeNames())
+	}
+
+	if runtime.PredictorType != predictor.Type {
+		return ErrorRuntimeIncompatibleWithPredictorType(runtime.Name, runtime.PredictorType, predictor.Type, userconfig.RuntimeNames(predictor.Type))
+	}
+
+	if accelerator := api.Compute.Accelerator(); runtime.Accelerator != accelerator {
+		return ErrorRuntimeIncompatibleWithCompute(runtime.Name, runtime.Accelerator, accelerator)
+	}
+
+	if predictor.Image != "" {
+		return ErrorConflictingFields(userconfig.RuntimeKey, userconfig.ImageKey)
+	}
+	if predictor.TensorFlowServingImage != "" {
+		return ErrorConflictingFields(userconfig.RuntimeKey, userconfig.TensorFlowServingImageKey)
+	}
+
+	predictor.Image = runtime.Image
+	predictor.TensorFlowServingImage = runtime.TensorFlowServingImage
+
+	return nil
+}
+
 func validatePythonPredictor(predictor *userconfig.Predictor) error {
 	if predictor.SignatureKey != nil {
 		return ErrorFieldNotSupportedByPredictorType(userconfig.SignatureKeyKey, userconfig.PythonPredictorType)
@@ -1090,6 +1391,57 @@ func validateCompute(api *userconfig.API, providerType types.ProviderType) error
 		return ErrorInvalidNumberOfInfs(compute.Inf)
 	}
 
+	if providerType == types.LocalProviderType && (compute.QoS != userconfig.BurstableQoSClass || compute.LimitRatio != nil) {
+		return ErrorUnsupportedLocalComputeResource(userconfig.QoSKey)
+	}
+
+	if compute.LimitRatio != nil && compute.QoS != userconfig.BurstableQoSClass {
+		return ErrorLimitRatioRequiresBurstableQoS(compute.QoS)
+	}
+
+	if compute.QoS == userconfig.GuaranteedQoSClass && (compute.CPU == nil || compute.Mem == nil) {
+		return ErrorGuaranteedQoSRequiresCPUAndMem()
+	}
+
+	if compute.ExclusiveCPUs {
+		if providerType == types.LocalProviderType {
+			return ErrorUnsupportedLocalComputeResource(userconfig.ExclusiveCPUsKey)
+		}
+		if compute.QoS != userconfig.GuaranteedQoSClass {
+			return ErrorExclusiveCPUsRequireGuaranteedQoS(compute.QoS)
+		}
+		if compute.CPU.MilliValue()%1000 != 0 {
+			return ErrorExclusiveCPUsRequireWholeCPUs(compute.CPU.UserString)
+		}
+	}
+
+	if compute.Shm != nil {
+		if providerType == types.LocalProviderType {
+			return ErrorUnsupportedLocalComputeResource(userconfig.ShmKey)
+		}
+		// shared memory is charged to the memory of the container which writes to it
+		if compute.Mem != nil && compute.Shm.Cmp(compute.Mem.Quantity) > 0 {
+			return ErrorShmExceedsMem(compute.Shm.UserString, compute.Mem.UserString)
+		}
+	}
+
+	if compute.HugePages != nil {
+		if providerType == types.LocalProviderType {
+			return ErrorUnsupportedLocalComputeResource(userconfig.HugePagesKey)
+		}
+		// the neuron runtime daemon allocates the Inferentia instances' huge pages
+		if compute.Inf > 0 {
+			return ErrorComputeResourceConflict(userconfig.HugePagesKey, userconfig.InfKey)
+		}
+		// kubernetes only allows huge pages to be requested alongside cpu or memory
+		if compute.QoS == userconfig.BestEffortQoSClass {
+			return ErrorHugePagesIncompatibleWithBestEffortQoS()
+		}
+		if compute.HugePages.Value()%consts.HugePageSize != 0 {
+			return ErrorInvalidHugePages(compute.HugePages.UserString)
+		}
+	}
+
 	return nil
 }
 
@@ -1131,7 +1483,7 @@ func checkDuplicateModelNames(modelResources []*userconfig.ModelResource) error
 }
 
 func validateDockerImagePath(image string, providerType types.ProviderType, awsClient *aws.Client) error {
-	if consts.DefaultImagePathsSet.Has(image) {
+	if consts.DefaultImagePathsSet.Has(image) || userconfig.IsRuntimeImage(image) {
 		return nil
 	}
 	if _, err := cr.ValidateImageVersion(image, consts.CortexVersion); err != nil {
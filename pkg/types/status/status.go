@@ -16,11 +16,38 @@ limitations under the License.
 
 package status
 
+import (
+	"time"
+)
+
 type Status struct {
-	APIName       string `json:"api_name"`
-	APIID         string `json:"api_id"`
-	Code          Code   `json:"status_code"`
-	ReplicaCounts `json:"replica_counts"`
+	APIName            string `json:"api_name"`
+	APIID              string `json:"api_id"`
+	Code               Code   `json:"status_code"`
+	ReplicaCounts      `json:"replica_counts"`
+	LastCrash          *Crash              `json:"last_crash"`          // nil if no replica has crashed
+	ImageVerifications []ImageVerification `json:"image_verifications"` // empty if the cluster doesn't have an image_signature_policy
+}
+
+// ImageVerification records the cosign signature which was verified for one of the API's images when it was deployed
+type ImageVerification struct {
+	Image  string `json:"image"`  // the image as specified in the API configuration
+	Digest string `json:"digest"` // the digest which the API's containers are pinned to
+	KeyID  string `json:"key_id"` // the fingerprint of the public key which signed the image
+}
+
+// Crash describes the most recent unexpected exit of one of the API's containers
+type Crash struct {
+	PodName      string    `json:"pod_name"`
+	Container    string    `json:"container"`
+	ExitCode     int32     `json:"exit_code"`
+	Signal       int32     `json:"signal"`
+	Reason       string    `json:"reason"`
+	FinishedAt   time.Time `json:"finished_at"`
+	RestartCount int32     `json:"restart_count"`
+	Restarted    bool      `json:"restarted"`   // whether the container has been restarted since it crashed
+	Logs         *string   `json:"logs"`        // the container's last log lines before it crashed (only populated when getting a single API)
+	ReportPath   *string   `json:"report_path"` // S3 path of the tracebacks written by the predictor's processes when it crashed, if any
 }
 
 type ReplicaCounts struct {
@@ -18,6 +18,7 @@ package userconfig
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -38,6 +39,11 @@ type API struct {
 	Compute        *Compute        `json:"compute" yaml:"compute"`
 	Autoscaling    *Autoscaling    `json:"autoscaling" yaml:"autoscaling"`
 	UpdateStrategy *UpdateStrategy `json:"update_strategy" yaml:"update_strategy"`
+	Deprecation    *Deprecation    `json:"deprecation" yaml:"deprecation"`
+	Security       *Security       `json:"security" yaml:"security"`
+	Project        *string         `json:"project" yaml:"project"` // groups APIs in usage reports (the API's name if not set)
+	RequestLog     *RequestLog     `json:"request_log" yaml:"request_log"`
+	GoldenDataset  *GoldenDataset  `json:"golden_dataset" yaml:"golden_dataset"`
 	Index          int             `json:"index" yaml:"-"`
 	FileName       string          `json:"file_name" yaml:"-"`
 }
@@ -48,6 +54,7 @@ type Predictor struct {
 	ModelPath              *string                `json:"model_path" yaml:"model_path"`
 	Models                 []*ModelResource       `json:"models" yaml:"models"`
 	PythonPath             *string                `json:"python_path" yaml:"python_path"`
+	Runtime                string                 `json:"runtime" yaml:"runtime"`
 	Image                  string                 `json:"image" yaml:"image"`
 	TensorFlowServingImage string                 `json:"tensorflow_serving_image" yaml:"tensorflow_serving_image"`
 	ProcessesPerReplica    int32                  `json:"processes_per_replica" yaml:"processes_per_replica"`
@@ -74,16 +81,24 @@ type Monitoring struct {
 }
 
 type Networking struct {
-	Endpoint   *string        `json:"endpoint" yaml:"endpoint"`
-	LocalPort  *int           `json:"local_port" yaml:"local_port"`
-	APIGateway APIGatewayType `json:"api_gateway" yaml:"api_gateway"`
+	Endpoint        *string        `json:"endpoint" yaml:"endpoint"`
+	LocalPort       *int           `json:"local_port" yaml:"local_port"`
+	APIGateway      APIGatewayType `json:"api_gateway" yaml:"api_gateway"`
+	MaxPayloadSize  *k8s.Quantity  `json:"max_payload_size" yaml:"max_payload_size"`
+	MaxResponseSize *k8s.Quantity  `json:"max_response_size" yaml:"max_response_size"`
+	EgressAllowlist []string       `json:"egress_allowlist" yaml:"egress_allowlist"`
 }
 
 type Compute struct {
-	CPU *k8s.Quantity `json:"cpu" yaml:"cpu"`
-	Mem *k8s.Quantity `json:"mem" yaml:"mem"`
-	GPU int64         `json:"gpu" yaml:"gpu"`
-	Inf int64         `json:"inf" yaml:"inf"`
+	CPU           *k8s.Quantity `json:"cpu" yaml:"cpu"`
+	Mem           *k8s.Quantity `json:"mem" yaml:"mem"`
+	GPU           int64         `json:"gpu" yaml:"gpu"`
+	Inf           int64         `json:"inf" yaml:"inf"`
+	QoS           QoSClass      `json:"qos" yaml:"qos"`
+	LimitRatio    *float64      `json:"limit_ratio" yaml:"limit_ratio"`
+	ExclusiveCPUs bool          `json:"exclusive_cpus" yaml:"exclusive_cpus"`
+	Shm           *k8s.Quantity `json:"shm" yaml:"shm"`
+	HugePages     *k8s.Quantity `json:"hugepages" yaml:"hugepages"`
 }
 
 type Autoscaling struct {
@@ -106,6 +121,39 @@ type UpdateStrategy struct {
 	MaxUnavailable string `json:"max_unavailable" yaml:"max_unavailable"`
 }
 
+const SunsetDateFormat = "2006-01-02"
+
+// SunsetWarningPeriod is how long before an API's sunset date warnings are shown when listing APIs and when deploying
+// API splitters which route traffic to it
+const SunsetWarningPeriod = 30 * 24 * time.Hour
+
+type Deprecation struct {
+	SunsetDate time.Time `json:"sunset_date" yaml:"sunset_date"`
+	Message    *string   `json:"message" yaml:"message"`
+}
+
+type RequestLog struct {
+	SampleRate float64 `json:"sample_rate" yaml:"sample_rate"`
+}
+
+// GoldenDataset is a set of examples which updates to the API must answer as well as the current version before they
+// are applied
+type GoldenDataset struct {
+	Path               string        `json:"path" yaml:"path"`
+	Tolerance          float64       `json:"tolerance" yaml:"tolerance"`
+	MaxAccuracyDrop    float64       `json:"max_accuracy_drop" yaml:"max_accuracy_drop"`
+	MaxLatencyIncrease *float64      `json:"max_latency_increase" yaml:"max_latency_increase"`
+	Timeout            time.Duration `json:"timeout" yaml:"timeout"`
+}
+
+type Security struct {
+	RunAsNonRoot           *bool   `json:"run_as_non_root" yaml:"run_as_non_root"`
+	ReadOnlyRootFilesystem *bool   `json:"read_only_root_filesystem" yaml:"read_only_root_filesystem"`
+	SeccompProfile         *string `json:"seccomp_profile" yaml:"seccomp_profile"`
+	AppArmorProfile        *string `json:"apparmor_profile" yaml:"apparmor_profile"`
+	PodSecurityStandard    *string `json:"pod_security_standard" yaml:"pod_security_standard"`
+}
+
 func (api *API) Identify() string {
 	return IdentifyAPI(api.FileName, api.Name, api.Kind, api.Index)
 }
@@ -121,11 +169,17 @@ func (api *API) ModelNames() []string {
 	return names
 }
 
+// ApplyDefaultDockerPaths sets the predictor's images which were not specified. If a runtime is specified, its images
+// are applied during validation instead (see spec.ValidateAPI)
 func (api *API) ApplyDefaultDockerPaths() {
 	usesGPU := api.Compute.GPU > 0
 	usesInf := api.Compute.Inf > 0
 
 	predictor := api.Predictor
+	if predictor.Runtime != "" {
+		return
+	}
+
 	switch predictor.Type {
 	case PythonPredictorType:
 		if predictor.Image == "" {
@@ -182,7 +236,7 @@ func IdentifyAPI(filePath string, name string, kind Kind, index int) string {
 
 // InitReplicas was left out deliberately
 func (api *API) ToK8sAnnotations() map[string]string {
-	return map[string]string{
+	annotations := map[string]string{
 		EndpointAnnotationKey:                     *api.Networking.Endpoint,
 		APIGatewayAnnotationKey:                   api.Networking.APIGateway.String(),
 		ProcessesPerReplicaAnnotationKey:          s.Int32(api.Predictor.ProcessesPerReplica),
@@ -199,6 +253,59 @@ func (api *API) ToK8sAnnotations() map[string]string {
 		DownscaleToleranceAnnotationKey:           s.Float64(api.Autoscaling.DownscaleTolerance),
 		UpscaleToleranceAnnotationKey:             s.Float64(api.Autoscaling.UpscaleTolerance),
 	}
+
+	if api.Networking.MaxPayloadSize != nil {
+		annotations[MaxPayloadSizeAnnotationKey] = api.Networking.MaxPayloadSize.String()
+	}
+
+	if api.Networking.MaxResponseSize != nil {
+		annotations[MaxResponseSizeAnnotationKey] = api.Networking.MaxResponseSize.String()
+	}
+
+	if api.Networking.EgressAllowlist != nil {
+		annotations[EgressAllowlistAnnotationKey] = strings.Join(api.Networking.EgressAllowlist, ",")
+	}
+
+	if api.Deprecation != nil {
+		annotations[SunsetDateAnnotationKey] = api.Deprecation.SunsetDate.Format(SunsetDateFormat)
+	}
+
+	if api.Project != nil {
+		annotations[ProjectAnnotationKey] = *api.Project
+	}
+
+	if api.Security != nil {
+		if api.Security.RunAsNonRoot != nil {
+			annotations[RunAsNonRootAnnotationKey] = s.Bool(*api.Security.RunAsNonRoot)
+		}
+		if api.Security.ReadOnlyRootFilesystem != nil {
+			annotations[ReadOnlyRootFilesystemAnnotationKey] = s.Bool(*api.Security.ReadOnlyRootFilesystem)
+		}
+		if api.Security.SeccompProfile != nil {
+			annotations[SeccompProfileAnnotationKey] = *api.Security.SeccompProfile
+		}
+		if api.Security.AppArmorProfile != nil {
+			annotations[AppArmorProfileAnnotationKey] = *api.Security.AppArmorProfile
+		}
+		if api.Security.PodSecurityStandard != nil {
+			annotations[PodSecurityStandardAnnotationKey] = *api.Security.PodSecurityStandard
+		}
+	}
+
+	return annotations
+}
+
+// Returns nil if the sunset date annotation is not set
+func SunsetDateFromAnnotations(k8sObj kmeta.Object) (*time.Time, error) {
+	sunsetDateStr, ok := k8sObj.GetAnnotations()[SunsetDateAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	sunsetDate, err := time.Parse(SunsetDateFormat, sunsetDateStr)
+	if err != nil {
+		return nil, k8s.ErrorParseAnnotation(SunsetDateAnnotationKey, sunsetDateStr, "date")
+	}
+	return &sunsetDate, nil
 }
 
 func APIGatewayFromAnnotations(k8sObj kmeta.Object) (APIGatewayType, error) {
@@ -323,6 +430,30 @@ func (api *API) UserStr(provider types.ProviderType) string {
 			sb.WriteString(fmt.Sprintf("%s:\n", UpdateStrategyKey))
 			sb.WriteString(s.Indent(api.UpdateStrategy.UserStr(), "  "))
 		}
+
+		if api.Deprecation != nil {
+			sb.WriteString(fmt.Sprintf("%s:\n", DeprecationKey))
+			sb.WriteString(s.Indent(api.Deprecation.UserStr(), "  "))
+		}
+
+		if api.Security != nil {
+			sb.WriteString(fmt.Sprintf("%s:\n", SecurityKey))
+			sb.WriteString(s.Indent(api.Security.UserStr(), "  "))
+		}
+
+		if api.Project != nil {
+			sb.WriteString(fmt.Sprintf("%s: %s\n", ProjectKey, *api.Project))
+		}
+
+		if api.RequestLog != nil {
+			sb.WriteString(fmt.Sprintf("%s:\n", RequestLogKey))
+			sb.WriteString(s.Indent(api.RequestLog.UserStr(), "  "))
+		}
+
+		if api.GoldenDataset != nil {
+			sb.WriteString(fmt.Sprintf("%s:\n", GoldenDatasetKey))
+			sb.WriteString(s.Indent(api.GoldenDataset.UserStr(), "  "))
+		}
 	}
 	return sb.String()
 }
@@ -357,6 +488,9 @@ func (predictor *Predictor) UserStr() string {
 		d, _ := yaml.Marshal(&predictor.Config)
 		sb.WriteString(s.Indent(string(d), "  "))
 	}
+	if predictor.Runtime != "" {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", RuntimeKey, predictor.Runtime))
+	}
 	sb.WriteString(fmt.Sprintf("%s: %s\n", ImageKey, predictor.Image))
 	if predictor.TensorFlowServingImage != "" {
 		sb.WriteString(fmt.Sprintf("%s: %s\n", TensorFlowServingImageKey, predictor.TensorFlowServingImage))
@@ -402,6 +536,15 @@ func (networking *Networking) UserStr(provider types.ProviderType) string {
 	if provider == types.AWSProviderType {
 		sb.WriteString(fmt.Sprintf("%s: %s\n", APIGatewayKey, networking.APIGateway))
 	}
+	if networking.MaxPayloadSize != nil {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", MaxPayloadSizeKey, networking.MaxPayloadSize.UserString))
+	}
+	if provider == types.AWSProviderType && networking.MaxResponseSize != nil {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", MaxResponseSizeKey, networking.MaxResponseSize.UserString))
+	}
+	if provider == types.AWSProviderType && networking.EgressAllowlist != nil {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", EgressAllowlistKey, s.ObjFlatNoQuotes(networking.EgressAllowlist)))
+	}
 	return sb.String()
 }
 
@@ -423,6 +566,21 @@ func (compute *Compute) UserStr() string {
 	} else {
 		sb.WriteString(fmt.Sprintf("%s: %s\n", MemKey, compute.Mem.UserString))
 	}
+	if compute.QoS != UnknownQoSClass {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", QoSKey, compute.QoS))
+	}
+	if compute.LimitRatio != nil {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", LimitRatioKey, s.Float64(*compute.LimitRatio)))
+	}
+	if compute.ExclusiveCPUs {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", ExclusiveCPUsKey, s.Bool(compute.ExclusiveCPUs)))
+	}
+	if compute.Shm != nil {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", ShmKey, compute.Shm.UserString))
+	}
+	if compute.HugePages != nil {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", HugePagesKey, compute.HugePages.UserString))
+	}
 	return sb.String()
 }
 
@@ -451,6 +609,26 @@ func (compute Compute) Equals(c2 *Compute) bool {
 		return false
 	}
 
+	if compute.QoS != c2.QoS {
+		return false
+	}
+
+	if s.Obj(compute.LimitRatio) != s.Obj(c2.LimitRatio) {
+		return false
+	}
+
+	if compute.ExclusiveCPUs != c2.ExclusiveCPUs {
+		return false
+	}
+
+	if !k8s.QuantityPtrsEqual(compute.Shm, c2.Shm) {
+		return false
+	}
+
+	if !k8s.QuantityPtrsEqual(compute.HugePages, c2.HugePages) {
+		return false
+	}
+
 	return true
 }
 
@@ -477,3 +655,86 @@ func (updateStrategy *UpdateStrategy) UserStr() string {
 	sb.WriteString(fmt.Sprintf("%s: %s\n", MaxUnavailableKey, updateStrategy.MaxUnavailable))
 	return sb.String()
 }
+
+func (deprecation *Deprecation) UserStr() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s: %s\n", SunsetDateKey, deprecation.SunsetDate.Format(SunsetDateFormat)))
+	if deprecation.Message != nil {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", DeprecationMessageKey, *deprecation.Message))
+	}
+	return sb.String()
+}
+
+func (security *Security) UserStr() string {
+	var sb strings.Builder
+	if security.RunAsNonRoot != nil {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", RunAsNonRootKey, s.Bool(*security.RunAsNonRoot)))
+	}
+	if security.ReadOnlyRootFilesystem != nil {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", ReadOnlyRootFilesystemKey, s.Bool(*security.ReadOnlyRootFilesystem)))
+	}
+	if security.SeccompProfile != nil {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", SeccompProfileKey, *security.SeccompProfile))
+	}
+	if security.AppArmorProfile != nil {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", AppArmorProfileKey, *security.AppArmorProfile))
+	}
+	if security.PodSecurityStandard != nil {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", PodSecurityStandardKey, *security.PodSecurityStandard))
+	}
+	return sb.String()
+}
+
+// DaysUntilSunset returns the number of whole days remaining until the sunset date (negative if it has passed)
+func (requestLog *RequestLog) UserStr() string {
+	return fmt.Sprintf("%s: %s\n", SampleRateKey, s.Float64(requestLog.SampleRate))
+}
+
+func (goldenDataset *GoldenDataset) UserStr() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s: %s\n", PathKey, goldenDataset.Path))
+	sb.WriteString(fmt.Sprintf("%s: %s\n", ToleranceKey, s.Float64(goldenDataset.Tolerance)))
+	sb.WriteString(fmt.Sprintf("%s: %s\n", MaxAccuracyDropKey, s.Float64(goldenDataset.MaxAccuracyDrop)))
+	if goldenDataset.MaxLatencyIncrease != nil {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", MaxLatencyIncreaseKey, s.Float64(*goldenDataset.MaxLatencyIncrease)))
+	}
+	sb.WriteString(fmt.Sprintf("%s: %s\n", GoldenDatasetTimeoutKey, goldenDataset.Timeout.String()))
+	return sb.String()
+}
+
+func (deprecation *Deprecation) DaysUntilSunset() int {
+	return int(math.Floor(time.Until(deprecation.SunsetDate).Hours() / 24))
+}
+
+// IsSunsetApproaching returns true if the sunset date is within SunsetWarningPeriod (or has passed)
+func (deprecation *Deprecation) IsSunsetApproaching() bool {
+	return time.Until(deprecation.SunsetDate) < SunsetWarningPeriod
+}
+
+func (deprecation *Deprecation) sunsetStr() string {
+	daysUntilSunset := deprecation.DaysUntilSunset()
+	sunsetDateStr := deprecation.SunsetDate.Format(SunsetDateFormat)
+	if daysUntilSunset < 0 {
+		return fmt.Sprintf("its sunset date (%s) has passed", sunsetDateStr)
+	}
+	return fmt.Sprintf("will be sunset in %d %s (on %s)", daysUntilSunset, s.PluralS("day", daysUntilSunset), sunsetDateStr)
+}
+
+// DeprecationWarning returns a message describing the API's deprecation (or "" if the API isn't deprecated)
+func (api *API) DeprecationWarning() string {
+	if api.Deprecation == nil {
+		return ""
+	}
+
+	msg := fmt.Sprintf("%s is deprecated and %s", api.Name, api.Deprecation.sunsetStr())
+	if api.Deprecation.Message != nil {
+		msg += ": " + *api.Deprecation.Message
+	}
+
+	return msg
+}
+
+// APISplitterDeprecationWarning returns a message describing the deprecation of an API which an API splitter routes traffic to
+func APISplitterDeprecationWarning(apiSplitterName string, apiName string, deprecation *Deprecation) string {
+	return fmt.Sprintf("%s routes traffic to %s, which is deprecated and %s", apiSplitterName, apiName, deprecation.sunsetStr())
+}
@@ -26,6 +26,11 @@ const (
 	ComputeKey        = "compute"
 	AutoscalingKey    = "autoscaling"
 	UpdateStrategyKey = "update_strategy"
+	DeprecationKey    = "deprecation"
+	SecurityKey       = "security"
+	ProjectKey        = "project"
+	RequestLogKey     = "request_log"
+	GoldenDatasetKey  = "golden_dataset"
 
 	// APISplitter
 	APIsKey   = "apis"
@@ -39,6 +44,7 @@ const (
 	ThreadsPerProcessKey      = "threads_per_process"
 	ModelsKey                 = "models"
 	PythonPathKey             = "python_path"
+	RuntimeKey                = "runtime"
 	ImageKey                  = "image"
 	TensorFlowServingImageKey = "tensorflow_serving_image"
 	ConfigKey                 = "config"
@@ -53,15 +59,23 @@ const (
 	ModelTypeKey = "model_type"
 
 	// Networking
-	APIGatewayKey = "api_gateway"
-	EndpointKey   = "endpoint"
-	LocalPortKey  = "local_port"
+	APIGatewayKey      = "api_gateway"
+	EndpointKey        = "endpoint"
+	LocalPortKey       = "local_port"
+	MaxPayloadSizeKey  = "max_payload_size"
+	MaxResponseSizeKey = "max_response_size"
+	EgressAllowlistKey = "egress_allowlist"
 
 	// Compute
-	CPUKey = "cpu"
-	MemKey = "mem"
-	GPUKey = "gpu"
-	InfKey = "inf"
+	CPUKey           = "cpu"
+	MemKey           = "mem"
+	GPUKey           = "gpu"
+	InfKey           = "inf"
+	QoSKey           = "qos"
+	LimitRatioKey    = "limit_ratio"
+	ExclusiveCPUsKey = "exclusive_cpus"
+	ShmKey           = "shm"
+	HugePagesKey     = "hugepages"
 
 	// Autoscaling
 	MinReplicasKey                  = "min_replicas"
@@ -81,9 +95,32 @@ const (
 	MaxSurgeKey       = "max_surge"
 	MaxUnavailableKey = "max_unavailable"
 
+	// Deprecation
+	SunsetDateKey         = "sunset_date"
+	DeprecationMessageKey = "message"
+
+	// Security
+	RunAsNonRootKey           = "run_as_non_root"
+	ReadOnlyRootFilesystemKey = "read_only_root_filesystem"
+	SeccompProfileKey         = "seccomp_profile"
+	AppArmorProfileKey        = "apparmor_profile"
+	PodSecurityStandardKey    = "pod_security_standard"
+
+	// RequestLog
+	SampleRateKey = "sample_rate"
+
+	// GoldenDataset
+	ToleranceKey            = "tolerance"
+	MaxAccuracyDropKey      = "max_accuracy_drop"
+	MaxLatencyIncreaseKey   = "max_latency_increase"
+	GoldenDatasetTimeoutKey = "timeout"
+
 	// K8s annotation
 	EndpointAnnotationKey                     = "networking.cortex.dev/endpoint"
 	APIGatewayAnnotationKey                   = "networking.cortex.dev/api-gateway"
+	MaxPayloadSizeAnnotationKey               = "networking.cortex.dev/max-payload-size"
+	MaxResponseSizeAnnotationKey              = "networking.cortex.dev/max-response-size"
+	EgressAllowlistAnnotationKey              = "networking.cortex.dev/egress-allowlist"
 	ProcessesPerReplicaAnnotationKey          = "predictor.cortex.dev/processes-per-replica"
 	ThreadsPerProcessAnnotationKey            = "predictor.cortex.dev/threads-per-process"
 	MinReplicasAnnotationKey                  = "autoscaling.cortex.dev/min-replicas"
@@ -97,4 +134,16 @@ const (
 	MaxUpscaleFactorAnnotationKey             = "autoscaling.cortex.dev/max-upscale-factor"
 	DownscaleToleranceAnnotationKey           = "autoscaling.cortex.dev/downscale-tolerance"
 	UpscaleToleranceAnnotationKey             = "autoscaling.cortex.dev/upscale-tolerance"
+	SunsetDateAnnotationKey                   = "lifecycle.cortex.dev/sunset-date"
+	MaintenanceStatusCodeAnnotationKey        = "lifecycle.cortex.dev/maintenance-status-code"
+	MaintenanceMessageAnnotationKey           = "lifecycle.cortex.dev/maintenance-message"
+	ExpirationAnnotationKey                   = "lifecycle.cortex.dev/expiration"
+	PreviewBranchAnnotationKey                = "lifecycle.cortex.dev/preview-branch"
+	RunAsNonRootAnnotationKey                 = "security.cortex.dev/run-as-non-root"
+	ReadOnlyRootFilesystemAnnotationKey       = "security.cortex.dev/read-only-root-filesystem"
+	SeccompProfileAnnotationKey               = "security.cortex.dev/seccomp-profile"
+	AppArmorProfileAnnotationKey              = "security.cortex.dev/apparmor-profile"
+	PodSecurityStandardAnnotationKey          = "security.cortex.dev/pod-security-standard"
+	ImageVerificationsAnnotationKey           = "security.cortex.dev/image-verifications"
+	ProjectAnnotationKey                      = "metering.cortex.dev/project"
 )
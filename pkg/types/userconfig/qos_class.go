@@ -0,0 +1,80 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userconfig
+
+type QoSClass int
+
+const (
+	UnknownQoSClass QoSClass = iota
+	GuaranteedQoSClass
+	BurstableQoSClass
+	BestEffortQoSClass
+)
+
+var _qosClasses = []string{
+	"unknown",
+	"guaranteed",
+	"burstable",
+	"best_effort",
+}
+
+func QoSClassFromString(s string) QoSClass {
+	for i := 0; i < len(_qosClasses); i++ {
+		if s == _qosClasses[i] {
+			return QoSClass(i)
+		}
+	}
+	return UnknownQoSClass
+}
+
+func QoSClassStrings() []string {
+	return _qosClasses[1:]
+}
+
+func (t QoSClass) String() string {
+	return _qosClasses[t]
+}
+
+// MarshalText satisfies TextMarshaler
+func (t QoSClass) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText satisfies TextUnmarshaler
+func (t *QoSClass) UnmarshalText(text []byte) error {
+	enum := string(text)
+	for i := 0; i < len(_qosClasses); i++ {
+		if enum == _qosClasses[i] {
+			*t = QoSClass(i)
+			return nil
+		}
+	}
+
+	*t = UnknownQoSClass
+	return nil
+}
+
+// UnmarshalBinary satisfies BinaryUnmarshaler
+// Needed for msgpack
+func (t *QoSClass) UnmarshalBinary(data []byte) error {
+	return t.UnmarshalText(data)
+}
+
+// MarshalBinary satisfies BinaryMarshaler
+func (t QoSClass) MarshalBinary() ([]byte, error) {
+	return []byte(t.String()), nil
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userconfig
+
+import (
+	"github.com/cortexlabs/cortex/pkg/consts"
+)
+
+type Accelerator string
+
+const (
+	CPUAccelerator Accelerator = "cpu"
+	GPUAccelerator Accelerator = "gpu"
+	InfAccelerator Accelerator = "inf"
+)
+
+// Runtime is an entry in the catalog of serving images which are maintained by cortex. APIs can reference a runtime
+// by name (via the predictor's runtime field) instead of specifying image paths directly
+type Runtime struct {
+	Name                   string        `json:"name"`
+	PredictorType          PredictorType `json:"predictor_type"`
+	Accelerator            Accelerator   `json:"accelerator"`
+	Python                 string        `json:"python"`
+	CUDA                   string        `json:"cuda"` // empty if the runtime doesn't support GPUs
+	Frameworks             []string      `json:"frameworks"`
+	Image                  string        `json:"image"`
+	TensorFlowServingImage string        `json:"tensorflow_serving_image"` // only set for the TensorFlow predictor
+}
+
+// RuntimeCatalog lists the runtimes which are supported by this version of cortex. The python, cuda, and framework
+// versions are checked against the images' Dockerfiles in runtime_test.go
+var RuntimeCatalog = []Runtime{
+	{
+		Name:          "python-3.6-cpu",
+		PredictorType: PythonPredictorType,
+		Accelerator:   CPUAccelerator,
+		Python:        "3.6.9",
+		Frameworks:    []string{"tensorflow 2.1.0", "torch 1.5.1"},
+		Image:         consts.RuntimeImage("python-predictor-cpu", "python-3.6-cpu"),
+	},
+	{
+		Name:          "python-3.6-cuda10.1",
+		PredictorType: PythonPredictorType,
+		Accelerator:   GPUAccelerator,
+		Python:        "3.6.9",
+		CUDA:          "10.1",
+		Frameworks:    []string{"tensorflow 2.1.0", "torch 1.5.1"},
+		Image:         consts.RuntimeImage("python-predictor-gpu", "python-3.6-cuda10.1"),
+	},
+	{
+		Name:          "python-3.6-inf",
+		PredictorType: PythonPredictorType,
+		Accelerator:   InfAccelerator,
+		Python:        "3.6.9",
+		Frameworks:    []string{"tensorflow-neuron 1.15.0", "torch-neuron 1.0.825"},
+		Image:         consts.RuntimeImage("python-predictor-inf", "python-3.6-inf"),
+	},
+	{
+		Name:                   "tensorflow-2.1-cpu",
+		PredictorType:          TensorFlowPredictorType,
+		Accelerator:            CPUAccelerator,
+		Python:                 "3.6.9",
+		Frameworks:             []string{"tensorflow-serving 2.1.0"},
+		Image:                  consts.RuntimeImage("tensorflow-predictor", "tensorflow-2.1-cpu"),
+		TensorFlowServingImage: consts.RuntimeImage("tensorflow-serving-cpu", "tensorflow-2.1-cpu"),
+	},
+	{
+		Name:                   "tensorflow-2.1-cuda10.1",
+		PredictorType:          TensorFlowPredictorType,
+		Accelerator:            GPUAccelerator,
+		Python:                 "3.6.9",
+		CUDA:                   "10.1",
+		Frameworks:             []string{"tensorflow-serving 2.1.0"},
+		Image:                  consts.RuntimeImage("tensorflow-predictor", "tensorflow-2.1-cuda10.1"),
+		TensorFlowServingImage: consts.RuntimeImage("tensorflow-serving-gpu", "tensorflow-2.1-cuda10.1"),
+	},
+	{
+		Name:                   "tensorflow-1.15-inf",
+		PredictorType:          TensorFlowPredictorType,
+		Accelerator:            InfAccelerator,
+		Python:                 "3.6.9",
+		Frameworks:             []string{"tensorflow-model-server-neuron 1.15"},
+		Image:                  consts.RuntimeImage("tensorflow-predictor", "tensorflow-1.15-inf"),
+		TensorFlowServingImage: consts.RuntimeImage("tensorflow-serving-inf", "tensorflow-1.15-inf"),
+	},
+	{
+		Name:          "onnx-1.2-cpu",
+		PredictorType: ONNXPredictorType,
+		Accelerator:   CPUAccelerator,
+		Python:        "3.6.9",
+		Frameworks:    []string{"onnxruntime 1.2.0"},
+		Image:         consts.RuntimeImage("onnx-predictor-cpu", "onnx-1.2-cpu"),
+	},
+	{
+		Name:          "onnx-1.2-cuda10.1",
+		PredictorType: ONNXPredictorType,
+		Accelerator:   GPUAccelerator,
+		Python:        "3.6.9",
+		CUDA:          "10.1",
+		Frameworks:    []string{"onnxruntime-gpu 1.2.0"},
+		Image:         consts.RuntimeImage("onnx-predictor-gpu", "onnx-1.2-cuda10.1"),
+	},
+}
+
+// Returns nil if there is no runtime with the given name
+func GetRuntime(name string) *Runtime {
+	for i := range RuntimeCatalog {
+		if RuntimeCatalog[i].Name == name {
+			return &RuntimeCatalog[i]
+		}
+	}
+	return nil
+}
+
+// AllRuntimeNames returns the names of all of the runtimes in the catalog
+func AllRuntimeNames() []string {
+	names := make([]string, 0, len(RuntimeCatalog))
+	for _, runtime := range RuntimeCatalog {
+		names = append(names, runtime.Name)
+	}
+	return names
+}
+
+// IsRuntimeImage returns true if the image is used by a runtime in the catalog
+func IsRuntimeImage(image string) bool {
+	for _, runtime := range RuntimeCatalog {
+		if runtime.Image == image || runtime.TensorFlowServingImage == image {
+			return true
+		}
+	}
+	return false
+}
+
+// RuntimeNames returns the names of the runtimes which support the predictor type
+func RuntimeNames(predictorType PredictorType) []string {
+	var names []string
+	for _, runtime := range RuntimeCatalog {
+		if runtime.PredictorType == predictorType {
+			names = append(names, runtime.Name)
+		}
+	}
+	return names
+}
+
+// Accelerator returns the type of accelerator which the API's compute requests
+func (compute *Compute) Accelerator() Accelerator {
+	if compute.GPU > 0 {
+		return GPUAccelerator
+	}
+	if compute.Inf > 0 {
+		return InfAccelerator
+	}
+	return CPUAccelerator
+}
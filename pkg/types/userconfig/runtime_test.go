@@ -0,0 +1,178 @@
+/*
+Copyright 2020 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userconfig
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/cortexlabs/cortex/pkg/consts"
+	"github.com/stretchr/testify/require"
+)
+
+// how each framework's version is pinned in the images' Dockerfiles (%s is the version)
+var _frameworkDockerfilePatterns = map[string]string{
+	"tensorflow":         `tensorflow(-cpu|-gpu)?==%s\b`,
+	"torch":              `torch==%s\b`,
+	"tensorflow-neuron":  `tensorflow-neuron==%s\b`,
+	"torch-neuron":       `torch-neuron==%s\b`,
+	"tensorflow-serving": `FROM tensorflow/serving:%s\b`,
+	"onnxruntime":        `onnxruntime==%s\b`,
+	"onnxruntime-gpu":    `onnxruntime-gpu==%s\b`,
+	// installed from the neuron apt repository without a pinned version
+	"tensorflow-model-server-neuron": `tensorflow-model-server-neuron`,
+}
+
+// returns the contents of the Dockerfile in images/ which builds the image
+func readImageDockerfile(t *testing.T, image string) string {
+	imageName := image[strings.LastIndex(image, "/")+1:]
+	imageName = strings.Split(imageName, ":")[0]
+
+	dockerfileBytes, err := ioutil.ReadFile(filepath.Join("..", "..", "..", "images", imageName, "Dockerfile"))
+	require.NoError(t, err, image)
+	return string(dockerfileBytes)
+}
+
+func TestRuntimeCatalog(t *testing.T) {
+	names := map[string]bool{}
+	for _, runtime := range RuntimeCatalog {
+		require.False(t, names[runtime.Name], "duplicate runtime: %s", runtime.Name)
+		names[runtime.Name] = true
+
+		require.NotNil(t, GetRuntime(runtime.Name))
+		require.Contains(t, RuntimeNames(runtime.PredictorType), runtime.Name)
+		require.True(t, IsRuntimeImage(runtime.Image), runtime.Name)
+
+		require.Equal(t, runtime.PredictorType == TensorFlowPredictorType, runtime.TensorFlowServingImage != "", runtime.Name)
+		require.Equal(t, runtime.Accelerator == GPUAccelerator, runtime.CUDA != "", runtime.Name)
+
+		// runtime images are pinned to tags which are specific to the runtime (rather than the mutable default images)
+		if os.Getenv("CORTEX_DEV_DEFAULT_PREDICTOR_IMAGE_REGISTRY") == "" {
+			require.True(t, strings.HasSuffix(runtime.Image, ":"+consts.CortexVersion+"-"+runtime.Name), runtime.Image)
+			if runtime.TensorFlowServingImage != "" {
+				require.True(t, strings.HasSuffix(runtime.TensorFlowServingImage, ":"+consts.CortexVersion+"-"+runtime.Name), runtime.TensorFlowServingImage)
+			}
+		}
+		require.False(t, consts.DefaultImagePathsSet.Has(runtime.Image), runtime.Image)
+	}
+
+	require.ElementsMatch(t, AllRuntimeNames(), func() []string {
+		var allNames []string
+		for name := range names {
+			allNames = append(allNames, name)
+		}
+		return allNames
+	}())
+
+	require.Nil(t, GetRuntime("python-2.7-cpu"))
+	require.False(t, IsRuntimeImage(consts.DefaultImagePythonPredictorCPU))
+}
+
+// checks that the versions in the catalog haven't drifted from the versions which are installed in the images
+func TestRuntimeVersionsMatchDockerfiles(t *testing.T) {
+	for _, runtime := range RuntimeCatalog {
+		predictorDockerfile := readImageDockerfile(t, runtime.Image)
+		frameworksDockerfile := predictorDockerfile
+		if runtime.TensorFlowServingImage != "" {
+			frameworksDockerfile = readImageDockerfile(t, runtime.TensorFlowServingImage)
+		}
+
+		require.Contains(t, predictorDockerfile, "ENV PYTHONVERSION="+runtime.Python+"\n", runtime.Name)
+
+		if runtime.CUDA != "" {
+			require.Regexp(t, regexp.MustCompile(`cuda:?`+regexp.QuoteMeta(runtime.CUDA)+`\b`), frameworksDockerfile, runtime.Name)
+		}
+
+		for _, framework := range runtime.Frameworks {
+			split := strings.Split(framework, " ")
+			require.Len(t, split, 2, framework)
+			name, version := split[0], split[1]
+
+			pattern, ok := _frameworkDockerfilePatterns[name]
+			require.True(t, ok, "missing dockerfile pattern for %s", name)
+			if strings.Contains(pattern, "%s") {
+				pattern = fmt.Sprintf(pattern, regexp.QuoteMeta(version))
+			}
+			require.Regexp(t, regexp.MustCompile(pattern), frameworksDockerfile, "%s: %s", runtime.Name, framework)
+		}
+	}
+}
+
+func TestApplyDefaultDockerPaths(t *testing.T) {
+	newAPI := func(predictorType PredictorType, gpu int64, inf int64) *API {
+		return &API{
+			Predictor: &Predictor{Type: predictorType},
+			Compute:   &Compute{GPU: gpu, Inf: inf},
+		}
+	}
+
+	api := newAPI(PythonPredictorType, 0, 0)
+	api.ApplyDefaultDockerPaths()
+	require.Equal(t, consts.DefaultImagePythonPredictorCPU, api.Predictor.Image)
+	require.Empty(t, api.Predictor.TensorFlowServingImage)
+
+	api = newAPI(PythonPredictorType, 1, 0)
+	api.ApplyDefaultDockerPaths()
+	require.Equal(t, consts.DefaultImagePythonPredictorGPU, api.Predictor.Image)
+
+	api = newAPI(PythonPredictorType, 0, 1)
+	api.ApplyDefaultDockerPaths()
+	require.Equal(t, consts.DefaultImagePythonPredictorInf, api.Predictor.Image)
+
+	api = newAPI(TensorFlowPredictorType, 0, 0)
+	api.ApplyDefaultDockerPaths()
+	require.Equal(t, consts.DefaultImageTensorFlowPredictor, api.Predictor.Image)
+	require.Equal(t, consts.DefaultImageTensorFlowServingCPU, api.Predictor.TensorFlowServingImage)
+
+	api = newAPI(TensorFlowPredictorType, 1, 0)
+	api.ApplyDefaultDockerPaths()
+	require.Equal(t, consts.DefaultImageTensorFlowPredictor, api.Predictor.Image)
+	require.Equal(t, consts.DefaultImageTensorFlowServingGPU, api.Predictor.TensorFlowServingImage)
+
+	api = newAPI(TensorFlowPredictorType, 0, 1)
+	api.ApplyDefaultDockerPaths()
+	require.Equal(t, consts.DefaultImageTensorFlowServingInf, api.Predictor.TensorFlowServingImage)
+
+	api = newAPI(ONNXPredictorType, 0, 0)
+	api.ApplyDefaultDockerPaths()
+	require.Equal(t, consts.DefaultImageONNXPredictorCPU, api.Predictor.Image)
+
+	api = newAPI(ONNXPredictorType, 1, 0)
+	api.ApplyDefaultDockerPaths()
+	require.Equal(t, consts.DefaultImageONNXPredictorGPU, api.Predictor.Image)
+
+	// images which are specified are not overwritten
+	api = newAPI(TensorFlowPredictorType, 1, 0)
+	api.Predictor.Image = "org/predictor:1.0"
+	api.ApplyDefaultDockerPaths()
+	require.Equal(t, "org/predictor:1.0", api.Predictor.Image)
+	require.Equal(t, consts.DefaultImageTensorFlowServingGPU, api.Predictor.TensorFlowServingImage)
+
+	// the runtime's images are applied during validation instead
+	for _, runtime := range RuntimeCatalog {
+		api = newAPI(runtime.PredictorType, 0, 0)
+		api.Predictor.Runtime = runtime.Name
+		api.ApplyDefaultDockerPaths()
+		require.Empty(t, api.Predictor.Image, runtime.Name)
+		require.Empty(t, api.Predictor.TensorFlowServingImage, runtime.Name)
+	}
+}
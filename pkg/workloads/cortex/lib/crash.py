@@ -0,0 +1,60 @@
+# Copyright 2020 Cortex Labs, Inc.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+import faulthandler
+import os
+import time
+
+from cortex.lib.storage import S3
+
+# /mnt is an emptyDir volume, so crash reports outlive the container which wrote them
+CRASH_REPORTS_DIR = "/mnt/workspace/crashes"
+
+MAX_CRASH_REPORT_SIZE = 1024 * 1024  # bytes (the end of larger reports is kept)
+
+# faulthandler writes to the file directly, so it must stay open for the process's lifetime
+_crash_report_file = None
+
+
+def enable_crash_reports():
+    """Write the tracebacks of this process's threads to a crash report if it segfaults or aborts"""
+    global _crash_report_file
+    os.makedirs(CRASH_REPORTS_DIR, exist_ok=True)
+    # the start time is included so that reports which haven't been uploaded yet aren't overwritten if a pid is reused
+    file_name = f"{int(time.time())}-{os.getpid()}.txt"
+    _crash_report_file = open(os.path.join(CRASH_REPORTS_DIR, file_name), "w")
+    faulthandler.enable(file=_crash_report_file, all_threads=True)
+
+
+def upload_crash_reports(storage, spec_path):
+    """Upload the crash reports written by the previous instance of this container"""
+    if not os.path.isdir(CRASH_REPORTS_DIR):
+        return
+
+    _, spec_key = S3.deconstruct_s3_path(spec_path)
+    prefix = os.path.join(os.path.dirname(spec_key), "crashes", os.environ["HOSTNAME"])
+
+    for file_name in sorted(os.listdir(CRASH_REPORTS_DIR)):
+        path = os.path.join(CRASH_REPORTS_DIR, file_name)
+        size = os.path.getsize(path)
+        if size == 0:
+            os.remove(path)  # the process which wrote this file didn't crash
+            continue
+        with open(path, "rb") as f:
+            if size > MAX_CRASH_REPORT_SIZE:
+                f.seek(size - MAX_CRASH_REPORT_SIZE)
+            report = f.read()
+        key = os.path.join(prefix, f"{int(os.path.getmtime(path))}-{file_name}")
+        # if the upload fails, the report is kept so that the next instance of this container can retry
+        storage.put_bytes(report, key, "text/plain")
+        os.remove(path)
@@ -0,0 +1,72 @@
+# Copyright 2020 Cortex Labs, Inc.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+
+import os
+import platform
+import subprocess
+import sys
+
+from cortex.lib.storage import S3
+
+# environment variables whose names contain any of these are recorded without their values
+_REDACTED_ENV_VAR_SUBSTRINGS = ["KEY", "SECRET", "TOKEN", "PASSWORD", "CREDENTIAL"]
+
+
+def _run(cmd):
+    try:
+        return subprocess.run(
+            cmd, stdout=subprocess.PIPE, stderr=subprocess.DEVNULL, check=True, timeout=60
+        ).stdout.decode("utf-8")
+    except Exception:
+        return None
+
+
+def _lines(output):
+    if output is None:
+        return None
+    return [line for line in output.splitlines() if line.strip() != ""]
+
+
+def _env_vars():
+    env_vars = {}
+    for name, value in os.environ.items():
+        if any(substring in name.upper() for substring in _REDACTED_ENV_VAR_SUBSTRINGS):
+            env_vars[name] = "<redacted>"
+        else:
+            env_vars[name] = value
+    return env_vars
+
+
+def get_environment():
+    driver_versions = _lines(
+        _run(["nvidia-smi", "--query-gpu=driver_version", "--format=csv,noheader"])
+    )
+
+    return {
+        "python_version": platform.python_version(),
+        "pip_packages": _lines(_run([sys.executable, "-m", "pip", "freeze"])),
+        "conda_packages": _lines(_run(["conda", "list", "--export"])),
+        "cuda_version": os.getenv("CUDA_VERSION"),
+        "cudnn_version": os.getenv("CUDNN_VERSION"),
+        "nvidia_driver_version": driver_versions[0] if driver_versions else None,
+        "env_vars": _env_vars(),
+    }
+
+
+def record_environment(storage, spec_path):
+    """Write the resolved environment of this replica next to the API spec (it is served by the operator)"""
+    _, spec_key = S3.deconstruct_s3_path(spec_path)
+    key = os.path.join(os.path.dirname(spec_key), "environment.json")
+    storage.put_json(get_environment(), key)
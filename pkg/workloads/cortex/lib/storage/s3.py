@@ -149,6 +149,17 @@ class S3(object):
     def put_msgpack(self, obj, key):
         self._upload_string_to_s3(msgpack.dumps(obj), key)
 
+    def put_bytes(self, bytes_val, key, content_type=None):
+        extra_args = {}
+        if content_type is not None:
+            extra_args["ContentType"] = content_type
+        self.s3.put_object(Bucket=self.bucket, Key=key, Body=bytes_val, **extra_args)
+
+    def presigned_url(self, key, expiration_sec=3600):
+        return self.s3.generate_presigned_url(
+            "get_object", Params={"Bucket": self.bucket, "Key": key}, ExpiresIn=expiration_sec
+        )
+
     def get_msgpack(self, key, allow_missing=False, num_retries=0, retry_delay_sec=2):
         obj = self._read_bytes_from_s3(
             key,
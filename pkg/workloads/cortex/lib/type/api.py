@@ -15,6 +15,7 @@
 import os
 import base64
 import time
+import random
 from pathlib import Path
 import json
 import msgpack
@@ -39,10 +40,20 @@ class API:
         self.monitoring = None
         if kwargs.get("monitoring") is not None:
             self.monitoring = Monitoring(**kwargs["monitoring"])
+        self.request_log_sample_rate = 0
+        if kwargs.get("request_log") is not None:
+            self.request_log_sample_rate = kwargs["request_log"]["sample_rate"]
 
         self.cache_dir = cache_dir
         self.storage = storage
 
+        self.extra_metric_dimensions = []
+        if os.environ.get("CORTEX_METRICS_DIMENSIONS"):
+            dimensions = json.loads(os.environ["CORTEX_METRICS_DIMENSIONS"]) or {}
+            self.extra_metric_dimensions = [
+                {"Name": name, "Value": dimensions[name]} for name in sorted(dimensions)
+            ]
+
         if provider != "local":
             host_ip = os.environ["HOST_IP"]
             datadog.initialize(statsd_host=host_ip, statsd_port="8125")
@@ -66,11 +77,33 @@ class API:
         except Exception as e:
             raise ValueError("unable to store class {}".format(class_name)) from e
 
+    def upload_response(self, request_id, body, media_type, expiration_sec):
+        key = os.path.join(self.metadata_root, "responses", request_id)
+        self.storage.put_bytes(body, key, content_type=media_type)
+        return self.storage.presigned_url(key, expiration_sec)
+
+    def sample_request(self):
+        return self.request_log_sample_rate > 0 and random.random() < self.request_log_sample_rate
+
+    def upload_request_log(self, record):
+        # must be kept in sync with requestLogPrefix() in pkg/operator/resources/syncapi/replay.go
+        timestamp = record["timestamp"]
+        key = os.path.join(
+            self.metadata_root,
+            "request_log",
+            time.strftime("%Y-%m-%d/%H", time.gmtime(timestamp)),
+            "{:013d}-{}.json".format(int(timestamp * 1000), record["request_id"]),
+        )
+        self.storage.put_json(record, key)
+
     def metric_dimensions_with_id(self):
-        return [{"Name": "APIName", "Value": self.name}, {"Name": "APIID", "Value": self.id}]
+        return [
+            {"Name": "APIName", "Value": self.name},
+            {"Name": "APIID", "Value": self.id},
+        ] + self.extra_metric_dimensions
 
     def metric_dimensions(self):
-        return [{"Name": "APIName", "Value": self.name}]
+        return [{"Name": "APIName", "Value": self.name}] + self.extra_metric_dimensions
 
     def post_request_metrics(self, status_code, total_time):
         total_time_ms = total_time * 1000
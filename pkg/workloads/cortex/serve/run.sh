@@ -51,7 +51,20 @@ export PYTHONPATH=$PYTHONPATH:$CORTEX_PYTHON_PATH
 # ensure predictor print() statements are always flushed
 export PYTHONUNBUFFERED=TRUE
 
-if [ "$CORTEX_PROVIDER" != "local" ]; then
+if [ "$(id -u)" != "0" ]; then
+    # the API is running as a non-root user (see the `security` field in the API configuration)
+    if [ ! -x /opt/conda/envs/env/bin/python ] || ! touch /mnt/workspace/.write_check 2>/dev/null; then
+        echo "error: your predictor image does not support running as a non-root user (user $(id -u) must be able to execute /opt/conda/envs/env/bin/python and write to /mnt); please update your predictor image, or set \`run_as_non_root: false\` in your API configuration"
+        exit 1
+    fi
+    rm -f /mnt/workspace/.write_check
+    for file in dependencies.sh conda-packages.txt requirements.txt; do
+        if [ -f "/mnt/project/$file" ]; then
+            echo "error: $file cannot be used when running as a non-root user; please build a custom predictor image with your dependencies pre-installed instead"
+            exit 1
+        fi
+    done
+elif [ "$CORTEX_PROVIDER" != "local" ]; then
     sysctl -w net.core.somaxconn=$CORTEX_SO_MAX_CONN >/dev/null
     sysctl -w net.ipv4.ip_local_port_range="15000 64000" >/dev/null
     sysctl -w net.ipv4.tcp_fin_timeout=30 >/dev/null
@@ -22,6 +22,7 @@ from concurrent.futures import ThreadPoolExecutor
 import threading
 import math
 import asyncio
+import base64
 from typing import Any
 
 from fastapi import Body, FastAPI
@@ -37,6 +38,7 @@ from cortex.lib.type import API, get_spec
 from cortex.lib.log import cx_logger
 from cortex.lib.storage import S3, LocalStorage, FileLock
 from cortex.lib.exceptions import UserRuntimeException
+from cortex.lib.crash import enable_crash_reports
 
 API_SUMMARY_MESSAGE = (
     "make a prediction by sending a post request to this endpoint with a json payload"
@@ -44,6 +46,19 @@ API_SUMMARY_MESSAGE = (
 
 API_LIVENESS_UPDATE_PERIOD = 5  # seconds
 
+MAX_PAYLOAD_SIZE = (
+    int(os.environ["CORTEX_MAX_PAYLOAD_SIZE"]) if "CORTEX_MAX_PAYLOAD_SIZE" in os.environ else None
+)  # bytes
+
+MAX_RESPONSE_SIZE = (
+    int(os.environ["CORTEX_MAX_RESPONSE_SIZE"])
+    if "CORTEX_MAX_RESPONSE_SIZE" in os.environ
+    else None
+)  # bytes
+
+# the operator deletes offloaded responses once their urls have expired (see syncapi/responses.go)
+OFFLOADED_RESPONSE_URL_EXPIRATION = 3600  # seconds
+
 
 request_thread_pool = ThreadPoolExecutor(max_workers=int(os.environ["CORTEX_THREADS_PER_PROCESS"]))
 loop = asyncio.get_event_loop()
@@ -152,6 +167,27 @@ async def parse_payload(request: Request, call_next):
     if not is_prediction_request(request):
         return await call_next(request)
 
+    if MAX_PAYLOAD_SIZE is not None:
+        content_length = request.headers.get("content-length")
+        if content_length is not None and content_length.isdigit():
+            if int(content_length) > MAX_PAYLOAD_SIZE:
+                return payload_too_large_response()
+        else:
+            # the body is read here so that chunked requests can't exceed the limit either;
+            # starlette reuses request._body when the payload is parsed below
+            body = b""
+            async for chunk in request.stream():
+                body += chunk
+                if len(body) > MAX_PAYLOAD_SIZE:
+                    return payload_too_large_response()
+            request._body = body
+
+    api = local_cache["api"]
+    request.state.log_request = local_cache["provider"] != "local" and api.sample_request()
+    if request.state.log_request:
+        # request.state is shared with the endpoint (unlike the request itself)
+        request.state.raw_payload = await request.body()
+
     if "payload" not in local_cache["predict_fn_args"]:
         return await call_next(request)
 
@@ -175,6 +211,13 @@ async def parse_payload(request: Request, call_next):
     return await call_next(request)
 
 
+def payload_too_large_response():
+    return PlainTextResponse(
+        content=f"the request payload exceeds the maximum size of {MAX_PAYLOAD_SIZE} bytes",
+        status_code=413,
+    )
+
+
 def predict(request: Request):
     api = local_cache["api"]
     predictor_impl = local_cache["predictor_impl"]
@@ -198,6 +241,15 @@ def predict(request: Request):
             ) from e
         response = Response(content=json_string, media_type="application/json")
 
+    if (
+        MAX_RESPONSE_SIZE is not None
+        and 200 <= response.status_code < 300
+        and len(getattr(response, "body", b"")) > MAX_RESPONSE_SIZE
+    ):
+        response = offload_response(request, response)
+
+    tasks = BackgroundTasks()
+
     if local_cache["provider"] != "local" and api.monitoring is not None:
         try:
             predicted_value = api.monitoring.extract_predicted_value(prediction)
@@ -206,16 +258,64 @@ def predict(request: Request):
                 api.monitoring.model_type == "classification"
                 and predicted_value not in local_cache["class_set"]
             ):
-                tasks = BackgroundTasks()
                 tasks.add_task(api.upload_class, class_name=predicted_value)
                 local_cache["class_set"].add(predicted_value)
-                response.background = tasks
         except:
             cx_logger().warn("unable to record prediction metric", exc_info=True)
 
+    if getattr(request.state, "log_request", False):
+        tasks.add_task(upload_request_log, record=request_log_record(request, response))
+
+    if len(tasks.tasks) > 0:
+        response.background = tasks
+
     return response
 
 
+def request_log_record(request: Request, response: Response):
+    # must be kept in sync with requestLogRecord in pkg/operator/resources/syncapi/replay.go
+    return {
+        "request_id": request.headers["x-request-id"],
+        "api_id": local_cache["api"].id,
+        "timestamp": request.state.start_time,
+        "content_type": request.headers.get("content-type", ""),
+        "query_params": str(request.query_params),
+        "payload": base64.b64encode(request.state.raw_payload).decode(),
+        "status_code": response.status_code,
+        "latency_ms": (time.time() - request.state.start_time) * 1000,
+        "response_content_type": response.headers.get("content-type", ""),
+        "response": base64.b64encode(getattr(response, "body", b"")).decode(),
+    }
+
+
+def upload_request_log(record):
+    try:
+        local_cache["api"].upload_request_log(record)
+    except:
+        cx_logger().warn("unable to write the request to the request log", exc_info=True)
+
+
+def offload_response(request: Request, response: Response):
+    """Write an oversized response to S3 and redirect the client to it, to bound gateway memory"""
+    api = local_cache["api"]
+    try:
+        url = api.upload_response(
+            request.headers["x-request-id"],
+            response.body,
+            response.media_type,
+            OFFLOADED_RESPONSE_URL_EXPIRATION,
+        )
+    except:
+        cx_logger().warn("unable to write the response to s3", exc_info=True)
+        return response
+
+    return JSONResponse(
+        content={"url": url, "expires_in": OFFLOADED_RESPONSE_URL_EXPIRATION},
+        status_code=303,
+        headers={"location": url},
+    )
+
+
 def build_predict_args(request: Request):
     args = {}
 
@@ -260,6 +360,7 @@ def start_fn():
         storage = LocalStorage(os.getenv("CORTEX_CACHE_DIR"))
     else:
         storage = S3(bucket=os.environ["CORTEX_BUCKET"], region=os.environ["AWS_REGION"])
+        enable_crash_reports()
 
     has_multiple_servers = os.getenv("CORTEX_MULTIPLE_TF_SERVERS")
     if has_multiple_servers:
@@ -20,6 +20,9 @@ import json
 from cortex.lib.type import get_spec
 from cortex.lib.storage import S3, LocalStorage
 from cortex.lib.checkers.pod import wait_neuron_rtd
+from cortex.lib.environment import record_environment
+from cortex.lib.crash import upload_crash_reports
+from cortex.lib.log import cx_logger
 
 
 def load_tensorflow_serving_models():
@@ -77,6 +80,17 @@ def main():
         storage = S3(bucket=os.environ["CORTEX_BUCKET"], region=os.environ["AWS_REGION"])
     raw_api_spec = get_spec(provider, storage, cache_dir, spec_path)
 
+    # record the resolved environment so that the deployment can be reproduced
+    if provider != "local":
+        try:
+            record_environment(storage, spec_path)
+        except Exception as e:
+            cx_logger().warn(f"unable to record the environment: {e}")
+        try:
+            upload_crash_reports(storage, spec_path)
+        except Exception as e:
+            cx_logger().warn(f"unable to upload crash reports: {e}")
+
     # load tensorflow models into TFS
     if raw_api_spec["predictor"]["type"] == "tensorflow":
         load_tensorflow_serving_models()